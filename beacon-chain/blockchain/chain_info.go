@@ -9,6 +9,7 @@ import (
 	"github.com/prysmaticlabs/go-ssz"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/epoch/precompute"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	f "github.com/prysmaticlabs/prysm/beacon-chain/forkchoice"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state"
 	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
@@ -59,6 +60,16 @@ type FinalizationFetcher interface {
 	PreviousJustifiedCheckpt() *ethpb.Checkpoint
 }
 
+// ForkChoiceFetcher retrieves the fork choice store of the blockchain service.
+type ForkChoiceFetcher interface {
+	ForkChoiceStore() f.ForkChoicer
+}
+
+// WeakSubjectivityFetcher retrieves the status of the weak subjectivity checkpoint check.
+type WeakSubjectivityFetcher interface {
+	WeakSubjectivityStatus() string
+}
+
 // ParticipationFetcher defines a common interface for methods in blockchain service which
 // directly retrieves validator participation related data.
 type ParticipationFetcher interface {
@@ -222,3 +233,8 @@ func (s *Service) Participation(epoch uint64) *precompute.Balance {
 
 	return s.epochParticipation[epoch]
 }
+
+// ForkChoiceStore returns the fork choice store of the blockchain service.
+func (s *Service) ForkChoiceStore() f.ForkChoicer {
+	return s.forkChoiceStore
+}
@@ -0,0 +1,80 @@
+package blockchain
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/state"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/slotutil"
+	"github.com/sirupsen/logrus"
+	"go.opencensus.io/trace"
+)
+
+// This advances a copy of the head state to the next epoch late in the last slot of every epoch, so that
+// the skip slot cache and the committee caches are already populated by the time the first block of the
+// next epoch (or a duty request for it) arrives.
+func (s *Service) precomputeNextEpochRoutine() {
+	secondsPerSlot := params.BeaconConfig().SecondsPerSlot
+	// Wait until most of the slot has passed, the head is unlikely to change after that.
+	delay := time.Duration(secondsPerSlot) * time.Second * 2 / 3
+
+	st := slotutil.GetSlotTicker(s.genesisTime, secondsPerSlot)
+	defer st.Done()
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case slot := <-st.C():
+			if (slot+1)%params.BeaconConfig().SlotsPerEpoch != 0 {
+				continue
+			}
+			select {
+			case <-s.ctx.Done():
+				return
+			case <-time.After(delay):
+			}
+			if err := s.precomputeNextEpoch(s.ctx, helpers.SlotToEpoch(slot)+1); err != nil {
+				log.WithError(err).Warn("Could not precompute next epoch")
+			}
+		}
+	}
+}
+
+// precomputeNextEpoch processes slots of a head state copy up to the start of the given epoch and fills
+// the committee and proposer caches for it. Nothing is done if the head is not in the preceding epoch,
+// as the resulting state would not be the pre state of the next block anyway.
+func (s *Service) precomputeNextEpoch(ctx context.Context, nextEpoch uint64) error {
+	ctx, span := trace.StartSpan(ctx, "beacon-chain.blockchain.precomputeNextEpoch")
+	defer span.End()
+
+	headState, err := s.HeadState(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not get head state")
+	}
+	if headState == nil || helpers.SlotToEpoch(headState.Slot())+1 != nextEpoch {
+		return nil
+	}
+
+	start := time.Now()
+	// The skip slot cache is keyed by the head state slot, which is what the pre state of the next
+	// block is going to be if the head doesn't change.
+	nextState, err := state.ProcessSlots(ctx, headState, helpers.StartSlot(nextEpoch))
+	if err != nil {
+		return errors.Wrap(err, "could not process slots")
+	}
+	if err := helpers.UpdateCommitteeCache(nextState, nextEpoch); err != nil {
+		return errors.Wrap(err, "could not update committee cache")
+	}
+	if err := helpers.UpdateProposerIndicesInCache(nextState, nextEpoch); err != nil {
+		return errors.Wrap(err, "could not update proposer indices cache")
+	}
+
+	log.WithFields(logrus.Fields{
+		"epoch":   nextEpoch,
+		"elapsed": time.Since(start),
+	}).Debug("Precomputed next epoch")
+	return nil
+}
@@ -0,0 +1,66 @@
+package blockchain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/state"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+)
+
+func TestPrecomputeNextEpoch_PopulatesSkipSlotCache(t *testing.T) {
+	state.SkipSlotCache.Enable()
+	defer state.SkipSlotCache.Disable()
+	ctx := context.Background()
+
+	headState, _ := testutil.DeterministicGenesisState(t, 64)
+	headSlot := params.BeaconConfig().SlotsPerEpoch - 1
+	if err := headState.SetSlot(headSlot); err != nil {
+		t.Fatal(err)
+	}
+	s := &Service{head: &head{slot: headSlot, state: headState}}
+
+	if err := s.precomputeNextEpoch(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
+	cached, err := state.SkipSlotCache.Get(ctx, headSlot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cached == nil {
+		t.Fatal("Expected next epoch state in skip slot cache")
+	}
+	if cached.Slot() != params.BeaconConfig().SlotsPerEpoch {
+		t.Errorf("Wanted cached state slot %d, got %d", params.BeaconConfig().SlotsPerEpoch, cached.Slot())
+	}
+
+	// The cached state is used as is when processing up to the next epoch start slot.
+	nextState, err := state.ProcessSlots(ctx, headState, params.BeaconConfig().SlotsPerEpoch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if nextState.Slot() != params.BeaconConfig().SlotsPerEpoch {
+		t.Errorf("Wanted state slot %d, got %d", params.BeaconConfig().SlotsPerEpoch, nextState.Slot())
+	}
+}
+
+func TestPrecomputeNextEpoch_HeadNotInPreviousEpoch(t *testing.T) {
+	state.SkipSlotCache.Enable()
+	defer state.SkipSlotCache.Disable()
+	ctx := context.Background()
+
+	headState, _ := testutil.DeterministicGenesisState(t, 64)
+	s := &Service{head: &head{state: headState}}
+
+	if err := s.precomputeNextEpoch(ctx, 2); err != nil {
+		t.Fatal(err)
+	}
+	cached, err := state.SkipSlotCache.Get(ctx, headState.Slot())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cached != nil && cached.Slot() != 0 {
+		t.Errorf("Did not expect head state to be advanced, got cached state at slot %d", cached.Slot())
+	}
+}
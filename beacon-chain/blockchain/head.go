@@ -2,14 +2,18 @@ package blockchain
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/pkg/errors"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
+	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state"
 	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
 	"github.com/prysmaticlabs/prysm/shared/featureconfig"
 	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/sirupsen/logrus"
 	"go.opencensus.io/trace"
 )
 
@@ -100,6 +104,20 @@ func (s *Service) saveHead(ctx context.Context, headRoot [32]byte) error {
 		return errors.New("cannot save nil head state")
 	}
 
+	// A new head which does not descend from the previous head means the chain reorganized.
+	// The new head may skip over several blocks descending from the previous head, so the
+	// previous head is only known not to be an ancestor once the common ancestor is found.
+	var reorg *statefeed.ReorgData
+	if s.hasHeadState() && bytesutil.ToBytes32(newHeadBlock.Block.ParentRoot) != s.headRoot() {
+		reorg, err = s.reorgData(ctx, s.headRoot(), s.headSlot(), headRoot, newHeadBlock.Block.Slot)
+		if err != nil {
+			log.WithError(err).Warn("Could not determine common ancestor of reorg")
+		}
+		if reorg != nil && reorg.CommonAncestorRoot == reorg.OldHeadRoot {
+			reorg = nil
+		}
+	}
+
 	// Cache the new head info.
 	s.setHead(headRoot, newHeadBlock, newHeadState)
 
@@ -108,9 +126,96 @@ func (s *Service) saveHead(ctx context.Context, headRoot [32]byte) error {
 		return errors.Wrap(err, "could not save head root in DB")
 	}
 
+	if reorg != nil {
+		reorgCount.Inc()
+		reorgDepth.Observe(float64(reorg.Depth))
+		reorgNewChainLength.Observe(float64(reorg.NewHeadSlot - reorg.CommonAncestorSlot))
+		log.WithFields(logrus.Fields{
+			"oldHeadRoot":        fmt.Sprintf("%#x", bytesutil.Trunc(reorg.OldHeadRoot[:])),
+			"newHeadRoot":        fmt.Sprintf("%#x", bytesutil.Trunc(reorg.NewHeadRoot[:])),
+			"commonAncestorSlot": reorg.CommonAncestorSlot,
+			"depth":              reorg.Depth,
+		}).Info("Chain reorg occurred")
+		s.stateNotifier.StateFeed().Send(&feed.Event{
+			Type: statefeed.Reorg,
+			Data: reorg,
+		})
+	}
+
+	s.stateNotifier.StateFeed().Send(&feed.Event{
+		Type: statefeed.NewHead,
+		Data: &statefeed.NewHeadData{
+			Slot:      newHeadBlock.Block.Slot,
+			BlockRoot: headRoot,
+		},
+	})
+
 	return nil
 }
 
+// This returns the reorg information between the old and new head, it finds the common ancestor
+// by walking back the chains of both heads until they meet.
+func (s *Service) reorgData(ctx context.Context, oldRoot [32]byte, oldSlot uint64, newRoot [32]byte, newSlot uint64) (*statefeed.ReorgData, error) {
+	ctx, span := trace.StartSpan(ctx, "blockchain.reorgData")
+	defer span.End()
+
+	oldAncestor, oldAncestorSlot := oldRoot, oldSlot
+	newAncestor, newAncestorSlot := newRoot, newSlot
+	for oldAncestor != newAncestor {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		var err error
+		if oldAncestorSlot >= newAncestorSlot {
+			oldAncestor, oldAncestorSlot, err = s.parentRootAndSlot(ctx, oldAncestor)
+		} else {
+			newAncestor, newAncestorSlot, err = s.parentRootAndSlot(ctx, newAncestor)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &statefeed.ReorgData{
+		OldHeadRoot:        oldRoot,
+		OldHeadSlot:        oldSlot,
+		NewHeadRoot:        newRoot,
+		NewHeadSlot:        newSlot,
+		CommonAncestorRoot: oldAncestor,
+		CommonAncestorSlot: oldAncestorSlot,
+		Depth:              oldSlot - oldAncestorSlot,
+	}, nil
+}
+
+// This returns the parent root and parent slot of a block, the blocks are read from the
+// initial sync cache or the DB.
+func (s *Service) parentRootAndSlot(ctx context.Context, root [32]byte) ([32]byte, uint64, error) {
+	b, err := s.blockByRoot(ctx, root)
+	if err != nil {
+		return [32]byte{}, 0, err
+	}
+	parentRoot := bytesutil.ToBytes32(b.Block.ParentRoot)
+	parent, err := s.blockByRoot(ctx, parentRoot)
+	if err != nil {
+		return [32]byte{}, 0, err
+	}
+	return parentRoot, parent.Block.Slot, nil
+}
+
+func (s *Service) blockByRoot(ctx context.Context, root [32]byte) (*ethpb.SignedBeaconBlock, error) {
+	if s.hasInitSyncBlock(root) {
+		return s.getInitSyncBlock(root), nil
+	}
+	b, err := s.beaconDB.Block(ctx, root)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get block")
+	}
+	if b == nil || b.Block == nil {
+		return nil, fmt.Errorf("block %#x not found", bytesutil.Trunc(root[:]))
+	}
+	return b, nil
+}
+
 // This gets called to update canonical root mapping. It does not save head block
 // root in DB. With the inception of inital-sync-cache-state flag, it uses finalized
 // check point as anchors to resume sync therefore head is no longer needed to be saved on per slot basis.
@@ -8,6 +8,8 @@ import (
 
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/prysmaticlabs/go-ssz"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
+	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
 	testDB "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
 	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
 	"github.com/prysmaticlabs/prysm/shared/testutil"
@@ -84,3 +86,185 @@ func TestSaveHead_Different(t *testing.T) {
 		t.Error("Head did not change")
 	}
 }
+
+func TestSaveHead_Reorg(t *testing.T) {
+	ctx := context.Background()
+	db := testDB.SetupDB(t)
+	defer testDB.TeardownDB(t, db)
+	service := setupBeaconChain(t, db)
+
+	// Build the chains:
+	//   0 <- 1 (old head)
+	//    \
+	//     <- 2 <- 3 (new head)
+	saveBlock := func(slot uint64, parentRoot [32]byte) [32]byte {
+		b := &ethpb.SignedBeaconBlock{Block: &ethpb.BeaconBlock{Slot: slot, ParentRoot: parentRoot[:]}}
+		if err := db.SaveBlock(ctx, b); err != nil {
+			t.Fatal(err)
+		}
+		r, err := ssz.HashTreeRoot(b.Block)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return r
+	}
+	genesisRoot := saveBlock(0, [32]byte{})
+	oldRoot := saveBlock(1, genesisRoot)
+	forkRoot := saveBlock(2, genesisRoot)
+	newRoot := saveBlock(3, forkRoot)
+
+	service.head = &head{slot: 1, root: oldRoot, state: testutil.NewBeaconState()}
+	headState := testutil.NewBeaconState()
+	if err := headState.SetSlot(3); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveStateSummary(ctx, &pb.StateSummary{Slot: 3, Root: newRoot[:]}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveState(ctx, headState, newRoot); err != nil {
+		t.Fatal(err)
+	}
+
+	stateChannel := make(chan *feed.Event, 2)
+	stateSub := service.stateNotifier.StateFeed().Subscribe(stateChannel)
+	defer stateSub.Unsubscribe()
+	if err := service.saveHead(ctx, newRoot); err != nil {
+		t.Fatal(err)
+	}
+
+	event := <-stateChannel
+	if event.Type != statefeed.Reorg {
+		t.Fatalf("Expected reorg event, got %d", event.Type)
+	}
+	data, ok := event.Data.(*statefeed.ReorgData)
+	if !ok {
+		t.Fatal("Event data is not type *statefeed.ReorgData")
+	}
+	want := &statefeed.ReorgData{
+		OldHeadRoot:        oldRoot,
+		OldHeadSlot:        1,
+		NewHeadRoot:        newRoot,
+		NewHeadSlot:        3,
+		CommonAncestorRoot: genesisRoot,
+		CommonAncestorSlot: 0,
+		Depth:              1,
+	}
+	if !reflect.DeepEqual(data, want) {
+		t.Errorf("Unexpected reorg data, wanted %v, got %v", want, data)
+	}
+}
+
+func TestSaveHead_NoReorgWhenExtendingHead(t *testing.T) {
+	ctx := context.Background()
+	db := testDB.SetupDB(t)
+	defer testDB.TeardownDB(t, db)
+	service := setupBeaconChain(t, db)
+
+	oldRoot := [32]byte{'A'}
+	service.head = &head{slot: 0, root: oldRoot, state: testutil.NewBeaconState()}
+	b := &ethpb.SignedBeaconBlock{Block: &ethpb.BeaconBlock{Slot: 1, ParentRoot: oldRoot[:]}}
+	if err := db.SaveBlock(ctx, b); err != nil {
+		t.Fatal(err)
+	}
+	newRoot, err := ssz.HashTreeRoot(b.Block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveStateSummary(ctx, &pb.StateSummary{Slot: 1, Root: newRoot[:]}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveState(ctx, testutil.NewBeaconState(), newRoot); err != nil {
+		t.Fatal(err)
+	}
+
+	stateChannel := make(chan *feed.Event, 1)
+	stateSub := service.stateNotifier.StateFeed().Subscribe(stateChannel)
+	defer stateSub.Unsubscribe()
+	if err := service.saveHead(ctx, newRoot); err != nil {
+		t.Fatal(err)
+	}
+	event := <-stateChannel
+	if event.Type != statefeed.NewHead {
+		t.Errorf("Expected only a new head event, got %d", event.Type)
+	}
+}
+
+func TestSaveHead_NoReorgWhenSkippingDescendants(t *testing.T) {
+	ctx := context.Background()
+	db := testDB.SetupDB(t)
+	defer testDB.TeardownDB(t, db)
+	service := setupBeaconChain(t, db)
+
+	// The new head descends from the old head through a block which never became head:
+	//   0 <- 1 (old head) <- 2 <- 3 (new head)
+	saveBlock := func(slot uint64, parentRoot [32]byte) [32]byte {
+		b := &ethpb.SignedBeaconBlock{Block: &ethpb.BeaconBlock{Slot: slot, ParentRoot: parentRoot[:]}}
+		if err := db.SaveBlock(ctx, b); err != nil {
+			t.Fatal(err)
+		}
+		r, err := ssz.HashTreeRoot(b.Block)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return r
+	}
+	genesisRoot := saveBlock(0, [32]byte{})
+	oldRoot := saveBlock(1, genesisRoot)
+	newRoot := saveBlock(3, saveBlock(2, oldRoot))
+
+	service.head = &head{slot: 1, root: oldRoot, state: testutil.NewBeaconState()}
+	if err := db.SaveStateSummary(ctx, &pb.StateSummary{Slot: 3, Root: newRoot[:]}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveState(ctx, testutil.NewBeaconState(), newRoot); err != nil {
+		t.Fatal(err)
+	}
+
+	stateChannel := make(chan *feed.Event, 2)
+	stateSub := service.stateNotifier.StateFeed().Subscribe(stateChannel)
+	defer stateSub.Unsubscribe()
+	if err := service.saveHead(ctx, newRoot); err != nil {
+		t.Fatal(err)
+	}
+	event := <-stateChannel
+	if event.Type != statefeed.NewHead {
+		t.Errorf("Expected only a new head event, got %d", event.Type)
+	}
+}
+
+func TestSaveHead_NotifiesNewHead(t *testing.T) {
+	ctx := context.Background()
+	db := testDB.SetupDB(t)
+	defer testDB.TeardownDB(t, db)
+	service := setupBeaconChain(t, db)
+
+	b := &ethpb.SignedBeaconBlock{Block: &ethpb.BeaconBlock{Slot: 1}}
+	if err := db.SaveBlock(ctx, b); err != nil {
+		t.Fatal(err)
+	}
+	newRoot, err := ssz.HashTreeRoot(b.Block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveStateSummary(ctx, &pb.StateSummary{Slot: 1, Root: newRoot[:]}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveState(ctx, testutil.NewBeaconState(), newRoot); err != nil {
+		t.Fatal(err)
+	}
+
+	stateChannel := make(chan *feed.Event, 1)
+	stateSub := service.stateNotifier.StateFeed().Subscribe(stateChannel)
+	defer stateSub.Unsubscribe()
+	if err := service.saveHead(ctx, newRoot); err != nil {
+		t.Fatal(err)
+	}
+	event := <-stateChannel
+	data, ok := event.Data.(*statefeed.NewHeadData)
+	if event.Type != statefeed.NewHead || !ok {
+		t.Fatalf("Expected new head event, got %d", event.Type)
+	}
+	if data.Slot != 1 || data.BlockRoot != newRoot {
+		t.Errorf("Unexpected new head data %v", data)
+	}
+}
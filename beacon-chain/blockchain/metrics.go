@@ -27,6 +27,20 @@ var (
 		Name: "competing_blocks",
 		Help: "The # of blocks received and processed from a competing chain",
 	})
+	reorgCount = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "beacon_reorg_total",
+		Help: "Count the number of times the head switched to a block not descending from the previous head",
+	})
+	reorgDepth = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "beacon_reorg_depth",
+		Help:    "The number of slots rolled back from the old head to the common ancestor on a reorg",
+		Buckets: []float64{1, 2, 3, 4, 8, 16, 32, 64},
+	})
+	reorgNewChainLength = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "beacon_reorg_new_chain_length",
+		Help:    "The number of slots from the common ancestor to the new head on a reorg",
+		Buckets: []float64{1, 2, 3, 4, 8, 16, 32, 64},
+	})
 	headFinalizedEpoch = promauto.NewGauge(prometheus.GaugeOpts{
 		Name: "head_finalized_epoch",
 		Help: "Last finalized epoch of the head state",
@@ -1,6 +1,7 @@
 package blockchain
 
 import (
+	"bytes"
 	"context"
 	"encoding/hex"
 	"fmt"
@@ -13,9 +14,11 @@ import (
 	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state/stateutil"
 	"github.com/prysmaticlabs/prysm/shared/attestationutil"
+	"github.com/prysmaticlabs/prysm/shared/bls"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
 	"github.com/prysmaticlabs/prysm/shared/featureconfig"
 	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/sliceutil"
 	"github.com/sirupsen/logrus"
 	"go.opencensus.io/trace"
 )
@@ -83,6 +86,9 @@ func (s *Service) onBlock(ctx context.Context, signed *ethpb.SignedBeaconBlock)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not execute state transition")
 	}
+	if err := s.verifyWeakSubjectivityPostState(ctx, b.ParentRoot, postState); err != nil {
+		return nil, err
+	}
 
 	if err := s.beaconDB.SaveBlock(ctx, signed); err != nil {
 		return nil, errors.Wrapf(err, "could not save block from slot %d", b.Slot)
@@ -91,6 +97,10 @@ func (s *Service) onBlock(ctx context.Context, signed *ethpb.SignedBeaconBlock)
 	if err := s.insertBlockToForkChoiceStore(ctx, b, root, postState); err != nil {
 		return nil, errors.Wrapf(err, "could not insert block %d to fork choice store", b.Slot)
 	}
+	s.insertSlashingsToForkChoiceStore(ctx, b.Body.AttesterSlashings)
+	if featureconfig.Get().EnableProposerBoost && s.isTimelyBlock(b.Slot) {
+		s.forkChoiceStore.BoostProposerRoot(ctx, root)
+	}
 
 	if featureconfig.Get().NewStateMgmt {
 		if err := s.stateGen.SaveState(ctx, root, postState); err != nil {
@@ -142,6 +152,7 @@ func (s *Service) onBlock(ctx context.Context, signed *ethpb.SignedBeaconBlock)
 
 		s.prevFinalizedCheckpt = s.finalizedCheckpt
 		s.finalizedCheckpt = postState.FinalizedCheckpoint()
+		s.notifyFinalizedCheckpoint(s.finalizedCheckpt)
 
 		if err := s.finalizedImpliesNewJustified(ctx, postState); err != nil {
 			return nil, errors.Wrap(err, "could not save new justified")
@@ -216,11 +227,88 @@ func (s *Service) onBlockInitialSyncStateTransition(ctx context.Context, signed
 	if err != nil {
 		return errors.Wrap(err, "could not execute state transition")
 	}
+	if err := s.verifyWeakSubjectivityPostState(ctx, b.ParentRoot, postState); err != nil {
+		return err
+	}
 
 	root, err := stateutil.BlockRoot(b)
 	if err != nil {
 		return errors.Wrapf(err, "could not get signing root of block %d", b.Slot)
 	}
+	return s.saveInitSyncPostState(ctx, signed, root, postState)
+}
+
+// onBlockBatch is called when a batch of consecutive initial sync blocks is received. It runs state
+// transition on every block without verifying any of their signatures, collecting the signature sets
+// instead. The sets of the whole batch are then verified at once, and only if they are all valid the
+// blocks and their post states are saved. It returns the block roots of the batch.
+func (s *Service) onBlockBatch(ctx context.Context, blks []*ethpb.SignedBeaconBlock) ([][32]byte, error) {
+	ctx, span := trace.StartSpan(ctx, "blockchain.onBlockBatch")
+	defer span.End()
+
+	if len(blks) == 0 {
+		return nil, errors.New("no blocks provided")
+	}
+	for _, signed := range blks {
+		if signed == nil || signed.Block == nil {
+			return nil, errors.New("nil block")
+		}
+	}
+
+	// Retrieve the pre state of the first block, the rest of the batch builds on top of it.
+	preState, err := s.verifyBlkPreState(ctx, blks[0].Block)
+	if err != nil {
+		return nil, err
+	}
+	if preState.Slot() >= blks[0].Block.Slot {
+		return nil, fmt.Errorf("pre state slot %d is not lower than first block slot %d", preState.Slot(), blks[0].Block.Slot)
+	}
+
+	set := bls.NewSet()
+	roots := make([][32]byte, len(blks))
+	postStates := make([]*stateTrie.BeaconState, len(blks))
+	for i, signed := range blks {
+		if i > 0 && !bytes.Equal(signed.Block.ParentRoot, roots[i-1][:]) {
+			return nil, fmt.Errorf("block at slot %d does not build on the previous block of the batch", signed.Block.Slot)
+		}
+		blkSet, postState, err := state.ExecuteStateTransitionNoVerifyAnySig(ctx, preState, signed)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not execute state transition")
+		}
+		// The blocks of the batch are not stored yet, the batch builds on the stored parent of its first block.
+		if err := s.verifyWeakSubjectivityPostState(ctx, blks[0].Block.ParentRoot, postState); err != nil {
+			return nil, err
+		}
+		set.Join(blkSet)
+		roots[i], err = stateutil.BlockRoot(signed.Block)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not get signing root of block %d", signed.Block.Slot)
+		}
+		// The state transition modifies its input, so each block keeps its own copy of the post state.
+		postStates[i] = postState.Copy()
+		preState = postState
+	}
+
+	verified, err := verifySignatureSet(set)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not verify batch signatures")
+	}
+	if !verified {
+		return nil, errors.New("batch signature verification failed")
+	}
+
+	for i, signed := range blks {
+		if err := s.saveInitSyncPostState(ctx, signed, roots[i], postStates[i]); err != nil {
+			return nil, err
+		}
+	}
+	return roots, nil
+}
+
+// saveInitSyncPostState saves an initial sync block along with its post state, then updates the
+// fork choice store and the justified and finalized checkpoints accordingly.
+func (s *Service) saveInitSyncPostState(ctx context.Context, signed *ethpb.SignedBeaconBlock, root [32]byte, postState *stateTrie.BeaconState) error {
+	b := signed.Block
 	if !featureconfig.Get().NoInitSyncBatchSaveBlocks {
 		s.saveInitSyncBlock(root, signed)
 	} else {
@@ -232,6 +320,7 @@ func (s *Service) onBlockInitialSyncStateTransition(ctx context.Context, signed
 	if err := s.insertBlockToForkChoiceStore(ctx, b, root, postState); err != nil {
 		return errors.Wrapf(err, "could not insert block %d to fork choice store", b.Slot)
 	}
+	s.insertSlashingsToForkChoiceStore(ctx, b.Body.AttesterSlashings)
 
 	if featureconfig.Get().NewStateMgmt {
 		if err := s.stateGen.SaveState(ctx, root, postState); err != nil {
@@ -296,6 +385,7 @@ func (s *Service) onBlockInitialSyncStateTransition(ctx context.Context, signed
 
 		s.prevFinalizedCheckpt = s.finalizedCheckpt
 		s.finalizedCheckpt = postState.FinalizedCheckpoint()
+		s.notifyFinalizedCheckpoint(s.finalizedCheckpt)
 
 		if err := s.finalizedImpliesNewJustified(ctx, postState); err != nil {
 			return errors.Wrap(err, "could not save new justified")
@@ -316,7 +406,7 @@ func (s *Service) onBlockInitialSyncStateTransition(ctx context.Context, signed
 	if !featureconfig.Get().NewStateMgmt {
 		numOfStates := len(s.boundaryRoots)
 		if numOfStates > initialSyncCacheSize {
-			if err = s.persistCachedStates(ctx, numOfStates); err != nil {
+			if err := s.persistCachedStates(ctx, numOfStates); err != nil {
 				return err
 			}
 		}
@@ -375,3 +465,17 @@ func (s *Service) insertBlockToForkChoiceStore(ctx context.Context, blk *ethpb.B
 
 	return nil
 }
+
+// This feeds in the validators slashed by the block's attester slashings to fork choice store,
+// their votes are no longer accounted for as they equivocated.
+func (s *Service) insertSlashingsToForkChoiceStore(ctx context.Context, slashings []*ethpb.AttesterSlashing) {
+	for _, slashing := range slashings {
+		if slashing.Attestation_1 == nil || slashing.Attestation_2 == nil {
+			continue
+		}
+		indices := sliceutil.IntersectionUint64(slashing.Attestation_1.AttestingIndices, slashing.Attestation_2.AttestingIndices)
+		for _, index := range indices {
+			s.forkChoiceStore.InsertSlashedIndex(ctx, index)
+		}
+	}
+}
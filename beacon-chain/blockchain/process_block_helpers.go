@@ -4,15 +4,20 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"sync"
 
 	"github.com/pkg/errors"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/prysmaticlabs/go-ssz"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
+	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/beacon-chain/db/filters"
 	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/shared/bls"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
 	"github.com/prysmaticlabs/prysm/shared/featureconfig"
+	"github.com/prysmaticlabs/prysm/shared/mputil"
 	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/prysmaticlabs/prysm/shared/roughtime"
 	"github.com/prysmaticlabs/prysm/shared/traceutil"
@@ -29,6 +34,32 @@ func (s *Service) CurrentSlot() uint64 {
 	return uint64(now-genesis) / params.BeaconConfig().SecondsPerSlot
 }
 
+// isTimelyBlock returns true if the block of the given slot is received during that same slot,
+// before the attestation deadline of the slot.
+func (s *Service) isTimelyBlock(slot uint64) bool {
+	now := roughtime.Now().Unix()
+	genesis := s.genesisTime.Unix()
+	if now < genesis {
+		return false
+	}
+	secondsSinceGenesis := uint64(now - genesis)
+	currentSlot := secondsSinceGenesis / params.BeaconConfig().SecondsPerSlot
+	timeIntoSlot := secondsSinceGenesis % params.BeaconConfig().SecondsPerSlot
+	attestationDeadline := params.BeaconConfig().SecondsPerSlot / params.BeaconConfig().IntervalsPerSlot
+	return currentSlot == slot && timeIntoSlot < attestationDeadline
+}
+
+// notifyFinalizedCheckpoint sends a finalized checkpoint event to the state feed subscribers.
+func (s *Service) notifyFinalizedCheckpoint(cp *ethpb.Checkpoint) {
+	s.stateNotifier.StateFeed().Send(&feed.Event{
+		Type: statefeed.FinalizedCheckpoint,
+		Data: &statefeed.FinalizedCheckpointData{
+			Epoch:     cp.Epoch,
+			BlockRoot: bytesutil.ToBytes32(cp.Root),
+		},
+	})
+}
+
 // getBlockPreState returns the pre state of an incoming block. It uses the parent root of the block
 // to retrieve the state in DB. It verifies the pre state's validity and the incoming block
 // is in the correct time window.
@@ -498,3 +529,36 @@ func (s *Service) deletePoolAtts(atts []*ethpb.Attestation) error {
 
 	return nil
 }
+
+// verifySignatureSet verifies the provided signature set as a multi-signature batch. The set is split in
+// chunks that are each verified by a separate worker goroutine, and it is only valid if every chunk is.
+func verifySignatureSet(set *bls.SignatureSet) (bool, error) {
+	if len(set.Signatures) == 0 {
+		return true, nil
+	}
+	if len(set.Signatures) != len(set.PublicKeys) || len(set.Signatures) != len(set.Messages) {
+		return false, fmt.Errorf(
+			"signature set has differing lengths. S: %d, P: %d, M: %d",
+			len(set.Signatures),
+			len(set.PublicKeys),
+			len(set.Messages),
+		)
+	}
+	results, err := mputil.Scatter(len(set.Signatures), func(offset int, entries int, _ *sync.RWMutex) (interface{}, error) {
+		return bls.VerifyMultipleSignatures(
+			set.Signatures[offset:offset+entries],
+			set.Messages[offset:offset+entries],
+			set.PublicKeys[offset:offset+entries],
+		)
+	})
+	if err != nil {
+		return false, err
+	}
+	for _, result := range results {
+		verified, ok := result.Extent.(bool)
+		if !ok || !verified {
+			return false, nil
+		}
+	}
+	return true, nil
+}
@@ -12,12 +12,14 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/blocks"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/state"
 	"github.com/prysmaticlabs/prysm/beacon-chain/db"
 	testDB "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
 	"github.com/prysmaticlabs/prysm/beacon-chain/forkchoice/protoarray"
 	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state/stategen"
 	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/bls"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
 	"github.com/prysmaticlabs/prysm/shared/featureconfig"
 	"github.com/prysmaticlabs/prysm/shared/params"
@@ -778,3 +780,160 @@ func TestCurrentSlot_HandlesOverflow(t *testing.T) {
 		t.Fatalf("Expected slot to be 0, got %d", slot)
 	}
 }
+
+func TestIsTimelyBlock(t *testing.T) {
+	secondsPerSlot := params.BeaconConfig().SecondsPerSlot
+	svc := Service{genesisTime: roughtime.Now().Add(-time.Duration(5*secondsPerSlot) * time.Second)}
+	if !svc.isTimelyBlock(5) {
+		t.Error("Expected block of the current slot received at the start of the slot to be timely")
+	}
+	if svc.isTimelyBlock(4) {
+		t.Error("Expected block of a previous slot not to be timely")
+	}
+
+	// Received after the attestation deadline of its slot.
+	deadline := secondsPerSlot / params.BeaconConfig().IntervalsPerSlot
+	svc.genesisTime = svc.genesisTime.Add(-time.Duration(deadline) * time.Second)
+	if svc.isTimelyBlock(5) {
+		t.Error("Expected block received after the attestation deadline not to be timely")
+	}
+}
+
+func TestInsertSlashingsToForkChoiceStore(t *testing.T) {
+	ctx := context.Background()
+	svc := Service{forkChoiceStore: protoarray.New(0, 0, [32]byte{})}
+	root := [32]byte{'a'}
+	if err := svc.forkChoiceStore.ProcessBlock(ctx, 0, root, [32]byte{}, 0, 0); err != nil {
+		t.Fatal(err)
+	}
+	svc.forkChoiceStore.ProcessAttestation(ctx, []uint64{0, 1, 2}, root, 1)
+	if _, err := svc.forkChoiceStore.Head(ctx, 0, root, []uint64{10, 10, 10}, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	// Only validator 1 attested to both conflicting attestations.
+	slashings := []*ethpb.AttesterSlashing{
+		{
+			Attestation_1: &ethpb.IndexedAttestation{AttestingIndices: []uint64{0, 1}},
+			Attestation_2: &ethpb.IndexedAttestation{AttestingIndices: []uint64{1, 2}},
+		},
+	}
+	svc.insertSlashingsToForkChoiceStore(ctx, slashings)
+	if w := svc.forkChoiceStore.Node(root).Weight; w != 20 {
+		t.Errorf("Expected weight of 20 after removing the slashed vote, got %d", w)
+	}
+}
+
+func TestVerifySignatureSet(t *testing.T) {
+	set := bls.NewSet()
+	for i := 0; i < 20; i++ {
+		msg := [32]byte{'s', 'y', 'n', 'c', byte(i)}
+		priv := bls.RandKey()
+		set.Join(&bls.SignatureSet{
+			Signatures: []*bls.Signature{priv.Sign(msg[:])},
+			PublicKeys: []*bls.PublicKey{priv.PublicKey()},
+			Messages:   [][32]byte{msg},
+		})
+	}
+	verified, err := verifySignatureSet(set)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !verified {
+		t.Error("Expected signature set to verify")
+	}
+
+	// Tamper with the last signature, so only one of the workers sees an invalid chunk.
+	set.Signatures[len(set.Signatures)-1] = set.Signatures[0]
+	verified, err = verifySignatureSet(set)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if verified {
+		t.Error("Expected tampered signature set to fail verification")
+	}
+}
+
+func TestOnBlockBatch_NoBlocks(t *testing.T) {
+	ctx := context.Background()
+	db := testDB.SetupDB(t)
+	defer testDB.TeardownDB(t, db)
+
+	service, err := NewService(ctx, &Config{BeaconDB: db})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := service.onBlockBatch(ctx, []*ethpb.SignedBeaconBlock{}); err == nil {
+		t.Error("Expected error processing an empty batch")
+	}
+}
+
+func TestOnBlockBatch_InvalidSignatureNotSaved(t *testing.T) {
+	ctx := context.Background()
+	db := testDB.SetupDB(t)
+	defer testDB.TeardownDB(t, db)
+
+	service, err := NewService(ctx, &Config{BeaconDB: db})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	genesisState, privKeys := testutil.DeterministicGenesisState(t, 64)
+	blk1, err := testutil.GenerateFullBlock(genesisState, privKeys, testutil.DefaultBlockGenConfig(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveState(ctx, genesisState.Copy(), bytesutil.ToBytes32(blk1.Block.ParentRoot)); err != nil {
+		t.Fatal(err)
+	}
+	postState, err := state.ExecuteStateTransition(ctx, genesisState.Copy(), blk1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	blk2, err := testutil.GenerateFullBlock(postState, privKeys, testutil.DefaultBlockGenConfig(), 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The signature is not part of the block root, so the batch still links up.
+	blk2.Signature = privKeys[0].Sign([]byte("wrong message")).Marshal()
+
+	if _, err := service.onBlockBatch(ctx, []*ethpb.SignedBeaconBlock{blk1, blk2}); err == nil || !strings.Contains(err.Error(), "batch signature verification failed") {
+		t.Errorf("Expected batch signature verification failure, received %v", err)
+	}
+	root1, err := ssz.HashTreeRoot(blk1.Block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if service.hasInitSyncBlock(root1) || db.HasBlock(ctx, root1) {
+		t.Error("Block from a batch that failed verification should not have been saved")
+	}
+}
+
+func TestOnBlockBatch_BrokenParentLinkage(t *testing.T) {
+	ctx := context.Background()
+	db := testDB.SetupDB(t)
+	defer testDB.TeardownDB(t, db)
+
+	service, err := NewService(ctx, &Config{BeaconDB: db})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	genesisState, privKeys := testutil.DeterministicGenesisState(t, 64)
+	blk1, err := testutil.GenerateFullBlock(genesisState, privKeys, testutil.DefaultBlockGenConfig(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveState(ctx, genesisState.Copy(), bytesutil.ToBytes32(blk1.Block.ParentRoot)); err != nil {
+		t.Fatal(err)
+	}
+	// A sibling of the first block does not build on it.
+	sibling, err := testutil.GenerateFullBlock(genesisState, privKeys, testutil.DefaultBlockGenConfig(), 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := service.onBlockBatch(ctx, []*ethpb.SignedBeaconBlock{blk1, sibling}); err == nil || !strings.Contains(err.Error(), "does not build on the previous block") {
+		t.Errorf("Expected parent linkage error, received %v", err)
+	}
+}
@@ -88,6 +88,10 @@ func (s *Service) processAttestation(subscribedToStateEvents chan struct{}) {
 			return
 		case <-st.C():
 			ctx := context.Background()
+			// The proposer boost only lasts for the slot of the boosted block.
+			if featureconfig.Get().EnableProposerBoost {
+				s.forkChoiceStore.ResetBoostedProposerRoot(ctx)
+			}
 			atts := s.attPool.ForkchoiceAttestations()
 			for _, a := range atts {
 				var hasState bool
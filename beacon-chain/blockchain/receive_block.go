@@ -26,6 +26,7 @@ type BlockReceiver interface {
 	ReceiveBlockNoPubsub(ctx context.Context, block *ethpb.SignedBeaconBlock) error
 	ReceiveBlockNoPubsubForkchoice(ctx context.Context, block *ethpb.SignedBeaconBlock) error
 	ReceiveBlockNoVerify(ctx context.Context, block *ethpb.SignedBeaconBlock) error
+	ReceiveBlockBatch(ctx context.Context, blocks []*ethpb.SignedBeaconBlock) error
 	HasInitSyncBlock(root [32]byte) bool
 }
 
@@ -198,6 +199,45 @@ func (s *Service) ReceiveBlockNoVerify(ctx context.Context, block *ethpb.SignedB
 		return errors.Wrap(err, "could not get signing root on received blockCopy")
 	}
 
+	if err := s.handleInitSyncBlockPostTransition(ctx, blockCopy, root, false /* verified */); err != nil {
+		traceutil.AnnotateError(span, err)
+		return err
+	}
+	return nil
+}
+
+// ReceiveBlockBatch runs state transition on a batch of consecutive blocks received during initial sync.
+// The BLS signatures of all the blocks are verified together as a single batch, which is much cheaper
+// than verifying every signature on its own. If the batch does not verify, none of its blocks are saved
+// and an error is returned so the caller can fall back to processing the blocks one by one.
+func (s *Service) ReceiveBlockBatch(ctx context.Context, blocks []*ethpb.SignedBeaconBlock) error {
+	ctx, span := trace.StartSpan(ctx, "beacon-chain.blockchain.ReceiveBlockBatch")
+	defer span.End()
+	blkCopies := make([]*ethpb.SignedBeaconBlock, len(blocks))
+	for i, blk := range blocks {
+		blkCopies[i] = stateTrie.CopySignedBeaconBlock(blk)
+	}
+
+	// Apply state transition on the incoming blocks and verify their signatures as a batch.
+	roots, err := s.onBlockBatch(ctx, blkCopies)
+	if err != nil {
+		err := errors.Wrap(err, "could not process block batch")
+		traceutil.AnnotateError(span, err)
+		return err
+	}
+
+	for i, blockCopy := range blkCopies {
+		if err := s.handleInitSyncBlockPostTransition(ctx, blockCopy, roots[i], true /* verified */); err != nil {
+			traceutil.AnnotateError(span, err)
+			return err
+		}
+	}
+	return nil
+}
+
+// handleInitSyncBlockPostTransition updates the head, notifies subscribers and reports metrics
+// for an initial sync block that has gone through state transition.
+func (s *Service) handleInitSyncBlockPostTransition(ctx context.Context, blockCopy *ethpb.SignedBeaconBlock, root [32]byte, verified bool) error {
 	cachedHeadRoot, err := s.HeadRoot(ctx)
 	if err != nil {
 		return errors.Wrap(err, "could not get head root from cache")
@@ -205,9 +245,7 @@ func (s *Service) ReceiveBlockNoVerify(ctx context.Context, block *ethpb.SignedB
 
 	if !bytes.Equal(root[:], cachedHeadRoot) {
 		if err := s.saveHeadNoDB(ctx, blockCopy, root); err != nil {
-			err := errors.Wrap(err, "could not save head")
-			traceutil.AnnotateError(span, err)
-			return err
+			return errors.Wrap(err, "could not save head")
 		}
 	}
 
@@ -217,7 +255,7 @@ func (s *Service) ReceiveBlockNoVerify(ctx context.Context, block *ethpb.SignedB
 		Data: &statefeed.BlockProcessedData{
 			Slot:      blockCopy.Block.Slot,
 			BlockRoot: root,
-			Verified:  false,
+			Verified:  verified,
 		},
 	})
 
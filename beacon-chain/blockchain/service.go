@@ -77,23 +77,27 @@ type Service struct {
 	opsService             *attestations.Service
 	initSyncBlocks         map[[32]byte]*ethpb.SignedBeaconBlock
 	initSyncBlocksLock     sync.RWMutex
+	wsCheckpt              *ethpb.Checkpoint
+	wsStatus               string
+	wsLock                 sync.RWMutex
 }
 
 // Config options for the service.
 type Config struct {
-	BeaconBlockBuf    int
-	ChainStartFetcher powchain.ChainStartFetcher
-	BeaconDB          db.HeadAccessDatabase
-	DepositCache      *depositcache.DepositCache
-	AttPool           attestations.Pool
-	ExitPool          *voluntaryexits.Pool
-	SlashingPool      *slashings.Pool
-	P2p               p2p.Broadcaster
-	MaxRoutines       int64
-	StateNotifier     statefeed.Notifier
-	ForkChoiceStore   f.ForkChoicer
-	OpsService        *attestations.Service
-	StateGen          *stategen.State
+	BeaconBlockBuf          int
+	ChainStartFetcher       powchain.ChainStartFetcher
+	BeaconDB                db.HeadAccessDatabase
+	DepositCache            *depositcache.DepositCache
+	AttPool                 attestations.Pool
+	ExitPool                *voluntaryexits.Pool
+	SlashingPool            *slashings.Pool
+	P2p                     p2p.Broadcaster
+	MaxRoutines             int64
+	StateNotifier           statefeed.Notifier
+	ForkChoiceStore         f.ForkChoicer
+	OpsService              *attestations.Service
+	StateGen                *stategen.State
+	WeakSubjectivityCheckpt *ethpb.Checkpoint
 }
 
 // NewService instantiates a new block service instance that will
@@ -120,6 +124,8 @@ func NewService(ctx context.Context, cfg *Config) (*Service, error) {
 		opsService:         cfg.OpsService,
 		stateGen:           cfg.StateGen,
 		initSyncBlocks:     make(map[[32]byte]*ethpb.SignedBeaconBlock),
+		wsCheckpt:          cfg.WeakSubjectivityCheckpt,
+		wsStatus:           WeakSubjectivityPending,
 	}, nil
 }
 
@@ -181,6 +187,10 @@ func (s *Service) Start() {
 		s.prevFinalizedCheckpt = stateTrie.CopyCheckpoint(finalizedCheckpoint)
 		s.resumeForkChoice(justifiedCheckpoint, finalizedCheckpoint)
 
+		if err := s.verifyWeakSubjectivityFinalized(ctx); err != nil {
+			log.Fatalf("Could not verify weak subjectivity checkpoint: %v", err)
+		}
+
 		if !featureconfig.Get().NewStateMgmt {
 			if finalizedCheckpoint.Epoch > 1 {
 				if err := s.pruneGarbageState(ctx, helpers.StartSlot(finalizedCheckpoint.Epoch)-params.BeaconConfig().SlotsPerEpoch); err != nil {
@@ -196,6 +206,8 @@ func (s *Service) Start() {
 				GenesisValidatorsRoot: beaconState.GenesisValidatorRoot(),
 			},
 		})
+
+		go s.precomputeNextEpochRoutine()
 	} else {
 		log.Info("Waiting to reach the validator deposit threshold to start the beacon chain...")
 		if s.chainStartFetcher == nil {
@@ -232,6 +244,7 @@ func (s *Service) Start() {
 	}
 
 	go s.processAttestation(attestationProcessorSubscribed)
+	go s.saveForkChoiceStorePeriodically()
 }
 
 // processChainStartTime initializes a series of deposits from the ChainStart deposits in the eth1
@@ -249,6 +262,8 @@ func (s *Service) processChainStartTime(ctx context.Context, genesisTime time.Ti
 			GenesisValidatorsRoot: initializedState.GenesisValidatorRoot(),
 		},
 	})
+
+	go s.precomputeNextEpochRoutine()
 }
 
 // initializes the state and genesis block of the beacon chain to persistent storage
@@ -294,6 +309,9 @@ func (s *Service) initializeBeaconChain(
 // Stop the blockchain service's main event loop and associated goroutines.
 func (s *Service) Stop() error {
 	defer s.cancel()
+	if err := s.saveForkChoiceStore(s.ctx); err != nil {
+		log.WithError(err).Error("Could not save fork choice store")
+	}
 	return nil
 }
 
@@ -469,11 +487,70 @@ func (s *Service) pruneGarbageState(ctx context.Context, slot uint64) error {
 
 // This is called when a client starts from non-genesis slot. This passes last justified and finalized
 // information to fork choice service to initializes fork choice store.
+// A fork choice store saved before the shutdown is restored if it passes its integrity checks and
+// contains the finalized root, otherwise the store starts from the finalized checkpoint.
 func (s *Service) resumeForkChoice(justifiedCheckpoint *ethpb.Checkpoint, finalizedCheckpoint *ethpb.Checkpoint) {
-	store := protoarray.New(justifiedCheckpoint.Epoch, finalizedCheckpoint.Epoch, bytesutil.ToBytes32(finalizedCheckpoint.Root))
+	finalizedRoot := bytesutil.ToBytes32(finalizedCheckpoint.Root)
+	if store := s.restoreForkChoiceStore(s.ctx, finalizedRoot); store != nil {
+		s.forkChoiceStore = store
+		return
+	}
+	store := protoarray.New(justifiedCheckpoint.Epoch, finalizedCheckpoint.Epoch, finalizedRoot)
 	s.forkChoiceStore = store
 }
 
+// This loads the fork choice store snapshot from the DB. It returns nil if there is no usable snapshot.
+func (s *Service) restoreForkChoiceStore(ctx context.Context, finalizedRoot [32]byte) *protoarray.ForkChoice {
+	enc, err := s.beaconDB.ForkChoiceSnapshot(ctx)
+	if err != nil {
+		log.WithError(err).Warn("Could not load fork choice store snapshot")
+		return nil
+	}
+	if enc == nil {
+		return nil
+	}
+	store, err := protoarray.Unmarshal(ctx, enc)
+	if err != nil {
+		log.WithError(err).Warn("Discarding invalid fork choice store snapshot")
+		return nil
+	}
+	if !store.HasNode(finalizedRoot) {
+		log.WithField("finalizedRoot", fmt.Sprintf("%#x", finalizedRoot)).Warn("Discarding fork choice store snapshot without finalized root")
+		return nil
+	}
+	log.WithField("nodes", len(store.Nodes())).Info("Restored fork choice store from snapshot")
+	return store
+}
+
+// This saves a snapshot of the fork choice store to the DB.
+func (s *Service) saveForkChoiceStore(ctx context.Context) error {
+	if s.forkChoiceStore == nil {
+		return nil
+	}
+	enc, err := s.forkChoiceStore.Marshal(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not marshal fork choice store")
+	}
+	// The service context may already be cancelled on shutdown, the snapshot is still saved.
+	return s.beaconDB.SaveForkChoiceSnapshot(context.Background(), enc)
+}
+
+// This saves the fork choice store once per epoch so that little is lost on an unclean shutdown.
+func (s *Service) saveForkChoiceStorePeriodically() {
+	ticker := time.NewTicker(time.Duration(params.BeaconConfig().SlotsPerEpoch*params.BeaconConfig().SecondsPerSlot) * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.saveForkChoiceStore(s.ctx); err != nil {
+				log.WithError(err).Error("Could not save fork choice store")
+			}
+		}
+	}
+}
+
 // This returns true if block has been processed before. Two ways to verify the block has been processed:
 // 1.) Check fork choice store.
 // 2.) Check DB.
@@ -509,6 +509,68 @@ func TestHasBlock_ForkChoiceAndDB(t *testing.T) {
 	}
 }
 
+func TestResumeForkChoice_RestoresSnapshot(t *testing.T) {
+	ctx := context.Background()
+	db := testDB.SetupDB(t)
+	defer testDB.TeardownDB(t, db)
+	finalizedRoot := [32]byte{'a'}
+	blockRoot := [32]byte{'b'}
+	s := &Service{
+		ctx:             ctx,
+		beaconDB:        db,
+		forkChoiceStore: protoarray.New(1, 1, finalizedRoot),
+	}
+	if err := s.forkChoiceStore.ProcessBlock(ctx, 32, finalizedRoot, [32]byte{}, 1, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.forkChoiceStore.ProcessBlock(ctx, 33, blockRoot, finalizedRoot, 1, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.saveForkChoiceStore(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	s.resumeForkChoice(&ethpb.Checkpoint{Epoch: 1, Root: finalizedRoot[:]}, &ethpb.Checkpoint{Epoch: 1, Root: finalizedRoot[:]})
+	if !s.forkChoiceStore.HasNode(blockRoot) {
+		t.Error("Expected fork choice store to be restored from snapshot")
+	}
+
+	// A snapshot which does not contain the finalized root is discarded.
+	otherRoot := [32]byte{'c'}
+	s.resumeForkChoice(&ethpb.Checkpoint{Epoch: 2, Root: otherRoot[:]}, &ethpb.Checkpoint{Epoch: 2, Root: otherRoot[:]})
+	if s.forkChoiceStore.HasNode(blockRoot) {
+		t.Error("Expected snapshot without finalized root to be discarded")
+	}
+}
+
+func TestResumeForkChoice_DiscardsCorruptedSnapshot(t *testing.T) {
+	ctx := context.Background()
+	db := testDB.SetupDB(t)
+	defer testDB.TeardownDB(t, db)
+	finalizedRoot := [32]byte{'a'}
+	s := &Service{
+		ctx:             ctx,
+		beaconDB:        db,
+		forkChoiceStore: protoarray.New(1, 1, finalizedRoot),
+	}
+	if err := s.forkChoiceStore.ProcessBlock(ctx, 32, finalizedRoot, [32]byte{}, 1, 1); err != nil {
+		t.Fatal(err)
+	}
+	enc, err := s.forkChoiceStore.Marshal(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	enc[len(enc)/2] ^= 0xff
+	if err := db.SaveForkChoiceSnapshot(ctx, enc); err != nil {
+		t.Fatal(err)
+	}
+
+	s.resumeForkChoice(&ethpb.Checkpoint{Epoch: 1, Root: finalizedRoot[:]}, &ethpb.Checkpoint{Epoch: 1, Root: finalizedRoot[:]})
+	if len(s.forkChoiceStore.Nodes()) != 0 {
+		t.Errorf("Expected fresh fork choice store, got %d nodes", len(s.forkChoiceStore.Nodes()))
+	}
+}
+
 func BenchmarkHasBlockDB(b *testing.B) {
 	db := testDB.SetupDB(b)
 	defer testDB.TeardownDB(b, db)
@@ -10,10 +10,12 @@ import (
 	"github.com/prysmaticlabs/go-ssz"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/epoch/precompute"
 	blockfeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/block"
+	gossipfeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/gossip"
 	opfeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/operation"
 	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/beacon-chain/forkchoice"
 	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
 	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
 	"github.com/prysmaticlabs/prysm/shared/event"
@@ -38,7 +40,10 @@ type ChainService struct {
 	stateNotifier               statefeed.Notifier
 	blockNotifier               blockfeed.Notifier
 	opNotifier                  opfeed.Notifier
+	gossipNotifier              gossipfeed.Notifier
 	ValidAttestation            bool
+	ForkChoice                  forkchoice.ForkChoicer
+	WeakSubjectivity            string
 }
 
 // StateNotifier mocks the same method in the chain service.
@@ -104,6 +109,27 @@ func (mon *MockOperationNotifier) OperationFeed() *event.Feed {
 	return mon.feed
 }
 
+// GossipNotifier mocks the gossip notifier of the beacon node.
+func (ms *ChainService) GossipNotifier() gossipfeed.Notifier {
+	if ms.gossipNotifier == nil {
+		ms.gossipNotifier = &MockGossipNotifier{}
+	}
+	return ms.gossipNotifier
+}
+
+// MockGossipNotifier mocks the gossip notifier.
+type MockGossipNotifier struct {
+	feed *event.Feed
+}
+
+// GossipFeed returns a gossip feed.
+func (mgn *MockGossipNotifier) GossipFeed() *event.Feed {
+	if mgn.feed == nil {
+		mgn.feed = new(event.Feed)
+	}
+	return mgn.feed
+}
+
 // ReceiveBlock mocks ReceiveBlock method in chain service.
 func (ms *ChainService) ReceiveBlock(ctx context.Context, block *ethpb.SignedBeaconBlock) error {
 	return nil
@@ -146,6 +172,16 @@ func (ms *ChainService) ReceiveBlockNoPubsubForkchoice(ctx context.Context, bloc
 	return nil
 }
 
+// ReceiveBlockBatch mocks ReceiveBlockBatch method in chain service.
+func (ms *ChainService) ReceiveBlockBatch(ctx context.Context, blocks []*ethpb.SignedBeaconBlock) error {
+	for _, block := range blocks {
+		if err := ms.ReceiveBlockNoPubsubForkchoice(ctx, block); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // HeadSlot mocks HeadSlot method in chain service.
 func (ms *ChainService) HeadSlot() uint64 {
 	if ms.State == nil {
@@ -233,6 +269,16 @@ func (ms *ChainService) Participation(epoch uint64) *precompute.Balance {
 	return ms.Balance
 }
 
+// ForkChoiceStore mocks the same method in the chain service.
+func (ms *ChainService) ForkChoiceStore() forkchoice.ForkChoicer {
+	return ms.ForkChoice
+}
+
+// WeakSubjectivityStatus mocks the same method in the chain service.
+func (ms *ChainService) WeakSubjectivityStatus() string {
+	return ms.WeakSubjectivity
+}
+
 // IsValidAttestation always returns true.
 func (ms *ChainService) IsValidAttestation(ctx context.Context, att *ethpb.Attestation) bool {
 	return ms.ValidAttestation
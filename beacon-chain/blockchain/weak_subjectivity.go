@@ -0,0 +1,135 @@
+package blockchain
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/sirupsen/logrus"
+)
+
+// Weak subjectivity check statuses reported by WeakSubjectivityStatus.
+const (
+	// WeakSubjectivityDisabled is reported when no weak subjectivity checkpoint is configured.
+	WeakSubjectivityDisabled = "disabled"
+	// WeakSubjectivityPending is reported until the finalized chain reaches the checkpoint epoch.
+	WeakSubjectivityPending = "pending"
+	// WeakSubjectivityVerified is reported once the finalized chain is known to include the checkpoint.
+	WeakSubjectivityVerified = "verified"
+	// WeakSubjectivityFailed is reported when the finalized chain conflicts with the checkpoint.
+	WeakSubjectivityFailed = "failed"
+)
+
+var errWeakSubjectivityConflict = errors.New("chain conflicts with weak subjectivity checkpoint")
+
+// WeakSubjectivityStatus returns the status of the weak subjectivity checkpoint check.
+func (s *Service) WeakSubjectivityStatus() string {
+	s.wsLock.RLock()
+	defer s.wsLock.RUnlock()
+	if s.wsCheckpt == nil {
+		return WeakSubjectivityDisabled
+	}
+	return s.wsStatus
+}
+
+func (s *Service) setWeakSubjectivityStatus(status string) {
+	s.wsLock.Lock()
+	defer s.wsLock.Unlock()
+	s.wsStatus = status
+}
+
+// verifyWeakSubjectivityPostState rejects a block whose post state builds on a chain which doesn't include
+// the weak subjectivity checkpoint block. The chain of a post state is checked against the checkpoint once the
+// state is past the checkpoint slot, using the block roots it keeps track of, or walking back from the given
+// root of a stored ancestor of the block once the checkpoint is older than these block roots. Rejecting a block
+// does not fail the check, as the block may be on a fork the node never follows. The checkpoint is considered
+// verified once it is finalized, after which every block has to descend from it anyway.
+func (s *Service) verifyWeakSubjectivityPostState(ctx context.Context, ancestorRoot []byte, postState *stateTrie.BeaconState) error {
+	if s.wsCheckpt == nil || s.WeakSubjectivityStatus() == WeakSubjectivityVerified {
+		return nil
+	}
+	wsSlot := helpers.StartSlot(s.wsCheckpt.Epoch)
+	if postState.Slot() <= wsSlot {
+		return nil
+	}
+	root, err := helpers.BlockRootAtSlot(postState, wsSlot)
+	if err != nil {
+		ancestor, err := s.ancestorAtOrBefore(ctx, bytesutil.ToBytes32(ancestorRoot), wsSlot)
+		if err != nil {
+			return errors.Wrap(err, "could not get ancestor at weak subjectivity checkpoint")
+		}
+		root = ancestor[:]
+	}
+	if !bytes.Equal(root, s.wsCheckpt.Root) {
+		return errors.Wrapf(errWeakSubjectivityConflict, "wanted root %#x at epoch %d, received %#x",
+			s.wsCheckpt.Root, s.wsCheckpt.Epoch, root)
+	}
+	if postState.FinalizedCheckpointEpoch() >= s.wsCheckpt.Epoch {
+		s.setWeakSubjectivityStatus(WeakSubjectivityVerified)
+		log.WithFields(logrus.Fields{
+			"root":  fmt.Sprintf("%#x", s.wsCheckpt.Root),
+			"epoch": s.wsCheckpt.Epoch,
+		}).Info("Weak subjectivity check has passed")
+	}
+	return nil
+}
+
+// verifyWeakSubjectivityFinalized checks that the finalized chain stored in the DB includes the weak
+// subjectivity checkpoint block. This is done on start up, as the node may have finalized the checkpoint
+// epoch in a previous run.
+func (s *Service) verifyWeakSubjectivityFinalized(ctx context.Context) error {
+	if s.wsCheckpt == nil {
+		return nil
+	}
+	if s.finalizedCheckpt == nil || s.finalizedCheckpt.Epoch < s.wsCheckpt.Epoch {
+		return nil
+	}
+	finalizedRoot := bytesutil.ToBytes32(s.finalizedCheckpt.Root)
+	if finalizedRoot == params.BeaconConfig().ZeroHash {
+		finalizedRoot = s.genesisRoot
+	}
+	root, err := s.ancestorAtOrBefore(ctx, finalizedRoot, helpers.StartSlot(s.wsCheckpt.Epoch))
+	if err != nil {
+		return errors.Wrap(err, "could not get finalized ancestor at weak subjectivity checkpoint")
+	}
+	if !bytes.Equal(root[:], s.wsCheckpt.Root) {
+		s.setWeakSubjectivityStatus(WeakSubjectivityFailed)
+		return errors.Wrapf(errWeakSubjectivityConflict, "wanted root %#x at epoch %d, finalized chain has %#x",
+			s.wsCheckpt.Root, s.wsCheckpt.Epoch, root)
+	}
+	s.setWeakSubjectivityStatus(WeakSubjectivityVerified)
+	return nil
+}
+
+// ancestorAtOrBefore returns the root of the latest block at or before the given slot, in the chain
+// of the given block root. That is the root a checkpoint at the given slot refers to.
+func (s *Service) ancestorAtOrBefore(ctx context.Context, root [32]byte, slot uint64) ([32]byte, error) {
+	for {
+		if ctx.Err() != nil {
+			return [32]byte{}, ctx.Err()
+		}
+		var signed *ethpb.SignedBeaconBlock
+		if s.hasInitSyncBlock(root) {
+			signed = s.getInitSyncBlock(root)
+		} else {
+			var err error
+			signed, err = s.beaconDB.Block(ctx, root)
+			if err != nil {
+				return [32]byte{}, errors.Wrap(err, "could not get ancestor block")
+			}
+		}
+		if signed == nil || signed.Block == nil {
+			return [32]byte{}, fmt.Errorf("block %#x not found", root)
+		}
+		if signed.Block.Slot <= slot {
+			return root, nil
+		}
+		root = bytesutil.ToBytes32(signed.Block.ParentRoot)
+	}
+}
@@ -0,0 +1,190 @@
+package blockchain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-ssz"
+	testDB "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+var _ = WeakSubjectivityFetcher(&Service{})
+
+func TestVerifyWeakSubjectivityPostState(t *testing.T) {
+	wsRoot := [32]byte{'a'}
+	wsEpoch := uint64(2)
+	wsSlot := wsEpoch * params.BeaconConfig().SlotsPerEpoch
+
+	newState := func(slot uint64, root [32]byte, finalizedEpoch uint64) *stateTrie.BeaconState {
+		blockRoots := make([][]byte, params.BeaconConfig().SlotsPerHistoricalRoot)
+		for i := range blockRoots {
+			blockRoots[i] = make([]byte, 32)
+		}
+		blockRoots[wsSlot%params.BeaconConfig().SlotsPerHistoricalRoot] = root[:]
+		st, err := stateTrie.InitializeFromProto(&pb.BeaconState{
+			Slot:                slot,
+			BlockRoots:          blockRoots,
+			FinalizedCheckpoint: &ethpb.Checkpoint{Epoch: finalizedEpoch, Root: make([]byte, 32)},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return st
+	}
+
+	tests := []struct {
+		name       string
+		state      *stateTrie.BeaconState
+		wantErr    bool
+		wantStatus string
+	}{
+		{
+			name:       "state before checkpoint slot",
+			state:      newState(wsSlot, [32]byte{'b'}, 0),
+			wantStatus: WeakSubjectivityPending,
+		},
+		{
+			name:       "chain includes checkpoint, not finalized",
+			state:      newState(wsSlot+1, wsRoot, 1),
+			wantStatus: WeakSubjectivityPending,
+		},
+		{
+			name:       "conflicting chain",
+			state:      newState(wsSlot+1, [32]byte{'b'}, 1),
+			wantErr:    true,
+			wantStatus: WeakSubjectivityPending,
+		},
+		{
+			name:       "chain includes checkpoint, finalized",
+			state:      newState(wsSlot+2*params.BeaconConfig().SlotsPerEpoch, wsRoot, wsEpoch),
+			wantStatus: WeakSubjectivityVerified,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Service{
+				wsCheckpt: &ethpb.Checkpoint{Epoch: wsEpoch, Root: wsRoot[:]},
+				wsStatus:  WeakSubjectivityPending,
+			}
+			err := s.verifyWeakSubjectivityPostState(context.Background(), nil, tt.state)
+			if tt.wantErr && errors.Cause(err) != errWeakSubjectivityConflict {
+				t.Errorf("Wanted error %v, received %v", errWeakSubjectivityConflict, err)
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+			if status := s.WeakSubjectivityStatus(); status != tt.wantStatus {
+				t.Errorf("Wanted status %s, received %s", tt.wantStatus, status)
+			}
+		})
+	}
+}
+
+func TestVerifyWeakSubjectivityPostState_CheckpointOlderThanBlockRoots(t *testing.T) {
+	ctx := context.Background()
+	db := testDB.SetupDB(t)
+	defer testDB.TeardownDB(t, db)
+
+	slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+	roots := make(map[uint64][32]byte)
+	var parentRoot [32]byte
+	for _, slot := range []uint64{0, slotsPerEpoch, 2 * slotsPerEpoch} {
+		blk := &ethpb.SignedBeaconBlock{Block: &ethpb.BeaconBlock{Slot: slot, ParentRoot: parentRoot[:]}}
+		if err := db.SaveBlock(ctx, blk); err != nil {
+			t.Fatal(err)
+		}
+		root, err := ssz.HashTreeRoot(blk.Block)
+		if err != nil {
+			t.Fatal(err)
+		}
+		roots[slot] = root
+		parentRoot = root
+	}
+	// The block roots of the post state no longer include the checkpoint slot.
+	postState, err := stateTrie.InitializeFromProto(&pb.BeaconState{
+		Slot:                slotsPerEpoch + params.BeaconConfig().SlotsPerHistoricalRoot + 1,
+		FinalizedCheckpoint: &ethpb.Checkpoint{Root: make([]byte, 32)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	headRoot := roots[2*slotsPerEpoch]
+
+	wsRoot := roots[slotsPerEpoch]
+	s := &Service{
+		beaconDB:       db,
+		wsCheckpt:      &ethpb.Checkpoint{Epoch: 1, Root: wsRoot[:]},
+		wsStatus:       WeakSubjectivityPending,
+		initSyncBlocks: make(map[[32]byte]*ethpb.SignedBeaconBlock),
+	}
+	if err := s.verifyWeakSubjectivityPostState(ctx, headRoot[:], postState); err != nil {
+		t.Fatal(err)
+	}
+
+	conflictingRoot := roots[0]
+	s.wsCheckpt = &ethpb.Checkpoint{Epoch: 1, Root: conflictingRoot[:]}
+	if err := s.verifyWeakSubjectivityPostState(ctx, headRoot[:], postState); errors.Cause(err) != errWeakSubjectivityConflict {
+		t.Errorf("Wanted error %v, received %v", errWeakSubjectivityConflict, err)
+	}
+	if status := s.WeakSubjectivityStatus(); status != WeakSubjectivityPending {
+		t.Errorf("Wanted status %s, received %s", WeakSubjectivityPending, status)
+	}
+
+	unknownRoot := [32]byte{'u'}
+	if err := s.verifyWeakSubjectivityPostState(ctx, unknownRoot[:], postState); err == nil {
+		t.Error("Expected an error when the ancestor of the block is unknown")
+	}
+}
+
+func TestVerifyWeakSubjectivityFinalized(t *testing.T) {
+	ctx := context.Background()
+	db := testDB.SetupDB(t)
+	defer testDB.TeardownDB(t, db)
+
+	// Build a chain with the checkpoint slot skipped, so that the checkpoint refers to an earlier block.
+	slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+	roots := make(map[uint64][32]byte)
+	var parentRoot [32]byte
+	for _, slot := range []uint64{0, slotsPerEpoch - 1, slotsPerEpoch + 1, 2 * slotsPerEpoch} {
+		blk := &ethpb.SignedBeaconBlock{Block: &ethpb.BeaconBlock{Slot: slot, ParentRoot: parentRoot[:]}}
+		if err := db.SaveBlock(ctx, blk); err != nil {
+			t.Fatal(err)
+		}
+		root, err := ssz.HashTreeRoot(blk.Block)
+		if err != nil {
+			t.Fatal(err)
+		}
+		roots[slot] = root
+		parentRoot = root
+	}
+	finalizedRoot := roots[2*slotsPerEpoch]
+	wsRoot := roots[slotsPerEpoch-1]
+
+	s := &Service{
+		beaconDB:         db,
+		finalizedCheckpt: &ethpb.Checkpoint{Epoch: 2, Root: finalizedRoot[:]},
+		wsCheckpt:        &ethpb.Checkpoint{Epoch: 1, Root: wsRoot[:]},
+		wsStatus:         WeakSubjectivityPending,
+		initSyncBlocks:   make(map[[32]byte]*ethpb.SignedBeaconBlock),
+	}
+	if err := s.verifyWeakSubjectivityFinalized(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if status := s.WeakSubjectivityStatus(); status != WeakSubjectivityVerified {
+		t.Errorf("Wanted status %s, received %s", WeakSubjectivityVerified, status)
+	}
+
+	conflictingRoot := roots[slotsPerEpoch+1]
+	s.wsCheckpt = &ethpb.Checkpoint{Epoch: 1, Root: conflictingRoot[:]}
+	if err := s.verifyWeakSubjectivityFinalized(ctx); errors.Cause(err) != errWeakSubjectivityConflict {
+		t.Errorf("Wanted error %v, received %v", errWeakSubjectivityConflict, err)
+	}
+	if status := s.WeakSubjectivityStatus(); status != WeakSubjectivityFailed {
+		t.Errorf("Wanted status %s, received %s", WeakSubjectivityFailed, status)
+	}
+}
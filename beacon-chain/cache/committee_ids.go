@@ -13,6 +13,10 @@ type committeeIDs struct {
 	attesterLock   sync.RWMutex
 	aggregator     *lru.Cache
 	aggregatorLock sync.RWMutex
+
+	persistent       []uint64
+	persistentExpiry uint64
+	persistentLock   sync.RWMutex
 }
 
 // CommitteeIDs for attester and aggregator.
@@ -85,3 +89,32 @@ func (c *committeeIDs) GetAggregatorCommitteeIDs(slot uint64) []uint64 {
 	}
 	return val.([]uint64)
 }
+
+// SetPersistentCommitteeIDs sets the long lived committee subnets the node is subscribed to
+// along with the epoch at which the subscription expires and should be rotated.
+func (c *committeeIDs) SetPersistentCommitteeIDs(committeeIDs []uint64, expirationEpoch uint64) {
+	c.persistentLock.Lock()
+	defer c.persistentLock.Unlock()
+
+	c.persistent = sliceutil.SetUint64(committeeIDs)
+	c.persistentExpiry = expirationEpoch
+}
+
+// GetPersistentCommitteeIDs gets the long lived committee subnets the node is subscribed to.
+func (c *committeeIDs) GetPersistentCommitteeIDs() []uint64 {
+	c.persistentLock.RLock()
+	defer c.persistentLock.RUnlock()
+
+	ids := make([]uint64, len(c.persistent))
+	copy(ids, c.persistent)
+	return ids
+}
+
+// PersistentCommitteeIDsExpiration returns the epoch at which the long lived committee
+// subnets should be rotated.
+func (c *committeeIDs) PersistentCommitteeIDsExpiration() uint64 {
+	c.persistentLock.RLock()
+	defer c.persistentLock.RUnlock()
+
+	return c.persistentExpiry
+}
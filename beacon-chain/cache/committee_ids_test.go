@@ -54,3 +54,26 @@ func TestCommitteeIDCache_RoundTrip(t *testing.T) {
 		t.Error("Expected equal value to return from cache")
 	}
 }
+
+func TestCommitteeIDCache_PersistentRoundTrip(t *testing.T) {
+	c := newCommitteeIDs()
+	if ids := c.GetPersistentCommitteeIDs(); len(ids) != 0 {
+		t.Errorf("Empty cache returned an object: %v", ids)
+	}
+	if exp := c.PersistentCommitteeIDsExpiration(); exp != 0 {
+		t.Errorf("Expected no expiration, got %d", exp)
+	}
+
+	c.SetPersistentCommitteeIDs([]uint64{5, 3, 5}, 300)
+	if ids := c.GetPersistentCommitteeIDs(); !reflect.DeepEqual(ids, []uint64{5, 3}) {
+		t.Errorf("Unexpected persistent IDs %v", ids)
+	}
+	if exp := c.PersistentCommitteeIDsExpiration(); exp != 300 {
+		t.Errorf("Expected expiration 300, got %d", exp)
+	}
+
+	c.SetPersistentCommitteeIDs([]uint64{7}, 600)
+	if ids := c.GetPersistentCommitteeIDs(); !reflect.DeepEqual(ids, []uint64{7}) {
+		t.Errorf("Unexpected persistent IDs %v", ids)
+	}
+}
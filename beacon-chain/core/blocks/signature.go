@@ -0,0 +1,139 @@
+package blocks
+
+import (
+	"context"
+	"encoding/binary"
+
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-ssz"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/attestationutil"
+	"github.com/prysmaticlabs/prysm/shared/bls"
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+// BlockSignatureSet retrieves the block proposer signature set of the provided block, so that it
+// can be verified later on as part of a batch. The state is expected to be at the block's slot.
+func BlockSignatureSet(beaconState *stateTrie.BeaconState, block *ethpb.SignedBeaconBlock) (*bls.SignatureSet, error) {
+	if block == nil || block.Block == nil {
+		return nil, errors.New("nil block")
+	}
+	proposer, err := beaconState.ValidatorAtIndex(block.Block.ProposerIndex)
+	if err != nil {
+		return nil, err
+	}
+	currentEpoch := helpers.SlotToEpoch(beaconState.Slot())
+	domain, err := helpers.Domain(beaconState.Fork(), currentEpoch, params.BeaconConfig().DomainBeaconProposer, beaconState.GenesisValidatorRoot())
+	if err != nil {
+		return nil, err
+	}
+	root, err := helpers.ComputeSigningRoot(block.Block, domain)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute signing root")
+	}
+	return signatureSet(proposer.PublicKey, block.Signature, root)
+}
+
+// RandaoSignatureSet retrieves the randao reveal signature set of the provided block body, so that
+// it can be verified later on as part of a batch. The state is expected to be at the block's slot.
+func RandaoSignatureSet(beaconState *stateTrie.BeaconState, body *ethpb.BeaconBlockBody) (*bls.SignatureSet, error) {
+	if body == nil {
+		return nil, errors.New("nil block body")
+	}
+	proposerIdx, err := helpers.BeaconProposerIndex(beaconState)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get beacon proposer index")
+	}
+	proposerPub := beaconState.PubkeyAtIndex(proposerIdx)
+
+	currentEpoch := helpers.SlotToEpoch(beaconState.Slot())
+	buf := make([]byte, 32)
+	binary.LittleEndian.PutUint64(buf, currentEpoch)
+	domain, err := helpers.Domain(beaconState.Fork(), currentEpoch, params.BeaconConfig().DomainRandao, beaconState.GenesisValidatorRoot())
+	if err != nil {
+		return nil, err
+	}
+	root, err := ssz.HashTreeRoot(&pb.SigningRoot{
+		ObjectRoot: buf,
+		Domain:     domain,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not hash container")
+	}
+	return signatureSet(proposerPub[:], body.RandaoReveal, root)
+}
+
+// AttestationSignatureSet retrieves the aggregate signature sets of the provided attestations, so that
+// they can be verified later on as part of a batch. Attestations without any attesting indices are
+// skipped, as there is no signature to verify for them.
+func AttestationSignatureSet(ctx context.Context, beaconState *stateTrie.BeaconState, atts []*ethpb.Attestation) (*bls.SignatureSet, error) {
+	set := bls.NewSet()
+	for _, att := range atts {
+		if att == nil || att.Data == nil || att.Data.Target == nil {
+			return nil, errors.New("nil or missing attestation data")
+		}
+		committee, err := helpers.BeaconCommitteeFromState(beaconState, att.Data.Slot, att.Data.CommitteeIndex)
+		if err != nil {
+			return nil, err
+		}
+		indexedAtt := attestationutil.ConvertToIndexed(ctx, att, committee)
+		indices := indexedAtt.AttestingIndices
+		if len(indices) == 0 {
+			continue
+		}
+
+		var aggPubkey *bls.PublicKey
+		for _, idx := range indices {
+			pubkeyAtIdx := beaconState.PubkeyAtIndex(idx)
+			pk, err := bls.PublicKeyFromBytes(pubkeyAtIdx[:])
+			if err != nil {
+				return nil, errors.Wrap(err, "could not deserialize validator public key")
+			}
+			if aggPubkey == nil {
+				// Copy the first key as aggregation mutates its receiver.
+				aggPubkey, err = pk.Copy()
+				if err != nil {
+					return nil, err
+				}
+				continue
+			}
+			aggPubkey = aggPubkey.Aggregate(pk)
+		}
+
+		domain, err := helpers.Domain(beaconState.Fork(), att.Data.Target.Epoch, params.BeaconConfig().DomainBeaconAttester, beaconState.GenesisValidatorRoot())
+		if err != nil {
+			return nil, err
+		}
+		root, err := helpers.ComputeSigningRoot(att.Data, domain)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not get signing root of object")
+		}
+		sig, err := bls.SignatureFromBytes(att.Signature)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not convert bytes to signature")
+		}
+		set.Signatures = append(set.Signatures, sig)
+		set.PublicKeys = append(set.PublicKeys, aggPubkey)
+		set.Messages = append(set.Messages, root)
+	}
+	return set, nil
+}
+
+func signatureSet(pub []byte, signature []byte, root [32]byte) (*bls.SignatureSet, error) {
+	publicKey, err := bls.PublicKeyFromBytes(pub)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not convert bytes to public key")
+	}
+	sig, err := bls.SignatureFromBytes(signature)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not convert bytes to signature")
+	}
+	return &bls.SignatureSet{
+		Signatures: []*bls.Signature{sig},
+		PublicKeys: []*bls.PublicKey{publicKey},
+		Messages:   [][32]byte{root},
+	}, nil
+}
@@ -0,0 +1,80 @@
+package blocks_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/state"
+	"github.com/prysmaticlabs/prysm/shared/bls"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+)
+
+func TestSignatureSets_VerifyValidBlock(t *testing.T) {
+	ctx := context.Background()
+	beaconState, privKeys := testutil.DeterministicGenesisState(t, 100)
+	blk, err := testutil.GenerateFullBlock(beaconState, privKeys, testutil.DefaultBlockGenConfig(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	beaconState, err = state.ProcessSlots(ctx, beaconState, blk.Block.Slot)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	set := bls.NewSet()
+	blkSet, err := blocks.BlockSignatureSet(beaconState, blk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	randaoSet, err := blocks.RandaoSignatureSet(beaconState, blk.Block.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	attSet, err := blocks.AttestationSignatureSet(ctx, beaconState, blk.Block.Body.Attestations)
+	if err != nil {
+		t.Fatal(err)
+	}
+	set.Join(blkSet).Join(randaoSet).Join(attSet)
+	if len(set.Signatures) != 2+len(blk.Block.Body.Attestations) {
+		t.Errorf("Wanted %d signatures in set, received %d", 2+len(blk.Block.Body.Attestations), len(set.Signatures))
+	}
+	verified, err := set.Verify()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !verified {
+		t.Error("Expected signature set of a valid block to verify")
+	}
+}
+
+func TestSignatureSets_InvalidRandao(t *testing.T) {
+	ctx := context.Background()
+	beaconState, privKeys := testutil.DeterministicGenesisState(t, 100)
+	blk, err := testutil.GenerateFullBlock(beaconState, privKeys, testutil.DefaultBlockGenConfig(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	beaconState, err = state.ProcessSlots(ctx, beaconState, blk.Block.Slot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Use the block signature as randao reveal, a well formed signature over the wrong message.
+	blk.Block.Body.RandaoReveal = blk.Signature
+
+	blkSet, err := blocks.BlockSignatureSet(beaconState, blk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	randaoSet, err := blocks.RandaoSignatureSet(beaconState, blk.Block.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	verified, err := bls.NewSet().Join(blkSet).Join(randaoSet).Verify()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if verified {
+		t.Error("Expected signature set with an invalid randao reveal to fail verification")
+	}
+}
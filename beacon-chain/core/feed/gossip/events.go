@@ -0,0 +1,29 @@
+package gossip
+
+import (
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+)
+
+const (
+	// BlockReceived is sent when a block is received over gossip, before it is validated
+	// against the chain of the beacon node. The block may be rejected afterwards, for example as
+	// a second block of its proposer for the slot, or as a block descending from an unknown parent.
+	BlockReceived = iota + 1
+
+	// AttReceived is sent when an attestation, aggregated or not, is received over gossip,
+	// before it is validated against the chain of the beacon node. The attestation may be rejected
+	// afterwards, for example as a second attestation of its validator for the slot, or as a vote
+	// for a block the beacon node does not know of.
+	AttReceived
+)
+
+// BlockReceivedData is the data sent with BlockReceived events.
+type BlockReceivedData struct {
+	SignedBlock *ethpb.SignedBeaconBlock
+}
+
+// AttReceivedData is the data sent with AttReceived events.
+type AttReceivedData struct {
+	// Attestation is the attestation object, for an aggregate and proof the aggregated attestation.
+	Attestation *ethpb.Attestation
+}
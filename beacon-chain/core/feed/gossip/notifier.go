@@ -0,0 +1,10 @@
+package gossip
+
+import "github.com/prysmaticlabs/prysm/shared/event"
+
+// Notifier interface defines the methods of the service that provides the objects received over
+// gossip to consumers. Every gossip message is sent over the feed from within pubsub validation,
+// so subscribers must buffer the events they receive and never block the feed.
+type Notifier interface {
+	GossipFeed() *event.Feed
+}
@@ -11,6 +11,12 @@ const (
 	Initialized
 	// Synced is sent when the beacon node has completed syncing and is ready to participate in the network.
 	Synced
+	// Reorg is sent when the new head of the chain does not descend from the previous head.
+	Reorg
+	// NewHead is sent when the head of the chain changes.
+	NewHead
+	// FinalizedCheckpoint is sent when a new checkpoint gets finalized.
+	FinalizedCheckpoint
 )
 
 // BlockProcessedData is the data sent with BlockProcessed events.
@@ -42,3 +48,37 @@ type InitializedData struct {
 	// GenesisValidatorsRoot represents ssz.HashTreeRoot(state.validators).
 	GenesisValidatorsRoot []byte
 }
+
+// ReorgData is the data sent with Reorg events.
+type ReorgData struct {
+	// OldHeadRoot is the root of the head block before the reorg.
+	OldHeadRoot [32]byte
+	// OldHeadSlot is the slot of the head block before the reorg.
+	OldHeadSlot uint64
+	// NewHeadRoot is the root of the head block after the reorg.
+	NewHeadRoot [32]byte
+	// NewHeadSlot is the slot of the head block after the reorg.
+	NewHeadSlot uint64
+	// CommonAncestorRoot is the root of the latest block shared by the old and new chains.
+	CommonAncestorRoot [32]byte
+	// CommonAncestorSlot is the slot of the latest block shared by the old and new chains.
+	CommonAncestorSlot uint64
+	// Depth is the number of slots of the old chain rolled back, from the common ancestor to the old head.
+	Depth uint64
+}
+
+// NewHeadData is the data sent with NewHead events.
+type NewHeadData struct {
+	// Slot is the slot of the new head block.
+	Slot uint64
+	// BlockRoot is the root of the new head block.
+	BlockRoot [32]byte
+}
+
+// FinalizedCheckpointData is the data sent with FinalizedCheckpoint events.
+type FinalizedCheckpointData struct {
+	// Epoch is the epoch of the finalized checkpoint.
+	Epoch uint64
+	// BlockRoot is the root of the finalized checkpoint block.
+	BlockRoot [32]byte
+}
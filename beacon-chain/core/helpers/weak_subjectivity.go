@@ -0,0 +1,41 @@
+package helpers
+
+import (
+	"encoding/hex"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+)
+
+// ParseWeakSubjectivityInputString parses a weak subjectivity checkpoint provided in the
+// `block_root:epoch_number` format, where the block root is a 0x prefixed, 32 bytes hex string.
+func ParseWeakSubjectivityInputString(wsCheckpointString string) (*ethpb.Checkpoint, error) {
+	s := strings.Split(wsCheckpointString, ":")
+	if len(s) != 2 {
+		return nil, errors.Errorf("weak subjectivity checkpoint input should be in `block_root:epoch_number` format, received %s", wsCheckpointString)
+	}
+
+	bRoot := s[0]
+	if !strings.HasPrefix(bRoot, "0x") {
+		return nil, errors.New("block root is not prefixed with 0x")
+	}
+	root, err := hex.DecodeString(bRoot[2:])
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decode block root")
+	}
+	if len(root) != 32 {
+		return nil, errors.Errorf("block root is not 32 bytes long, received %d bytes", len(root))
+	}
+
+	epoch, err := strconv.ParseUint(s[1], 10, 64)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not parse epoch")
+	}
+
+	return &ethpb.Checkpoint{
+		Epoch: epoch,
+		Root:  root,
+	}, nil
+}
@@ -0,0 +1,71 @@
+package helpers_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+)
+
+func TestParseWeakSubjectivityInputString(t *testing.T) {
+	root := bytes.Repeat([]byte{0xab}, 32)
+	rootHex := "0x" + strings.Repeat("ab", 32)
+	tests := []struct {
+		name    string
+		input   string
+		epoch   uint64
+		wantErr string
+	}{
+		{
+			name:  "valid input",
+			input: rootHex + ":123",
+			epoch: 123,
+		},
+		{
+			name:    "missing epoch",
+			input:   rootHex,
+			wantErr: "should be in `block_root:epoch_number` format",
+		},
+		{
+			name:    "missing 0x prefix",
+			input:   strings.Repeat("ab", 32) + ":123",
+			wantErr: "not prefixed with 0x",
+		},
+		{
+			name:    "invalid hex",
+			input:   "0xzz:123",
+			wantErr: "could not decode block root",
+		},
+		{
+			name:    "short root",
+			input:   "0xabab:123",
+			wantErr: "not 32 bytes long",
+		},
+		{
+			name:    "invalid epoch",
+			input:   rootHex + ":abc",
+			wantErr: "could not parse epoch",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cp, err := helpers.ParseWeakSubjectivityInputString(tt.input)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("Expected error containing %q, received %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if cp.Epoch != tt.epoch {
+				t.Errorf("Wanted epoch %d, received %d", tt.epoch, cp.Epoch)
+			}
+			if !bytes.Equal(cp.Root, root) {
+				t.Errorf("Wanted root %#x, received %#x", root, cp.Root)
+			}
+		})
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/state/interop"
 	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state/stateutil"
+	"github.com/prysmaticlabs/prysm/shared/bls"
 	"github.com/prysmaticlabs/prysm/shared/mathutil"
 	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/prysmaticlabs/prysm/shared/traceutil"
@@ -131,6 +132,65 @@ func ExecuteStateTransitionNoVerifyAttSigs(
 	return state, nil
 }
 
+// ExecuteStateTransitionNoVerifyAnySig defines the procedure for a state transition function.
+// This does not validate any BLS signatures of a block, it returns the signature set of the block's
+// proposer, randao reveal and attestations instead so they can be verified at a later point in a batch
+// alongside the signature sets of other blocks.
+//
+// WARNING: This method does not validate any signatures in a block. The caller must verify the
+// returned signature set before accepting the block. This method also modifies the passed in state.
+//
+// Spec pseudocode definition:
+//  def state_transition(state: BeaconState, block: BeaconBlock, validate_state_root: bool=False) -> BeaconState:
+//    # Process slots (including those with no blocks) since block
+//    process_slots(state, block.slot)
+//    # Process block
+//    process_block(state, block)
+//    # Validate state root (`validate_state_root == True` in production)
+//    if validate_state_root:
+//        assert block.state_root == hash_tree_root(state)
+//    # Return post-state
+//    return state
+func ExecuteStateTransitionNoVerifyAnySig(
+	ctx context.Context,
+	state *stateTrie.BeaconState,
+	signed *ethpb.SignedBeaconBlock,
+) (*bls.SignatureSet, *stateTrie.BeaconState, error) {
+	if ctx.Err() != nil {
+		return nil, nil, ctx.Err()
+	}
+	if signed == nil || signed.Block == nil {
+		return nil, nil, errors.New("nil block")
+	}
+
+	b.ClearEth1DataVoteCache()
+	ctx, span := trace.StartSpan(ctx, "beacon-chain.ChainService.ExecuteStateTransitionNoVerifyAnySig")
+	defer span.End()
+	var err error
+
+	// Execute per slots transition.
+	state, err = ProcessSlots(ctx, state, signed.Block.Slot)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not process slot")
+	}
+
+	// Execute per block transition.
+	set, state, err := ProcessBlockNoVerifyAnySig(ctx, state, signed)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "could not process block in slot %d", signed.Block.Slot)
+	}
+
+	postStateRoot, err := state.HashTreeRoot(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !bytes.Equal(postStateRoot[:], signed.Block.StateRoot) {
+		return nil, nil, fmt.Errorf("validate state root failed, wanted: %#x, received: %#x",
+			postStateRoot[:], signed.Block.StateRoot)
+	}
+	return set, state, nil
+}
+
 // CalculateStateRoot defines the procedure for a state transition function.
 // This does not validate any BLS signatures in a block, it is used for calculating the
 // state root of the state for the block proposer to use.
@@ -280,7 +340,9 @@ func ProcessSlots(ctx context.Context, state *stateTrie.BeaconState, slot uint64
 		return nil, err
 	}
 
-	if cachedState != nil && cachedState.Slot() < slot {
+	// A cached state at the requested slot is used as is, such states are put into the cache ahead of
+	// time by the next epoch precomputation.
+	if cachedState != nil && cachedState.Slot() <= slot {
 		highestSlot = cachedState.Slot()
 		state = cachedState
 	}
@@ -289,7 +351,7 @@ func ProcessSlots(ctx context.Context, state *stateTrie.BeaconState, slot uint64
 		if err != nil {
 			return nil, err
 		}
-		if cachedState != nil && cachedState.Slot() < slot {
+		if cachedState != nil && cachedState.Slot() <= slot {
 			highestSlot = cachedState.Slot()
 			state = cachedState
 		}
@@ -434,6 +496,70 @@ func ProcessBlockNoVerifyAttSigs(
 	return state, nil
 }
 
+// ProcessBlockNoVerifyAnySig creates a new, modified beacon state by applying block operation
+// transformations as defined in the Ethereum Serenity specification. It does not validate the block
+// proposer, randao reveal and attestation signatures, but returns them as a signature set to be
+// verified by the caller.
+//
+// Spec pseudocode definition:
+//
+//  def process_block(state: BeaconState, block: BeaconBlock) -> None:
+//    process_block_header(state, block)
+//    process_randao(state, block.body)
+//    process_eth1_data(state, block.body)
+//    process_operations(state, block.body)
+func ProcessBlockNoVerifyAnySig(
+	ctx context.Context,
+	state *stateTrie.BeaconState,
+	signed *ethpb.SignedBeaconBlock,
+) (*bls.SignatureSet, *stateTrie.BeaconState, error) {
+	ctx, span := trace.StartSpan(ctx, "beacon-chain.ChainService.state.ProcessBlockNoVerifyAnySig")
+	defer span.End()
+
+	state, err := b.ProcessBlockHeaderNoVerify(state, signed.Block)
+	if err != nil {
+		traceutil.AnnotateError(span, err)
+		return nil, nil, errors.Wrap(err, "could not process block header")
+	}
+	blockSet, err := b.BlockSignatureSet(state, signed)
+	if err != nil {
+		traceutil.AnnotateError(span, err)
+		return nil, nil, errors.Wrap(err, "could not retrieve block signature set")
+	}
+	randaoSet, err := b.RandaoSignatureSet(state, signed.Block.Body)
+	if err != nil {
+		traceutil.AnnotateError(span, err)
+		return nil, nil, errors.Wrap(err, "could not retrieve randao signature set")
+	}
+	// Attestation committees are not affected by the block's operations, so the attestation signature
+	// set is retrieved before they are applied.
+	attSet, err := b.AttestationSignatureSet(ctx, state, signed.Block.Body.Attestations)
+	if err != nil {
+		traceutil.AnnotateError(span, err)
+		return nil, nil, errors.Wrap(err, "could not retrieve attestation signature set")
+	}
+
+	state, err = b.ProcessRandaoNoVerify(state, signed.Block.Body)
+	if err != nil {
+		traceutil.AnnotateError(span, err)
+		return nil, nil, errors.Wrap(err, "could not process randao")
+	}
+
+	state, err = b.ProcessEth1DataInBlock(state, signed.Block)
+	if err != nil {
+		traceutil.AnnotateError(span, err)
+		return nil, nil, errors.Wrap(err, "could not process eth1 data")
+	}
+
+	state, err = processOperationsNoVerifyAttsSigs(ctx, state, signed.Block.Body)
+	if err != nil {
+		traceutil.AnnotateError(span, err)
+		return nil, nil, errors.Wrap(err, "could not process block operation")
+	}
+
+	return bls.NewSet().Join(blockSet).Join(randaoSet).Join(attSet), state, nil
+}
+
 // ProcessOperations processes the operations in the beacon block and updates beacon state
 // with the operations in block.
 //
@@ -551,6 +677,44 @@ func processOperationsNoVerify(
 	return state, nil
 }
 
+// processOperationsNoVerifyAttsSigs processes the operations in the beacon block and updates beacon state
+// with the operations in block. It does not verify attestation signatures, all other operations
+// are fully verified.
+func processOperationsNoVerifyAttsSigs(
+	ctx context.Context,
+	state *stateTrie.BeaconState,
+	body *ethpb.BeaconBlockBody) (*stateTrie.BeaconState, error) {
+	ctx, span := trace.StartSpan(ctx, "beacon-chain.ChainService.state.ProcessOperations")
+	defer span.End()
+
+	if err := verifyOperationLengths(state, body); err != nil {
+		return nil, errors.Wrap(err, "could not verify operation lengths")
+	}
+
+	state, err := b.ProcessProposerSlashings(ctx, state, body)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not process block proposer slashings")
+	}
+	state, err = b.ProcessAttesterSlashings(ctx, state, body)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not process block attester slashings")
+	}
+	state, err = b.ProcessAttestationsNoVerify(ctx, state, body)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not process block attestations")
+	}
+	state, err = b.ProcessDeposits(ctx, state, body)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not process block validator deposits")
+	}
+	state, err = b.ProcessVoluntaryExits(ctx, state, body)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not process validator exits")
+	}
+
+	return state, nil
+}
+
 func verifyOperationLengths(state *stateTrie.BeaconState, body *ethpb.BeaconBlockBody) error {
 	if uint64(len(body.ProposerSlashings)) > params.BeaconConfig().MaxProposerSlashings {
 		return fmt.Errorf(
@@ -135,6 +135,55 @@ func TestExecuteStateTransition_FullProcess(t *testing.T) {
 	}
 }
 
+func TestExecuteStateTransitionNoVerifyAnySig_ReturnsVerifiableSet(t *testing.T) {
+	beaconState, privKeys := testutil.DeterministicGenesisState(t, 100)
+	block, err := testutil.GenerateFullBlock(beaconState, privKeys, testutil.DefaultBlockGenConfig(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	set, postState, err := state.ExecuteStateTransitionNoVerifyAnySig(context.Background(), beaconState, block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if postState.Slot() != block.Block.Slot {
+		t.Errorf("Unexpected slot, wanted %d, received %d", block.Block.Slot, postState.Slot())
+	}
+	// Proposer signature, randao reveal and one per attestation.
+	if len(set.Signatures) != 2+len(block.Block.Body.Attestations) {
+		t.Errorf("Wanted %d signatures in set, received %d", 2+len(block.Block.Body.Attestations), len(set.Signatures))
+	}
+	verified, err := set.Verify()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !verified {
+		t.Error("Expected signature set to verify")
+	}
+}
+
+func TestExecuteStateTransitionNoVerifyAnySig_InvalidSignatureFailsSet(t *testing.T) {
+	beaconState, privKeys := testutil.DeterministicGenesisState(t, 100)
+	block, err := testutil.GenerateFullBlock(beaconState, privKeys, testutil.DefaultBlockGenConfig(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block.Signature = privKeys[0].Sign([]byte("wrong message")).Marshal()
+
+	// The transition itself succeeds, the invalid signature is only caught when verifying the set.
+	set, _, err := state.ExecuteStateTransitionNoVerifyAnySig(context.Background(), beaconState, block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	verified, err := set.Verify()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if verified {
+		t.Error("Expected signature set with an invalid proposer signature to fail verification")
+	}
+}
+
 func TestProcessBlock_IncorrectProposerSlashing(t *testing.T) {
 	beaconState, privKeys := testutil.DeterministicGenesisState(t, 100)
 
@@ -61,6 +61,8 @@ type ReadOnlyDatabase interface {
 	DepositContractAddress(ctx context.Context) ([]byte, error)
 	// Powchain operations.
 	PowchainData(ctx context.Context) (*db.ETH1ChainData, error)
+	// Fork choice operations.
+	ForkChoiceSnapshot(ctx context.Context) ([]byte, error)
 }
 
 // NoHeadAccessDatabase -- See github.com/prysmaticlabs/prysm/beacon-chain/db.NoHeadAccessDatabase
@@ -107,6 +109,8 @@ type NoHeadAccessDatabase interface {
 	SaveDepositContractAddress(ctx context.Context, addr common.Address) error
 	// Powchain operations.
 	SavePowchainData(ctx context.Context, data *db.ETH1ChainData) error
+	// Fork choice operations.
+	SaveForkChoiceSnapshot(ctx context.Context, enc []byte) error
 }
 
 // HeadAccessDatabase -- See github.com/prysmaticlabs/prysm/beacon-chain/db.HeadAccessDatabase
@@ -308,6 +308,16 @@ func (e Exporter) SavePowchainData(ctx context.Context, data *db.ETH1ChainData)
 	return e.db.SavePowchainData(ctx, data)
 }
 
+// ForkChoiceSnapshot -- passthrough
+func (e Exporter) ForkChoiceSnapshot(ctx context.Context) ([]byte, error) {
+	return e.db.ForkChoiceSnapshot(ctx)
+}
+
+// SaveForkChoiceSnapshot -- passthrough
+func (e Exporter) SaveForkChoiceSnapshot(ctx context.Context, enc []byte) error {
+	return e.db.SaveForkChoiceSnapshot(ctx, enc)
+}
+
 // SaveArchivedPointRoot -- passthrough
 func (e Exporter) SaveArchivedPointRoot(ctx context.Context, blockRoot [32]byte, index uint64) error {
 	return e.db.SaveArchivedPointRoot(ctx, blockRoot, index)
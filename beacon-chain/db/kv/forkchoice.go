@@ -0,0 +1,39 @@
+package kv
+
+import (
+	"context"
+
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// SaveForkChoiceSnapshot saves the encoded fork choice store, overwriting the previous snapshot.
+func (k *Store) SaveForkChoiceSnapshot(ctx context.Context, enc []byte) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.SaveForkChoiceSnapshot")
+	defer span.End()
+
+	return k.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(forkChoiceBucket)
+		return bkt.Put(forkChoiceSnapshotKey, enc)
+	})
+}
+
+// ForkChoiceSnapshot retrieves the encoded fork choice store, it returns nil if no snapshot was saved.
+func (k *Store) ForkChoiceSnapshot(ctx context.Context) ([]byte, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.ForkChoiceSnapshot")
+	defer span.End()
+
+	var enc []byte
+	err := k.db.View(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(forkChoiceBucket)
+		v := bkt.Get(forkChoiceSnapshotKey)
+		if len(v) == 0 {
+			return nil
+		}
+		// Bolt values are only valid during the transaction.
+		enc = make([]byte, len(v))
+		copy(enc, v)
+		return nil
+	})
+	return enc, err
+}
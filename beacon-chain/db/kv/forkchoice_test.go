@@ -0,0 +1,35 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+	"testing"
+)
+
+func TestStore_ForkChoiceSnapshot_CanSaveRetrieve(t *testing.T) {
+	db := setupDB(t)
+	defer teardownDB(t, db)
+	ctx := context.Background()
+
+	enc, err := db.ForkChoiceSnapshot(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if enc != nil {
+		t.Errorf("Expected no snapshot, got %#x", enc)
+	}
+
+	if err := db.SaveForkChoiceSnapshot(ctx, []byte("snapshot 1")); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveForkChoiceSnapshot(ctx, []byte("snapshot 2")); err != nil {
+		t.Fatal(err)
+	}
+	enc, err = db.ForkChoiceSnapshot(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(enc, []byte("snapshot 2")) {
+		t.Errorf("Expected latest snapshot, got %s", enc)
+	}
+}
@@ -103,6 +103,7 @@ func NewKVStore(dirPath string, stateSummaryCache *cache.StateSummaryCache) (*St
 			stateSummaryBucket,
 			archivedIndexRootBucket,
 			slotsHasObjectBucket,
+			forkChoiceBucket,
 			// Indices buckets.
 			attestationHeadBlockRootBucket,
 			attestationSourceRootIndicesBucket,
@@ -23,6 +23,7 @@ var (
 	powchainBucket                       = []byte("powchain")
 	archivedIndexRootBucket              = []byte("archived-index-root")
 	slotsHasObjectBucket                 = []byte("slots-has-objects")
+	forkChoiceBucket                     = []byte("fork-choice")
 
 	// Key indices buckets.
 	blockParentRootIndicesBucket        = []byte("block-parent-root-indices")
@@ -44,6 +45,7 @@ var (
 	lastArchivedIndexKey      = []byte("last-archived")
 	savedBlockSlotsKey        = []byte("saved-block-slots")
 	savedStateSlotsKey        = []byte("saved-state-slots")
+	forkChoiceSnapshotKey     = []byte("fork-choice-snapshot")
 
 	// New state management service compatibility bucket.
 	newStateServiceCompatibleBucket = []byte("new-state-compatible")
@@ -116,4 +116,11 @@ var (
 		Name:  "disable-discv5",
 		Usage: "Does not run the discoveryV5 dht.",
 	}
+	// WeakSubjectivityCheckpt defines the weak subjectivity checkpoint the node's finalized chain must include.
+	WeakSubjectivityCheckpt = &cli.StringFlag{
+		Name: "weak-subjectivity-checkpoint",
+		Usage: "Input in `block_root:epoch_number` format. This guarantees that syncing leads to the given weak " +
+			"subjectivity checkpoint being in the canonical chain. Chains conflicting with it are refused. " +
+			"The block root must be 0x prefixed, 32 bytes hex.",
+	}
 )
@@ -2,6 +2,7 @@ package flags
 
 import (
 	"github.com/prysmaticlabs/prysm/shared/cmd"
+	"github.com/prysmaticlabs/prysm/shared/params"
 	log "github.com/sirupsen/logrus"
 	"gopkg.in/urfave/cli.v2"
 )
@@ -35,6 +36,16 @@ func Init(c *GlobalFlags) {
 	globalConfig = c
 }
 
+// RequiredSyncPeers is the number of peers that need to agree on a sync target, both for
+// initial sync and for syncing to the head of peers while finality is stalled.
+func RequiredSyncPeers() int {
+	required := params.BeaconConfig().MaxPeersToSync
+	if Get().MinimumSyncPeers < required {
+		required = Get().MinimumSyncPeers
+	}
+	return required
+}
+
 // ConfigureGlobalFlags initializes the global config.
 // based on the provided cli context.
 func ConfigureGlobalFlags(ctx *cli.Context) {
@@ -13,6 +13,9 @@ type ForkChoicer interface {
 	AttestationProcessor // to track new attestation for fork choice.
 	Pruner               // to clean old data for fork choice.
 	Getter               // to retrieve fork choice information.
+	ProposerBooster      // to boost the weight of timely blocks.
+	SlashingProcessor    // to discount the votes of equivocating validators.
+	Marshaler            // to persist fork choice across restarts.
 }
 
 // HeadRetriever retrieves head root of the current chain.
@@ -30,6 +33,22 @@ type AttestationProcessor interface {
 	ProcessAttestation(context.Context, []uint64, [32]byte, uint64)
 }
 
+// ProposerBooster boosts the weight of a block received in a timely manner during its slot.
+type ProposerBooster interface {
+	BoostProposerRoot(context.Context, [32]byte)
+	ResetBoostedProposerRoot(context.Context)
+}
+
+// SlashingProcessor processes slashed validators to remove their votes from fork choice.
+type SlashingProcessor interface {
+	InsertSlashedIndex(context.Context, uint64)
+}
+
+// Marshaler encodes the fork choice store so it can be saved to disk.
+type Marshaler interface {
+	Marshal(context.Context) ([]byte, error)
+}
+
 // Pruner prunes the fork choice upon new finalization. This is used to keep fork choice sane.
 type Pruner interface {
 	Prune(context.Context, [32]byte) error
@@ -40,4 +59,8 @@ type Getter interface {
 	Nodes() []*protoarray.Node
 	Node([32]byte) *protoarray.Node
 	HasNode([32]byte) bool
+	JustifiedEpoch() uint64
+	FinalizedEpoch() uint64
+	Vote(uint64) (protoarray.Vote, bool)
+	Balances() []uint64
 }
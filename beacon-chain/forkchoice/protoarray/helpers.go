@@ -15,6 +15,7 @@ func computeDeltas(
 	votes []Vote,
 	oldBalances []uint64,
 	newBalances []uint64,
+	equivocatingIndices map[uint64]bool,
 ) ([]int, []Vote, error) {
 	ctx, span := trace.StartSpan(ctx, "protoArrayForkChoice.computeDeltas")
 	defer span.End()
@@ -31,6 +32,12 @@ func computeDeltas(
 			continue
 		}
 
+		// Skip equivocating validators, their last vote was already removed from the tree
+		// when they got slashed.
+		if equivocatingIndices[uint64(validatorIndex)] {
+			continue
+		}
+
 		// If the validator index did not exist in `oldBalance` or `newBalance` list above, the balance is just 0.
 		if validatorIndex < len(oldBalances) {
 			oldBalance = oldBalances[validatorIndex]
@@ -71,6 +78,17 @@ func computeDeltas(
 	return deltas, votes, nil
 }
 
+// This computes the score added to a timely block of the current slot. It's a share of the
+// average weight of the committees of a single slot, as given by the justified balances.
+func computeProposerBoostScore(justifiedBalances []uint64) uint64 {
+	totalBalance := uint64(0)
+	for _, balance := range justifiedBalances {
+		totalBalance += balance
+	}
+	committeeWeight := totalBalance / params.BeaconConfig().SlotsPerEpoch
+	return committeeWeight * params.BeaconConfig().ProposerScoreBoost / 100
+}
+
 // This return a copy of the proto array node object.
 func copyNode(node *Node) *Node {
 	if node == nil {
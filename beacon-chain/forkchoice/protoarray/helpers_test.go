@@ -23,7 +23,7 @@ func TestComputeDelta_ZeroHash(t *testing.T) {
 		newBalances = append(newBalances, 0)
 	}
 
-	delta, _, err := computeDeltas(context.Background(), indices, votes, oldBalances, newBalances)
+	delta, _, err := computeDeltas(context.Background(), indices, votes, oldBalances, newBalances, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -57,7 +57,7 @@ func TestComputeDelta_AllVoteTheSame(t *testing.T) {
 		newBalances = append(newBalances, balance)
 	}
 
-	delta, _, err := computeDeltas(context.Background(), indices, votes, oldBalances, newBalances)
+	delta, _, err := computeDeltas(context.Background(), indices, votes, oldBalances, newBalances, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -99,7 +99,7 @@ func TestComputeDelta_DifferentVotes(t *testing.T) {
 		newBalances = append(newBalances, balance)
 	}
 
-	delta, _, err := computeDeltas(context.Background(), indices, votes, oldBalances, newBalances)
+	delta, _, err := computeDeltas(context.Background(), indices, votes, oldBalances, newBalances, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -136,7 +136,7 @@ func TestComputeDelta_MovingVotes(t *testing.T) {
 		newBalances = append(newBalances, balance)
 	}
 
-	delta, _, err := computeDeltas(context.Background(), indices, votes, oldBalances, newBalances)
+	delta, _, err := computeDeltas(context.Background(), indices, votes, oldBalances, newBalances, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -179,7 +179,7 @@ func TestComputeDelta_MoveOutOfTree(t *testing.T) {
 	votes = append(votes, Vote{indexToHash(1), params.BeaconConfig().ZeroHash, 0})
 	votes = append(votes, Vote{indexToHash(1), [32]byte{'A'}, 0})
 
-	delta, _, err := computeDeltas(context.Background(), indices, votes, oldBalances, newBalances)
+	delta, _, err := computeDeltas(context.Background(), indices, votes, oldBalances, newBalances, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -216,7 +216,7 @@ func TestComputeDelta_ChangingBalances(t *testing.T) {
 		newBalances = append(newBalances, newBalance)
 	}
 
-	delta, _, err := computeDeltas(context.Background(), indices, votes, oldBalances, newBalances)
+	delta, _, err := computeDeltas(context.Background(), indices, votes, oldBalances, newBalances, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -259,7 +259,7 @@ func TestComputeDelta_ValidatorAppear(t *testing.T) {
 	votes = append(votes, Vote{indexToHash(1), indexToHash(2), 0})
 	votes = append(votes, Vote{indexToHash(1), indexToHash(2), 0})
 
-	delta, _, err := computeDeltas(context.Background(), indices, votes, oldBalances, newBalances)
+	delta, _, err := computeDeltas(context.Background(), indices, votes, oldBalances, newBalances, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -295,7 +295,7 @@ func TestComputeDelta_ValidatorDisappears(t *testing.T) {
 	votes = append(votes, Vote{indexToHash(1), indexToHash(2), 0})
 	votes = append(votes, Vote{indexToHash(1), indexToHash(2), 0})
 
-	delta, _, err := computeDeltas(context.Background(), indices, votes, oldBalances, newBalances)
+	delta, _, err := computeDeltas(context.Background(), indices, votes, oldBalances, newBalances, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -318,6 +318,40 @@ func TestComputeDelta_ValidatorDisappears(t *testing.T) {
 	}
 }
 
+func TestComputeDelta_EquivocatingValidator(t *testing.T) {
+	balance := uint64(32)
+	indices := map[[32]byte]uint64{indexToHash(1): 0, indexToHash(2): 1}
+	votes := []Vote{
+		{currentRoot: indexToHash(1), nextRoot: indexToHash(2)},
+		{currentRoot: indexToHash(1), nextRoot: indexToHash(2)},
+	}
+	balances := []uint64{balance, balance}
+
+	delta, _, err := computeDeltas(context.Background(), indices, votes, balances, balances, map[uint64]bool{0: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Only the vote of validator 1 moves from block 1 to block 2.
+	if delta[0] != -int(balance) {
+		t.Errorf("Expected delta of block 1 to be %d, got %d", -int(balance), delta[0])
+	}
+	if delta[1] != int(balance) {
+		t.Errorf("Expected delta of block 2 to be %d, got %d", balance, delta[1])
+	}
+}
+
+func TestComputeProposerBoostScore(t *testing.T) {
+	balances := make([]uint64, params.BeaconConfig().SlotsPerEpoch*2)
+	for i := range balances {
+		balances[i] = 10
+	}
+	// The committee weight of a slot is 20, the score is a share of it.
+	want := 20 * params.BeaconConfig().ProposerScoreBoost / 100
+	if got := computeProposerBoostScore(balances); got != want {
+		t.Errorf("Expected proposer boost score %d, got %d", want, got)
+	}
+}
+
 func indexToHash(i uint64) [32]byte {
 	var b [8]byte
 	binary.LittleEndian.PutUint64(b[:], i)
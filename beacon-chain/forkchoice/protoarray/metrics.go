@@ -42,6 +42,12 @@ var (
 			Help: "The number of times an attestation is processed for fork choice.",
 		},
 	)
+	equivocatingValidatorCount = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "proto_array_equivocating_validator_count",
+			Help: "The number of validators whose votes are ignored because of equivocation.",
+		},
+	)
 	prunedCount = promauto.NewCounter(
 		prometheus.CounterOpts{
 			Name: "proto_array_pruned_count",
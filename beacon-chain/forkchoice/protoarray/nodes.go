@@ -111,7 +111,7 @@ func (s *Store) insert(ctx context.Context,
 // and its best child. For each node, it updates the weight with input delta and
 // back propagate the nodes delta to its parents delta. After scoring changes,
 // the best child is then updated along with best descendant.
-func (s *Store) applyWeightChanges(ctx context.Context, justifiedEpoch uint64, finalizedEpoch uint64, delta []int) error {
+func (s *Store) applyWeightChanges(ctx context.Context, justifiedEpoch uint64, finalizedEpoch uint64, newBalances []uint64, delta []int) error {
 	ctx, span := trace.StartSpan(ctx, "protoArrayForkChoice.applyWeightChanges")
 	defer span.End()
 
@@ -126,6 +126,15 @@ func (s *Store) applyWeightChanges(ctx context.Context, justifiedEpoch uint64, f
 		s.finalizedEpoch = finalizedEpoch
 	}
 
+	// The score of the previously boosted block is removed from the tree and the
+	// currently boosted block, if any, gets its score added.
+	s.proposerBoostLock.Lock()
+	defer s.proposerBoostLock.Unlock()
+	proposerScore := uint64(0)
+	if s.proposerBoostRoot != [32]byte{} {
+		proposerScore = computeProposerBoostScore(newBalances)
+	}
+
 	// Iterate backwards through all index to node in store.
 	for i := len(s.nodes) - 1; i >= 0; i-- {
 		n := s.nodes[i]
@@ -137,6 +146,12 @@ func (s *Store) applyWeightChanges(ctx context.Context, justifiedEpoch uint64, f
 		}
 
 		nodeDelta := delta[i]
+		if s.previousProposerBoostRoot != [32]byte{} && n.root == s.previousProposerBoostRoot {
+			nodeDelta -= int(s.previousProposerBoostScore)
+		}
+		if s.proposerBoostRoot != [32]byte{} && n.root == s.proposerBoostRoot {
+			nodeDelta += int(proposerScore)
+		}
 
 		if nodeDelta < 0 {
 			// A node's weight can not be negative but the delta can be negative.
@@ -167,6 +182,9 @@ func (s *Store) applyWeightChanges(ctx context.Context, justifiedEpoch uint64, f
 		}
 	}
 
+	s.previousProposerBoostRoot = s.proposerBoostRoot
+	s.previousProposerBoostScore = proposerScore
+
 	return nil
 }
 
@@ -123,7 +123,7 @@ func TestStore_ApplyScoreChanges_InvalidDeltaLength(t *testing.T) {
 	s := &Store{}
 
 	// This will fail because node indices has length of 0, and delta list has a length of 1.
-	if err := s.applyWeightChanges(context.Background(), 0, 0, []int{1}); err.Error() != errInvalidDeltaLength.Error() {
+	if err := s.applyWeightChanges(context.Background(), 0, 0, []uint64{}, []int{1}); err.Error() != errInvalidDeltaLength.Error() {
 		t.Error("Did not get wanted error")
 	}
 }
@@ -132,7 +132,7 @@ func TestStore_ApplyScoreChanges_UpdateEpochs(t *testing.T) {
 	s := &Store{}
 
 	// The justified and finalized epochs in Store should be updated to 1 and 1 given the following input.
-	if err := s.applyWeightChanges(context.Background(), 1, 1, []int{}); err != nil {
+	if err := s.applyWeightChanges(context.Background(), 1, 1, []uint64{}, []int{}); err != nil {
 		t.Error("Did not get wanted error")
 	}
 
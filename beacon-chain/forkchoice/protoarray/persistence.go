@@ -0,0 +1,235 @@
+package protoarray
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/shared/hashutil"
+	"go.opencensus.io/trace"
+)
+
+// snapshotVersion is bumped whenever the encoding of a fork choice snapshot changes.
+// Snapshots of a different version are rejected.
+const snapshotVersion = byte(2)
+
+// Encoded sizes of the snapshot items.
+const (
+	snapshotHeaderSize   = 1 + 8 + 8 + 32 + 8 + 32 + 32 + 8
+	snapshotNodeSize     = 8 + 32 + 8 + 8 + 8 + 8 + 8 + 8
+	snapshotVoteSize     = 32 + 32 + 8
+	snapshotChecksumSize = 32
+)
+
+var errInvalidSnapshot = errors.New("invalid fork choice snapshot")
+
+// Marshal encodes the fork choice store, including all of its nodes, the validators' latest
+// votes, the justified balances and the equivocating indices, so that it can be persisted
+// and restored after a restart. The proposer boost is encoded as well, as node weights include
+// the boost score until the next weight update removes it. The encoding ends with a checksum
+// of its content.
+func (f *ForkChoice) Marshal(ctx context.Context) ([]byte, error) {
+	ctx, span := trace.StartSpan(ctx, "protoArrayForkChoice.Marshal")
+	defer span.End()
+
+	// The write lock is held as head computations update the votes and nodes under the read lock.
+	f.store.nodeIndicesLock.Lock()
+	defer f.store.nodeIndicesLock.Unlock()
+	f.store.proposerBoostLock.Lock()
+	defer f.store.proposerBoostLock.Unlock()
+
+	buf := new(bytes.Buffer)
+	buf.WriteByte(snapshotVersion)
+	writeUint64(buf, f.store.justifiedEpoch)
+	writeUint64(buf, f.store.finalizedEpoch)
+	buf.Write(f.store.finalizedRoot[:])
+	writeUint64(buf, f.store.pruneThreshold)
+	buf.Write(f.store.proposerBoostRoot[:])
+	buf.Write(f.store.previousProposerBoostRoot[:])
+	writeUint64(buf, f.store.previousProposerBoostScore)
+
+	writeUint64(buf, uint64(len(f.store.nodes)))
+	for _, n := range f.store.nodes {
+		writeUint64(buf, n.Slot)
+		buf.Write(n.root[:])
+		writeUint64(buf, n.Parent)
+		writeUint64(buf, n.justifiedEpoch)
+		writeUint64(buf, n.finalizedEpoch)
+		writeUint64(buf, n.Weight)
+		writeUint64(buf, n.bestChild)
+		writeUint64(buf, n.BestDescendent)
+	}
+
+	writeUint64(buf, uint64(len(f.votes)))
+	for _, v := range f.votes {
+		buf.Write(v.currentRoot[:])
+		buf.Write(v.nextRoot[:])
+		writeUint64(buf, v.nextEpoch)
+	}
+
+	writeUint64(buf, uint64(len(f.balances)))
+	for _, b := range f.balances {
+		writeUint64(buf, b)
+	}
+
+	equivocating := make([]uint64, 0, len(f.equivocatingIndices))
+	for index := range f.equivocatingIndices {
+		equivocating = append(equivocating, index)
+	}
+	sort.Slice(equivocating, func(i, j int) bool {
+		return equivocating[i] < equivocating[j]
+	})
+	writeUint64(buf, uint64(len(equivocating)))
+	for _, index := range equivocating {
+		writeUint64(buf, index)
+	}
+
+	checksum := hashutil.Hash(buf.Bytes())
+	buf.Write(checksum[:])
+	return buf.Bytes(), nil
+}
+
+// Unmarshal restores a fork choice store from an encoding produced by Marshal. The checksum
+// and the consistency of the node indices are verified, an error is returned if any check fails.
+func Unmarshal(ctx context.Context, enc []byte) (*ForkChoice, error) {
+	ctx, span := trace.StartSpan(ctx, "protoArrayForkChoice.Unmarshal")
+	defer span.End()
+
+	if len(enc) < snapshotHeaderSize+snapshotChecksumSize {
+		return nil, errors.Wrap(errInvalidSnapshot, "snapshot is too short")
+	}
+	content := enc[:len(enc)-snapshotChecksumSize]
+	checksum := hashutil.Hash(content)
+	if !bytes.Equal(checksum[:], enc[len(enc)-snapshotChecksumSize:]) {
+		return nil, errors.Wrap(errInvalidSnapshot, "checksum mismatch")
+	}
+	if content[0] != snapshotVersion {
+		return nil, errors.Wrapf(errInvalidSnapshot, "unsupported version %d", content[0])
+	}
+
+	r := &snapshotReader{b: content[1:]}
+	justifiedEpoch := r.uint64()
+	finalizedEpoch := r.uint64()
+	f := New(justifiedEpoch, finalizedEpoch, r.root())
+	f.store.pruneThreshold = r.uint64()
+	f.store.proposerBoostRoot = r.root()
+	f.store.previousProposerBoostRoot = r.root()
+	f.store.previousProposerBoostScore = r.uint64()
+
+	nodesLen := r.length(snapshotNodeSize)
+	for i := uint64(0); i < nodesLen; i++ {
+		n := &Node{
+			Slot:           r.uint64(),
+			root:           r.root(),
+			Parent:         r.uint64(),
+			justifiedEpoch: r.uint64(),
+			finalizedEpoch: r.uint64(),
+			Weight:         r.uint64(),
+			bestChild:      r.uint64(),
+			BestDescendent: r.uint64(),
+		}
+		if _, ok := f.store.nodeIndices[n.root]; ok {
+			return nil, errors.Wrapf(errInvalidSnapshot, "duplicated node %#x", n.root)
+		}
+		f.store.nodeIndices[n.root] = i
+		f.store.nodes = append(f.store.nodes, n)
+	}
+
+	votesLen := r.length(snapshotVoteSize)
+	f.votes = make([]Vote, votesLen)
+	for i := range f.votes {
+		f.votes[i] = Vote{currentRoot: r.root(), nextRoot: r.root(), nextEpoch: r.uint64()}
+	}
+
+	balancesLen := r.length(8)
+	f.balances = make([]uint64, balancesLen)
+	for i := range f.balances {
+		f.balances[i] = r.uint64()
+	}
+
+	equivocatingLen := r.length(8)
+	for i := uint64(0); i < equivocatingLen; i++ {
+		f.equivocatingIndices[r.uint64()] = true
+	}
+
+	if r.err != nil {
+		return nil, errors.Wrap(errInvalidSnapshot, r.err.Error())
+	}
+	if len(r.b) != 0 {
+		return nil, errors.Wrapf(errInvalidSnapshot, "%d unexpected trailing bytes", len(r.b))
+	}
+	if err := f.store.verifyIndices(); err != nil {
+		return nil, err
+	}
+	nodeCount.Set(float64(len(f.store.nodes)))
+	return f, nil
+}
+
+// verifyIndices checks that every index referenced by the nodes is within bounds. A parent
+// always precedes its children in the node list.
+func (s *Store) verifyIndices() error {
+	for i, n := range s.nodes {
+		if n.Parent != nonExistentNode && n.Parent >= uint64(i) {
+			return errors.Wrapf(errInvalidSnapshot, "node %d has invalid parent index %d", i, n.Parent)
+		}
+		if n.bestChild != nonExistentNode && (n.bestChild <= uint64(i) || n.bestChild >= uint64(len(s.nodes))) {
+			return errors.Wrapf(errInvalidSnapshot, "node %d has invalid best child index %d", i, n.bestChild)
+		}
+		if n.BestDescendent != nonExistentNode && (n.BestDescendent <= uint64(i) || n.BestDescendent >= uint64(len(s.nodes))) {
+			return errors.Wrapf(errInvalidSnapshot, "node %d has invalid best descendant index %d", i, n.BestDescendent)
+		}
+	}
+	return nil
+}
+
+func writeUint64(buf *bytes.Buffer, v uint64) {
+	b := make([]byte, 8)
+	binary.LittleEndian.PutUint64(b, v)
+	buf.Write(b)
+}
+
+// snapshotReader decodes the items of a snapshot, recording the first error
+// encountered so that decoding code does not need to check every read.
+type snapshotReader struct {
+	b   []byte
+	err error
+}
+
+func (r *snapshotReader) next(n int) []byte {
+	if r.err != nil {
+		return make([]byte, n)
+	}
+	if len(r.b) < n {
+		r.err = errors.New("unexpected end of snapshot")
+		r.b = nil
+		return make([]byte, n)
+	}
+	b := r.b[:n]
+	r.b = r.b[n:]
+	return b
+}
+
+func (r *snapshotReader) uint64() uint64 {
+	return binary.LittleEndian.Uint64(r.next(8))
+}
+
+func (r *snapshotReader) root() [32]byte {
+	var root [32]byte
+	copy(root[:], r.next(32))
+	return root
+}
+
+// length reads the number of items of a list and verifies that the remaining
+// snapshot is large enough to hold them, so that a corrupted length can not
+// cause an oversized allocation.
+func (r *snapshotReader) length(itemSize int) uint64 {
+	l := r.uint64()
+	if r.err == nil && l > uint64(len(r.b)/itemSize) {
+		r.err = errors.Errorf("list length %d exceeds snapshot size", l)
+		r.b = nil
+		return 0
+	}
+	return l
+}
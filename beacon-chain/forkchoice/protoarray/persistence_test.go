@@ -0,0 +1,170 @@
+package protoarray
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+func persistenceTestStore(t *testing.T) *ForkChoice {
+	ctx := context.Background()
+	f := setup(1, 1)
+	// Define the following tree:
+	//            0
+	//           / \
+	//          1   2
+	//          |
+	//          3
+	if err := f.ProcessBlock(ctx, 1, indexToHash(1), params.BeaconConfig().ZeroHash, 1, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.ProcessBlock(ctx, 1, indexToHash(2), params.BeaconConfig().ZeroHash, 1, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.ProcessBlock(ctx, 2, indexToHash(3), indexToHash(1), 1, 1); err != nil {
+		t.Fatal(err)
+	}
+	f.ProcessAttestation(ctx, []uint64{0, 1}, indexToHash(2), 2)
+	f.ProcessAttestation(ctx, []uint64{2}, indexToHash(3), 2)
+	f.InsertSlashedIndex(ctx, 3)
+	if _, err := f.Head(ctx, 1, params.BeaconConfig().ZeroHash, []uint64{1, 1, 1, 1}, 1); err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+func TestForkChoice_MarshalRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	f := persistenceTestStore(t)
+	enc, err := f.Marshal(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	restored, err := Unmarshal(ctx, enc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(f.Nodes(), restored.Nodes()) {
+		t.Error("Restored nodes are not the same as original")
+	}
+	if !reflect.DeepEqual(f.votes, restored.votes) {
+		t.Error("Restored votes are not the same as original")
+	}
+	if !reflect.DeepEqual(f.balances, restored.balances) {
+		t.Error("Restored balances are not the same as original")
+	}
+	if !reflect.DeepEqual(f.equivocatingIndices, restored.equivocatingIndices) {
+		t.Error("Restored equivocating indices are not the same as original")
+	}
+	if !reflect.DeepEqual(f.store.nodeIndices, restored.store.nodeIndices) {
+		t.Error("Restored node indices are not the same as original")
+	}
+
+	// Both stores keep computing the same head from new votes.
+	f.ProcessAttestation(ctx, []uint64{4, 5}, indexToHash(3), 3)
+	restored.ProcessAttestation(ctx, []uint64{4, 5}, indexToHash(3), 3)
+	balances := []uint64{1, 1, 1, 1, 1, 1}
+	want, err := f.Head(ctx, 1, params.BeaconConfig().ZeroHash, balances, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := restored.Head(ctx, 1, params.BeaconConfig().ZeroHash, balances, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != want || got != indexToHash(3) {
+		t.Errorf("Expected head %#x, got %#x", want, got)
+	}
+}
+
+func TestUnmarshal_IntegrityChecks(t *testing.T) {
+	ctx := context.Background()
+	f := persistenceTestStore(t)
+	enc, err := f.Marshal(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	corrupted := make([]byte, len(enc))
+	copy(corrupted, enc)
+	corrupted[snapshotHeaderSize+10]++
+	if _, err := Unmarshal(ctx, corrupted); err == nil {
+		t.Error("Expected error for corrupted snapshot")
+	}
+	if _, err := Unmarshal(ctx, enc[:len(enc)-1]); err == nil {
+		t.Error("Expected error for truncated snapshot")
+	}
+	if _, err := Unmarshal(ctx, []byte{snapshotVersion}); err == nil {
+		t.Error("Expected error for short snapshot")
+	}
+
+	// A snapshot with a node pointing to an unknown parent is rejected even with a valid checksum.
+	f.store.nodes[1].Parent = 10
+	enc, err = f.Marshal(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Unmarshal(ctx, enc); err == nil {
+		t.Error("Expected error for invalid parent index")
+	}
+}
+
+func TestForkChoice_MarshalRoundTrip_ProposerBoost(t *testing.T) {
+	ctx := context.Background()
+	balances := boostTestBalances()
+	f := setup(1, 1)
+	f.store.pruneThreshold = 7
+	if err := f.ProcessBlock(ctx, 1, indexToHash(1), params.BeaconConfig().ZeroHash, 1, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.ProcessBlock(ctx, 2, indexToHash(2), params.BeaconConfig().ZeroHash, 1, 1); err != nil {
+		t.Fatal(err)
+	}
+	f.ProcessAttestation(ctx, []uint64{0}, indexToHash(1), 2)
+	f.BoostProposerRoot(ctx, indexToHash(2))
+	if _, err := f.Head(ctx, 1, params.BeaconConfig().ZeroHash, balances, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	enc, err := f.Marshal(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	restored, err := Unmarshal(ctx, enc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if restored.store.pruneThreshold != f.store.pruneThreshold {
+		t.Errorf("Expected prune threshold %d, got %d", f.store.pruneThreshold, restored.store.pruneThreshold)
+	}
+	if restored.store.proposerBoostRoot != f.store.proposerBoostRoot {
+		t.Errorf("Expected proposer boost root %#x, got %#x", f.store.proposerBoostRoot, restored.store.proposerBoostRoot)
+	}
+	if restored.store.previousProposerBoostRoot != f.store.previousProposerBoostRoot ||
+		restored.store.previousProposerBoostScore != f.store.previousProposerBoostScore {
+		t.Error("Restored previous proposer boost is not the same as original")
+	}
+
+	// The boost included in the restored node weights is removed on the next slot.
+	f.ResetBoostedProposerRoot(ctx)
+	restored.ResetBoostedProposerRoot(ctx)
+	want, err := f.Head(ctx, 1, params.BeaconConfig().ZeroHash, balances, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := restored.Head(ctx, 1, params.BeaconConfig().ZeroHash, balances, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != want || got != indexToHash(1) {
+		t.Errorf("Expected head %#x, got %#x", want, got)
+	}
+	if w := restored.Node(indexToHash(2)).Weight; w != 0 {
+		t.Errorf("Expected boost to be removed from restored node weight, got %d", w)
+	}
+	if !reflect.DeepEqual(f.Nodes(), restored.Nodes()) {
+		t.Error("Restored nodes are not the same as original")
+	}
+}
@@ -0,0 +1,126 @@
+package protoarray
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+func boostTestBalances() []uint64 {
+	balances := make([]uint64, params.BeaconConfig().SlotsPerEpoch*2)
+	for i := range balances {
+		balances[i] = 10
+	}
+	return balances
+}
+
+func TestProposerBoost_TimelyBlockWinsOverLateBlock(t *testing.T) {
+	ctx := context.Background()
+	balances := boostTestBalances()
+	score := computeProposerBoostScore(balances)
+	if score <= balances[0] {
+		t.Fatalf("Proposer boost score %d should outweigh a single vote", score)
+	}
+	f := setup(1, 1)
+
+	// A late block 1 is released with a single vote, while the honest block 2
+	// arrives on time during its slot:
+	//            0
+	//           / \
+	//  head -> 1   2 <- boosted
+	if err := f.ProcessBlock(ctx, 1, indexToHash(1), params.BeaconConfig().ZeroHash, 1, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.ProcessBlock(ctx, 2, indexToHash(2), params.BeaconConfig().ZeroHash, 1, 1); err != nil {
+		t.Fatal(err)
+	}
+	f.ProcessAttestation(ctx, []uint64{0}, indexToHash(1), 2)
+	r, err := f.Head(ctx, 1, params.BeaconConfig().ZeroHash, balances, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r != indexToHash(1) {
+		t.Error("Incorrect head without proposer boost")
+	}
+
+	f.BoostProposerRoot(ctx, indexToHash(2))
+	r, err = f.Head(ctx, 1, params.BeaconConfig().ZeroHash, balances, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r != indexToHash(2) {
+		t.Error("Expected the boosted block to become head")
+	}
+	// The boost is not applied twice by consecutive head computations.
+	if _, err := f.Head(ctx, 1, params.BeaconConfig().ZeroHash, balances, 1); err != nil {
+		t.Fatal(err)
+	}
+	if w := f.Node(indexToHash(2)).Weight; w != score {
+		t.Errorf("Expected weight of boosted block to be %d, got %d", score, w)
+	}
+
+	// At the start of the next slot the boost is removed.
+	f.ResetBoostedProposerRoot(ctx)
+	r, err = f.Head(ctx, 1, params.BeaconConfig().ZeroHash, balances, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r != indexToHash(1) {
+		t.Error("Incorrect head after proposer boost reset")
+	}
+	if w := f.Node(indexToHash(2)).Weight; w != 0 {
+		t.Errorf("Expected weight of block 2 to be 0 after reset, got %d", w)
+	}
+}
+
+func TestProposerBoost_AppliesToAncestors(t *testing.T) {
+	ctx := context.Background()
+	balances := boostTestBalances()
+	score := computeProposerBoostScore(balances)
+	f := setup(1, 1)
+
+	// Define the following tree where validators 0 and 1 vote for block 2 and
+	// block 3 is boosted:
+	//            0
+	//           / \
+	//          1   2 <- head
+	//          |
+	//          3 <- boosted
+	if err := f.ProcessBlock(ctx, 1, indexToHash(1), params.BeaconConfig().ZeroHash, 1, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.ProcessBlock(ctx, 1, indexToHash(2), params.BeaconConfig().ZeroHash, 1, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.ProcessBlock(ctx, 2, indexToHash(3), indexToHash(1), 1, 1); err != nil {
+		t.Fatal(err)
+	}
+	f.ProcessAttestation(ctx, []uint64{0, 1}, indexToHash(2), 2)
+	f.BoostProposerRoot(ctx, indexToHash(3))
+	r, err := f.Head(ctx, 1, params.BeaconConfig().ZeroHash, balances, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r != indexToHash(2) {
+		t.Error("Expected two votes to outweigh the proposer boost")
+	}
+	if w := f.Node(indexToHash(1)).Weight; w != score {
+		t.Errorf("Expected the boost to propagate to block 1, got weight %d", w)
+	}
+
+	// One more vote for block 1 makes the boosted chain win:
+	//            0
+	//           / \
+	//          1   2
+	//          |
+	//  head -> 3
+	f.ProcessAttestation(ctx, []uint64{2}, indexToHash(1), 2)
+	r, err = f.Head(ctx, 1, params.BeaconConfig().ZeroHash, balances, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r != indexToHash(3) {
+		t.Error("Incorrect head with proposer boost and one vote")
+	}
+}
@@ -29,7 +29,7 @@ func New(justifiedEpoch uint64, finalizedEpoch uint64, finalizedRoot [32]byte) *
 	b := make([]uint64, 0)
 	v := make([]Vote, 0)
 
-	return &ForkChoice{store: s, balances: b, votes: v}
+	return &ForkChoice{store: s, balances: b, votes: v, equivocatingIndices: make(map[uint64]bool)}
 }
 
 // Head returns the head root from fork choice store.
@@ -45,13 +45,13 @@ func (f *ForkChoice) Head(ctx context.Context, justifiedEpoch uint64, justifiedR
 	// The only time it writes to node indices is inserting and pruning blocks from the store.
 	f.store.nodeIndicesLock.RLock()
 	defer f.store.nodeIndicesLock.RUnlock()
-	deltas, newVotes, err := computeDeltas(ctx, f.store.nodeIndices, f.votes, f.balances, newBalances)
+	deltas, newVotes, err := computeDeltas(ctx, f.store.nodeIndices, f.votes, f.balances, newBalances, f.equivocatingIndices)
 	if err != nil {
 		return [32]byte{}, errors.Wrap(err, "Could not compute deltas")
 	}
 	f.votes = newVotes
 
-	if err := f.store.applyWeightChanges(ctx, justifiedEpoch, finalizedEpoch, deltas); err != nil {
+	if err := f.store.applyWeightChanges(ctx, justifiedEpoch, finalizedEpoch, newBalances, deltas); err != nil {
 		return [32]byte{}, errors.Wrap(err, "Could not apply score changes")
 	}
 	f.balances = newBalances
@@ -93,6 +93,58 @@ func (f *ForkChoice) ProcessBlock(ctx context.Context, slot uint64, blockRoot [3
 	return f.store.insert(ctx, slot, blockRoot, parentRoot, justifiedEpoch, finalizedEpoch)
 }
 
+// InsertSlashedIndex marks the validator as equivocating, as proven by an attester slashing.
+// The weight of the validator's latest vote is removed from the tree right away and any vote
+// of the validator is ignored from then on.
+func (f *ForkChoice) InsertSlashedIndex(ctx context.Context, index uint64) {
+	ctx, span := trace.StartSpan(ctx, "protoArrayForkChoice.InsertSlashedIndex")
+	defer span.End()
+
+	f.store.nodeIndicesLock.Lock()
+	defer f.store.nodeIndicesLock.Unlock()
+
+	if f.equivocatingIndices[index] {
+		return
+	}
+	f.equivocatingIndices[index] = true
+	equivocatingValidatorCount.Inc()
+
+	// Nothing to remove if the validator has no vote accounted in the tree.
+	if index >= uint64(len(f.votes)) || index >= uint64(len(f.balances)) {
+		return
+	}
+	nodeIndex, ok := f.store.nodeIndices[f.votes[index].currentRoot]
+	if !ok {
+		return
+	}
+	balance := f.balances[index]
+	for nodeIndex != nonExistentNode && nodeIndex < uint64(len(f.store.nodes)) {
+		n := f.store.nodes[nodeIndex]
+		if n.Weight < balance {
+			n.Weight = 0
+		} else {
+			n.Weight -= balance
+		}
+		nodeIndex = n.Parent
+	}
+}
+
+// BoostProposerRoot boosts the weight of the block with the given root on the next head
+// computations. This is meant for a block of the current slot that was received before the
+// attestation deadline, it's boosted until ResetBoostedProposerRoot is called.
+func (f *ForkChoice) BoostProposerRoot(ctx context.Context, root [32]byte) {
+	f.store.proposerBoostLock.Lock()
+	defer f.store.proposerBoostLock.Unlock()
+	f.store.proposerBoostRoot = root
+}
+
+// ResetBoostedProposerRoot removes the proposer boost. It should be called at the start of every slot.
+func (f *ForkChoice) ResetBoostedProposerRoot(ctx context.Context) {
+	f.store.proposerBoostLock.Lock()
+	defer f.store.proposerBoostLock.Unlock()
+	f.store.proposerBoostRoot = [32]byte{}
+}
+
 // Prune prunes the fork choice store with the new finalized root. The store is only pruned if the input
 // root is different than the current store finalized root, and the number of the store has met prune threshold.
 func (f *ForkChoice) Prune(ctx context.Context, finalizedRoot [32]byte) error {
@@ -128,3 +180,40 @@ func (f *ForkChoice) HasNode(root [32]byte) bool {
 	_, ok := f.store.nodeIndices[root]
 	return ok
 }
+
+// JustifiedEpoch returns the latest justified epoch in the fork choice store.
+func (f *ForkChoice) JustifiedEpoch() uint64 {
+	f.store.nodeIndicesLock.RLock()
+	defer f.store.nodeIndicesLock.RUnlock()
+	return f.store.justifiedEpoch
+}
+
+// FinalizedEpoch returns the latest finalized epoch in the fork choice store.
+func (f *ForkChoice) FinalizedEpoch() uint64 {
+	f.store.nodeIndicesLock.RLock()
+	defer f.store.nodeIndicesLock.RUnlock()
+	return f.store.finalizedEpoch
+}
+
+// Vote returns the copied latest vote of the validator, false is returned if
+// the validator has not voted.
+func (f *ForkChoice) Vote(index uint64) (Vote, bool) {
+	// The write lock is held as head computations update the votes under the read lock.
+	f.store.nodeIndicesLock.Lock()
+	defer f.store.nodeIndicesLock.Unlock()
+
+	if index >= uint64(len(f.votes)) {
+		return Vote{}, false
+	}
+	return f.votes[index], true
+}
+
+// Balances returns the copied justified balances used in the last head computation.
+func (f *ForkChoice) Balances() []uint64 {
+	f.store.nodeIndicesLock.Lock()
+	defer f.store.nodeIndicesLock.Unlock()
+
+	cpy := make([]uint64, len(f.balances))
+	copy(cpy, f.balances)
+	return cpy
+}
@@ -4,20 +4,25 @@ import "sync"
 
 // ForkChoice defines the overall fork choice store which includes all block nodes, validator's latest votes and balances.
 type ForkChoice struct {
-	store    *Store
-	votes    []Vote   // tracks individual validator's last vote.
-	balances []uint64 // tracks individual validator's last justified balances.
+	store               *Store
+	votes               []Vote          // tracks individual validator's last vote.
+	balances            []uint64        // tracks individual validator's last justified balances.
+	equivocatingIndices map[uint64]bool // tracks validators slashed for equivocation, their votes no longer count.
 }
 
 // Store defines the fork choice store which includes block nodes and the last view of checkpoint information.
 type Store struct {
-	pruneThreshold  uint64              // do not prune tree unless threshold is reached.
-	justifiedEpoch  uint64              // latest justified epoch in store.
-	finalizedEpoch  uint64              // latest finalized epoch in store.
-	finalizedRoot   [32]byte            // latest finalized root in store.
-	nodes           []*Node             // list of block nodes, each node is a representation of one block.
-	nodeIndices     map[[32]byte]uint64 // the root of block node and the nodes index in the list.
-	nodeIndicesLock sync.RWMutex
+	pruneThreshold             uint64              // do not prune tree unless threshold is reached.
+	justifiedEpoch             uint64              // latest justified epoch in store.
+	finalizedEpoch             uint64              // latest finalized epoch in store.
+	finalizedRoot              [32]byte            // latest finalized root in store.
+	proposerBoostRoot          [32]byte            // root of the timely block of the current slot which gets boosted.
+	previousProposerBoostRoot  [32]byte            // root of the block boosted during the last weight update.
+	previousProposerBoostScore uint64              // score added to the previously boosted block.
+	nodes                      []*Node             // list of block nodes, each node is a representation of one block.
+	nodeIndices                map[[32]byte]uint64 // the root of block node and the nodes index in the list.
+	nodeIndicesLock            sync.RWMutex
+	proposerBoostLock          sync.Mutex
 }
 
 // Node defines the individual block which includes its block parent, ancestor and how much weight accounted for it.
@@ -42,3 +47,38 @@ type Vote struct {
 
 // This defines an unknown node which is used for the array based stateful DAG.
 const nonExistentNode = ^uint64(0)
+
+// Root returns the block root of the node.
+func (n *Node) Root() [32]byte {
+	return n.root
+}
+
+// JustifiedEpoch returns the justified epoch of the node.
+func (n *Node) JustifiedEpoch() uint64 {
+	return n.justifiedEpoch
+}
+
+// FinalizedEpoch returns the finalized epoch of the node.
+func (n *Node) FinalizedEpoch() uint64 {
+	return n.finalizedEpoch
+}
+
+// BestChild returns the best child index of the node.
+func (n *Node) BestChild() uint64 {
+	return n.bestChild
+}
+
+// CurrentRoot returns the root the vote currently accounts for.
+func (v Vote) CurrentRoot() [32]byte {
+	return v.currentRoot
+}
+
+// NextRoot returns the root the vote will account for on the next head computation.
+func (v Vote) NextRoot() [32]byte {
+	return v.nextRoot
+}
+
+// NextEpoch returns the target epoch of the latest vote.
+func (v Vote) NextEpoch() uint64 {
+	return v.nextEpoch
+}
@@ -394,3 +394,102 @@ func TestVotes_CanFindHead(t *testing.T) {
 		t.Error("Incorrect head for with justified epoch at 2")
 	}
 }
+
+func TestVotes_EquivocatingValidatorsAreIgnored(t *testing.T) {
+	balances := []uint64{1, 1, 1}
+	f := setup(1, 1)
+
+	// Define the following tree, validators 0 and 1 vote for block 1 and validator 2 votes for block 2:
+	//            0
+	//           / \
+	//  head -> 1   2
+	if err := f.ProcessBlock(context.Background(), 0, indexToHash(1), params.BeaconConfig().ZeroHash, 1, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.ProcessBlock(context.Background(), 0, indexToHash(2), params.BeaconConfig().ZeroHash, 1, 1); err != nil {
+		t.Fatal(err)
+	}
+	f.ProcessAttestation(context.Background(), []uint64{0, 1}, indexToHash(1), 2)
+	f.ProcessAttestation(context.Background(), []uint64{2}, indexToHash(2), 2)
+	r, err := f.Head(context.Background(), 1, params.BeaconConfig().ZeroHash, balances, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r != indexToHash(1) {
+		t.Error("Incorrect head with two votes for block 1")
+	}
+
+	// Validators 0 and 1 get slashed, the weight of their votes is removed right away:
+	//            0
+	//           / \
+	//          1   2 <- head
+	f.InsertSlashedIndex(context.Background(), 0)
+	f.InsertSlashedIndex(context.Background(), 1)
+	if w := f.Node(indexToHash(1)).Weight; w != 0 {
+		t.Errorf("Expected weight of block 1 to be 0 after slashing, got %d", w)
+	}
+	r, err = f.Head(context.Background(), 1, params.BeaconConfig().ZeroHash, balances, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r != indexToHash(2) {
+		t.Error("Incorrect head after slashing validators voting for block 1")
+	}
+
+	// New votes of the slashed validators are ignored, and slashing them again is a no-op.
+	f.ProcessAttestation(context.Background(), []uint64{0, 1}, indexToHash(1), 3)
+	f.InsertSlashedIndex(context.Background(), 0)
+	r, err = f.Head(context.Background(), 1, params.BeaconConfig().ZeroHash, balances, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r != indexToHash(2) {
+		t.Error("Incorrect head after slashed validators voted again")
+	}
+	if w := f.Node(indexToHash(1)).Weight; w != 0 {
+		t.Errorf("Expected weight of block 1 to stay 0, got %d", w)
+	}
+	if w := f.Node(indexToHash(2)).Weight; w != 1 {
+		t.Errorf("Expected weight of block 2 to be 1, got %d", w)
+	}
+}
+
+func TestVotes_VoteAndBalancesAccessors(t *testing.T) {
+	ctx := context.Background()
+	balances := []uint64{10, 20}
+	f := setup(1, 1)
+	if err := f.ProcessBlock(ctx, 1, indexToHash(1), params.BeaconConfig().ZeroHash, 1, 1); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := f.Vote(0); ok {
+		t.Error("Expected no vote before any attestation")
+	}
+
+	f.ProcessAttestation(ctx, []uint64{1}, indexToHash(1), 2)
+	v, ok := f.Vote(1)
+	if !ok {
+		t.Fatal("Expected vote for validator 1")
+	}
+	if v.NextRoot() != indexToHash(1) || v.NextEpoch() != 2 {
+		t.Errorf("Unexpected vote, root %#x epoch %d", v.NextRoot(), v.NextEpoch())
+	}
+
+	if _, err := f.Head(ctx, 1, params.BeaconConfig().ZeroHash, balances, 1); err != nil {
+		t.Fatal(err)
+	}
+	v, _ = f.Vote(1)
+	if v.CurrentRoot() != indexToHash(1) {
+		t.Errorf("Expected current root to be updated after head computation, got %#x", v.CurrentRoot())
+	}
+	got := f.Balances()
+	if len(got) != 2 || got[0] != 10 || got[1] != 20 {
+		t.Errorf("Unexpected balances %v", got)
+	}
+	got[0] = 0
+	if f.Balances()[0] != 10 {
+		t.Error("Expected balances to be copied")
+	}
+	if f.JustifiedEpoch() != 1 || f.FinalizedEpoch() != 1 {
+		t.Errorf("Unexpected checkpoints %d %d", f.JustifiedEpoch(), f.FinalizedEpoch())
+	}
+}
@@ -9,6 +9,7 @@ import (
 
 	gwruntime "github.com/grpc-ecosystem/grpc-gateway/runtime"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1_gateway"
+	pbrpc "github.com/prysmaticlabs/prysm/proto/beacon/rpc/v1_gateway"
 	"github.com/prysmaticlabs/prysm/shared"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/connectivity"
@@ -53,6 +54,8 @@ func (g *Gateway) Start() {
 		ethpb.RegisterNodeHandler,
 		ethpb.RegisterBeaconChainHandler,
 		ethpb.RegisterBeaconNodeValidatorHandler,
+		pbrpc.RegisterDebugHandler,
+		pbrpc.RegisterEventsHandler,
 	} {
 		if err := f(ctx, gwmux, conn); err != nil {
 			log.WithError(err).Error("Failed to start gateway")
@@ -61,6 +64,7 @@ func (g *Gateway) Start() {
 		}
 	}
 
+	g.mux.HandleFunc(eventStreamPath, eventStreamHandler(pbrpc.NewEventsClient(conn)))
 	g.mux.Handle("/", gwmux)
 
 	g.server = &http.Server{
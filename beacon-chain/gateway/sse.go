@@ -0,0 +1,87 @@
+package gateway
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	gwruntime "github.com/grpc-ecosystem/grpc-gateway/runtime"
+	pbrpc "github.com/prysmaticlabs/prysm/proto/beacon/rpc/v1_gateway"
+)
+
+// Path under which the event stream is served as server-sent events.
+const eventStreamPath = "/eth/v1alpha1/events/sse"
+
+// eventStreamBufferSize is the number of events buffered for each server-sent event stream.
+// Events received while the buffer of a stream is full are dropped for that stream.
+const eventStreamBufferSize = 256
+
+// eventStreamHandler serves the event stream of the beacon node as server-sent events.
+// Topics are selected with the topics query parameter, for example
+// /eth/v1alpha1/events/sse?topics=head,finalized_checkpoint. All topics are streamed
+// if none is given.
+func eventStreamHandler(client pbrpc.EventsClient) http.HandlerFunc {
+	marshaler := &gwruntime.JSONPb{OrigName: false, EmitDefaults: true}
+	return func(w http.ResponseWriter, r *http.Request) {
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			http.Error(w, "Streaming is not supported", http.StatusInternalServerError)
+			return
+		}
+		req := &pbrpc.StreamEventsRequest{}
+		for _, param := range r.URL.Query()["topics"] {
+			for _, name := range strings.Split(param, ",") {
+				topic, ok := pbrpc.EventTopic_value[strings.ToUpper(strings.TrimSpace(name))]
+				if !ok || topic == int32(pbrpc.EventTopic_UNKNOWN_TOPIC) {
+					http.Error(w, fmt.Sprintf("Unknown event topic %s", name), http.StatusBadRequest)
+					return
+				}
+				req.Topics = append(req.Topics, pbrpc.EventTopic(topic))
+			}
+		}
+
+		stream, err := client.StreamEvents(r.Context(), req)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Could not subscribe to events: %v", err), http.StatusBadGateway)
+			return
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+		w.WriteHeader(http.StatusOK)
+		flusher.Flush()
+
+		// Events are received into a buffer while they are written out, so that a slow client
+		// does not hold up the event stream of the beacon node.
+		events := make(chan *pbrpc.Event, eventStreamBufferSize)
+		go func() {
+			defer close(events)
+			for {
+				e, err := stream.Recv()
+				if err != nil {
+					if r.Context().Err() == nil {
+						log.WithError(err).Debug("Event stream closed")
+					}
+					return
+				}
+				select {
+				case events <- e:
+				default:
+					log.WithField("topic", e.Topic).Warn("Event stream buffer full, dropping event")
+				}
+			}
+		}()
+
+		for e := range events {
+			data, err := marshaler.Marshal(e)
+			if err != nil {
+				log.WithError(err).Error("Could not marshal event")
+				continue
+			}
+			if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", strings.ToLower(e.Topic.String()), data); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
@@ -40,6 +40,7 @@ var appFlags = []cli.Flag{
 	flags.SetGCPercent,
 	flags.UnsafeSync,
 	flags.DisableDiscv5,
+	flags.WeakSubjectivityCheckpt,
 	flags.InteropMockEth1DataVotesFlag,
 	flags.InteropGenesisStateFlag,
 	flags.InteropNumValidatorsFlag,
@@ -62,7 +63,12 @@ var appFlags = []cli.Flag{
 	cmd.P2PPrivKey,
 	cmd.P2PMetadata,
 	cmd.P2PWhitelist,
+	cmd.P2PMaxPeersPerIP,
+	cmd.P2PMaxPeersPerSubnet,
+	cmd.P2POutboundPeerRatio,
+	cmd.P2PDenyList,
 	cmd.P2PEncoding,
+	cmd.P2PRPCEncodings,
 	cmd.P2PPubsub,
 	cmd.DataDirFlag,
 	cmd.VerbosityFlag,
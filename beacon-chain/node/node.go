@@ -16,10 +16,12 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/prysmaticlabs/prysm/beacon-chain/archiver"
 	"github.com/prysmaticlabs/prysm/beacon-chain/blockchain"
 	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
 	"github.com/prysmaticlabs/prysm/beacon-chain/cache/depositcache"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/beacon-chain/db"
 	"github.com/prysmaticlabs/prysm/beacon-chain/flags"
 	"github.com/prysmaticlabs/prysm/beacon-chain/forkchoice"
@@ -72,6 +74,7 @@ type BeaconNode struct {
 	stateFeed         *event.Feed
 	blockFeed         *event.Feed
 	opFeed            *event.Feed
+	gossipFeed        *event.Feed
 	forkChoiceStore   forkchoice.ForkChoicer
 	stateGen          *stategen.State
 }
@@ -100,6 +103,7 @@ func NewBeaconNode(ctx *cli.Context) (*BeaconNode, error) {
 		stateFeed:         new(event.Feed),
 		blockFeed:         new(event.Feed),
 		opFeed:            new(event.Feed),
+		gossipFeed:        new(event.Feed),
 		attestationPool:   attestations.NewPool(),
 		exitPool:          voluntaryexits.NewPool(),
 		slashingsPool:     slashings.NewPool(),
@@ -178,6 +182,11 @@ func (b *BeaconNode) OperationFeed() *event.Feed {
 	return b.opFeed
 }
 
+// GossipFeed implements gossipfeed.Notifier.
+func (b *BeaconNode) GossipFeed() *event.Feed {
+	return b.gossipFeed
+}
+
 // Start the BeaconNode and kicks off every registered service.
 func (b *BeaconNode) Start() {
 	b.lock.Lock()
@@ -307,10 +316,15 @@ func (b *BeaconNode) registerP2P(ctx *cli.Context) error {
 		TCPPort:           ctx.Uint(cmd.P2PTCPPort.Name),
 		UDPPort:           ctx.Uint(cmd.P2PUDPPort.Name),
 		MaxPeers:          ctx.Uint(cmd.P2PMaxPeers.Name),
+		MaxPeersPerIP:     uint(ctx.Uint64(cmd.P2PMaxPeersPerIP.Name)),
+		MaxPeersPerSubnet: uint(ctx.Uint64(cmd.P2PMaxPeersPerSubnet.Name)),
+		OutboundPeerRatio: ctx.Float64(cmd.P2POutboundPeerRatio.Name),
 		WhitelistCIDR:     ctx.String(cmd.P2PWhitelist.Name),
+		DenyListCIDR:      sliceutil.SplitCommaSeparated(ctx.StringSlice(cmd.P2PDenyList.Name)),
 		EnableUPnP:        ctx.Bool(cmd.EnableUPnPFlag.Name),
 		DisableDiscv5:     ctx.Bool(flags.DisableDiscv5.Name),
 		Encoding:          ctx.String(cmd.P2PEncoding.Name),
+		RPCEncodings:      sliceutil.SplitCommaSeparated(ctx.StringSlice(cmd.P2PRPCEncodings.Name)),
 		StateNotifier:     b,
 		PubSub:            ctx.String(cmd.P2PPubsub.Name),
 	})
@@ -349,20 +363,30 @@ func (b *BeaconNode) registerBlockchainService(ctx *cli.Context) error {
 		return err
 	}
 
+	var wsCheckpt *ethpb.Checkpoint
+	if wsCheckptString := ctx.String(flags.WeakSubjectivityCheckpt.Name); wsCheckptString != "" {
+		var err error
+		wsCheckpt, err = helpers.ParseWeakSubjectivityInputString(wsCheckptString)
+		if err != nil {
+			return errors.Wrap(err, "could not parse weak subjectivity checkpoint")
+		}
+	}
+
 	maxRoutines := ctx.Int64(cmd.MaxGoroutines.Name)
 	blockchainService, err := blockchain.NewService(context.Background(), &blockchain.Config{
-		BeaconDB:          b.db,
-		DepositCache:      b.depositCache,
-		ChainStartFetcher: web3Service,
-		AttPool:           b.attestationPool,
-		ExitPool:          b.exitPool,
-		SlashingPool:      b.slashingsPool,
-		P2p:               b.fetchP2P(ctx),
-		MaxRoutines:       maxRoutines,
-		StateNotifier:     b,
-		ForkChoiceStore:   b.forkChoiceStore,
-		OpsService:        opsService,
-		StateGen:          b.stateGen,
+		BeaconDB:                b.db,
+		DepositCache:            b.depositCache,
+		ChainStartFetcher:       web3Service,
+		AttPool:                 b.attestationPool,
+		ExitPool:                b.exitPool,
+		SlashingPool:            b.slashingsPool,
+		P2p:                     b.fetchP2P(ctx),
+		MaxRoutines:             maxRoutines,
+		StateNotifier:           b,
+		ForkChoiceStore:         b.forkChoiceStore,
+		OpsService:              opsService,
+		StateGen:                b.stateGen,
+		WeakSubjectivityCheckpt: wsCheckpt,
 	})
 	if err != nil {
 		return errors.Wrap(err, "could not register blockchain service")
@@ -445,6 +469,7 @@ func (b *BeaconNode) registerSyncService(ctx *cli.Context) error {
 		StateNotifier:       b,
 		BlockNotifier:       b,
 		AttestationNotifier: b,
+		GossipNotifier:      b,
 		AttPool:             b.attestationPool,
 		ExitPool:            b.exitPool,
 		SlashingPool:        b.slashingsPool,
@@ -531,37 +556,47 @@ func (b *BeaconNode) registerRPCService(ctx *cli.Context) error {
 	slasherCert := ctx.String(flags.SlasherCertFlag.Name)
 	slasherProvider := ctx.String(flags.SlasherProviderFlag.Name)
 
+	var p2pService *p2p.Service
+	if err := b.services.FetchService(&p2pService); err != nil {
+		return err
+	}
+
 	mockEth1DataVotes := ctx.Bool(flags.InteropMockEth1DataVotesFlag.Name)
 	rpcService := rpc.NewService(context.Background(), &rpc.Config{
-		Host:                  host,
-		Port:                  port,
-		CertFlag:              cert,
-		KeyFlag:               key,
-		BeaconDB:              b.db,
-		Broadcaster:           b.fetchP2P(ctx),
-		PeersFetcher:          b.fetchP2P(ctx),
-		HeadFetcher:           chainService,
-		ForkFetcher:           chainService,
-		FinalizationFetcher:   chainService,
-		ParticipationFetcher:  chainService,
-		BlockReceiver:         chainService,
-		AttestationReceiver:   chainService,
-		GenesisTimeFetcher:    chainService,
-		AttestationsPool:      b.attestationPool,
-		ExitPool:              b.exitPool,
-		SlashingsPool:         b.slashingsPool,
-		POWChainService:       web3Service,
-		ChainStartFetcher:     chainStartFetcher,
-		MockEth1Votes:         mockEth1DataVotes,
-		SyncService:           syncService,
-		DepositFetcher:        depositFetcher,
-		PendingDepositFetcher: b.depositCache,
-		BlockNotifier:         b,
-		StateNotifier:         b,
-		OperationNotifier:     b,
-		SlasherCert:           slasherCert,
-		SlasherProvider:       slasherProvider,
-		StateGen:              b.stateGen,
+		Host:                    host,
+		Port:                    port,
+		CertFlag:                cert,
+		KeyFlag:                 key,
+		BeaconDB:                b.db,
+		Broadcaster:             b.fetchP2P(ctx),
+		PeersFetcher:            b.fetchP2P(ctx),
+		PubSubProvider:          p2pService,
+		GossipInspector:         p2pService,
+		HeadFetcher:             chainService,
+		ForkFetcher:             chainService,
+		FinalizationFetcher:     chainService,
+		ParticipationFetcher:    chainService,
+		ForkChoiceFetcher:       chainService,
+		WeakSubjectivityFetcher: chainService,
+		BlockReceiver:           chainService,
+		AttestationReceiver:     chainService,
+		GenesisTimeFetcher:      chainService,
+		AttestationsPool:        b.attestationPool,
+		ExitPool:                b.exitPool,
+		SlashingsPool:           b.slashingsPool,
+		POWChainService:         web3Service,
+		ChainStartFetcher:       chainStartFetcher,
+		MockEth1Votes:           mockEth1DataVotes,
+		SyncService:             syncService,
+		DepositFetcher:          depositFetcher,
+		PendingDepositFetcher:   b.depositCache,
+		BlockNotifier:           b,
+		StateNotifier:           b,
+		OperationNotifier:       b,
+		GossipNotifier:          b,
+		SlasherCert:             slasherCert,
+		SlasherProvider:         slasherProvider,
+		StateGen:                b.stateGen,
 	})
 
 	return b.services.RegisterService(rpcService)
@@ -24,8 +24,13 @@ type Config struct {
 	TCPPort               uint
 	UDPPort               uint
 	MaxPeers              uint
+	MaxPeersPerIP         uint
+	MaxPeersPerSubnet     uint
+	OutboundPeerRatio     float64
 	WhitelistCIDR         string
+	DenyListCIDR          []string
 	Encoding              string
+	RPCEncodings          []string
 	StateNotifier         statefeed.Notifier
 	PubSub                string
 }
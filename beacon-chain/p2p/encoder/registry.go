@@ -0,0 +1,93 @@
+package encoder
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ErrUnsupportedEncoding is returned when no registered encoding matches a protocol ID.
+var ErrUnsupportedEncoding = errors.New("unsupported network encoding")
+
+// FromName returns the network encoding for the given encoding name, as used
+// by the p2p encoding flags.
+func FromName(name string) (NetworkEncoding, error) {
+	switch name {
+	case SSZ:
+		return &SszNetworkEncoder{}, nil
+	case SSZSnappy:
+		return &SszNetworkEncoder{UseSnappyCompression: true}, nil
+	default:
+		return nil, fmt.Errorf("unknown network encoding %s", name)
+	}
+}
+
+// Registry holds the network encodings supported for req/resp protocols, in
+// order of preference. The encoding used on a stream is negotiated through
+// the protocol ID suffix, so a node may speak several encodings at once and
+// pick the one a peer supports for every stream.
+type Registry struct {
+	encodings []NetworkEncoding
+}
+
+// NewRegistry creates a registry with the given encoding as the preferred one.
+func NewRegistry(preferred NetworkEncoding) *Registry {
+	return &Registry{encodings: []NetworkEncoding{preferred}}
+}
+
+// Register adds an encoding to the registry with a lower preference than all
+// encodings registered before it.
+func (r *Registry) Register(e NetworkEncoding) error {
+	if e == nil {
+		return errors.New("nil network encoding")
+	}
+	for _, registered := range r.encodings {
+		if registered.ProtocolSuffix() == e.ProtocolSuffix() {
+			return fmt.Errorf("encoding with protocol suffix %s is already registered", e.ProtocolSuffix())
+		}
+	}
+	r.encodings = append(r.encodings, e)
+	return nil
+}
+
+// Preferred returns the most preferred encoding of the registry.
+func (r *Registry) Preferred() NetworkEncoding {
+	return r.encodings[0]
+}
+
+// Encodings returns all registered encodings in order of preference.
+func (r *Registry) Encodings() []NetworkEncoding {
+	encodings := make([]NetworkEncoding, len(r.encodings))
+	copy(encodings, r.encodings)
+	return encodings
+}
+
+// ProtocolIDs returns the protocol IDs of the given base topic for every
+// registered encoding, in order of preference.
+func (r *Registry) ProtocolIDs(baseTopic string) []string {
+	ids := make([]string, len(r.encodings))
+	for i, e := range r.encodings {
+		ids[i] = baseTopic + e.ProtocolSuffix()
+	}
+	return ids
+}
+
+// ForProtocol returns the registered encoding matching the suffix of the
+// given protocol ID. If several suffixes match, the longest one wins.
+func (r *Registry) ForProtocol(protocolID string) (NetworkEncoding, error) {
+	var match NetworkEncoding
+	for _, e := range r.encodings {
+		suffix := e.ProtocolSuffix()
+		if !strings.HasSuffix(protocolID, suffix) {
+			continue
+		}
+		if match == nil || len(suffix) > len(match.ProtocolSuffix()) {
+			match = e
+		}
+	}
+	if match == nil {
+		return nil, errors.Wrapf(ErrUnsupportedEncoding, "protocol %s", protocolID)
+	}
+	return match, nil
+}
@@ -0,0 +1,87 @@
+package encoder_test
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/encoder"
+)
+
+func TestFromName(t *testing.T) {
+	e, err := encoder.FromName(encoder.SSZSnappy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.ProtocolSuffix() != "/ssz_snappy" {
+		t.Errorf("Unexpected protocol suffix %s", e.ProtocolSuffix())
+	}
+	if _, err := encoder.FromName("json"); err == nil {
+		t.Error("Expected error for unknown encoding")
+	}
+}
+
+func TestRegistry_Register(t *testing.T) {
+	r := encoder.NewRegistry(&encoder.SszNetworkEncoder{UseSnappyCompression: true})
+	if err := r.Register(&encoder.SszNetworkEncoder{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Register(&encoder.SszNetworkEncoder{}); err == nil {
+		t.Error("Expected error when registering an encoding twice")
+	}
+	if err := r.Register(nil); err == nil {
+		t.Error("Expected error when registering a nil encoding")
+	}
+	ids := r.ProtocolIDs("/eth2/beacon_chain/req/status/1")
+	wanted := []string{
+		"/eth2/beacon_chain/req/status/1/ssz_snappy",
+		"/eth2/beacon_chain/req/status/1/ssz",
+	}
+	if len(ids) != len(wanted) {
+		t.Fatalf("Expected %d protocol ids, got %d", len(wanted), len(ids))
+	}
+	for i := range wanted {
+		if ids[i] != wanted[i] {
+			t.Errorf("Expected protocol id %s at position %d, got %s", wanted[i], i, ids[i])
+		}
+	}
+	if r.Preferred().ProtocolSuffix() != "/ssz_snappy" {
+		t.Errorf("Unexpected preferred encoding %s", r.Preferred().ProtocolSuffix())
+	}
+}
+
+func TestRegistry_ForProtocol(t *testing.T) {
+	r := encoder.NewRegistry(&encoder.SszNetworkEncoder{})
+	if err := r.Register(&encoder.SszNetworkEncoder{UseSnappyCompression: true}); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		protocol string
+		suffix   string
+		wantErr  bool
+	}{
+		{protocol: "/eth2/beacon_chain/req/ping/1/ssz", suffix: "/ssz"},
+		{protocol: "/eth2/beacon_chain/req/ping/1/ssz_snappy", suffix: "/ssz_snappy"},
+		{protocol: "/eth2/beacon_chain/req/ping/1/ssz_zstd", wantErr: true},
+	}
+	for _, tt := range tests {
+		e, err := r.ForProtocol(tt.protocol)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("Expected error for protocol %s", tt.protocol)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Unexpected error for protocol %s: %v", tt.protocol, err)
+			continue
+		}
+		if e.ProtocolSuffix() != tt.suffix {
+			t.Errorf("Expected suffix %s for protocol %s, got %s", tt.suffix, tt.protocol, e.ProtocolSuffix())
+		}
+	}
+
+	// Encodings registered later are still matched by their exact suffix.
+	r = encoder.NewRegistry(&encoder.SszNetworkEncoder{UseSnappyCompression: true})
+	if _, err := r.ForProtocol("/eth2/beacon_chain/req/ping/1/ssz"); err == nil {
+		t.Error("Expected error for encoding that is not registered")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"io/ioutil"
 
 	"github.com/gogo/protobuf/proto"
 	"github.com/golang/snappy"
@@ -115,14 +116,17 @@ func (e SszNetworkEncoder) doDecode(b []byte, to interface{}) error {
 // Decode the bytes to the protobuf message provided.
 func (e SszNetworkEncoder) Decode(b []byte, to interface{}) error {
 	if e.UseSnappyCompression {
-		newBuffer := bytes.NewBuffer(b)
-		r := snappy.NewReader(newBuffer)
-		newObj := make([]byte, len(b))
-		numOfBytes, err := r.Read(newObj)
+		// The framed format may split the message into several chunks, so
+		// the whole buffer has to be read to decompress it.
+		r := snappy.NewReader(bytes.NewBuffer(b))
+		newObj, err := ioutil.ReadAll(io.LimitReader(r, int64(MaxChunkSize)+1))
 		if err != nil {
 			return err
 		}
-		return e.doDecode(newObj[:numOfBytes], to)
+		if uint64(len(newObj)) > MaxChunkSize {
+			return fmt.Errorf("size of decoded message exceeds max chunk size %d", MaxChunkSize)
+		}
+		return e.doDecode(newObj, to)
 	}
 	return e.doDecode(b, to)
 }
@@ -163,12 +167,13 @@ func (e SszNetworkEncoder) DecodeWithMaxLength(r io.Reader, to interface{}, maxS
 	if msgLen > maxSize {
 		return fmt.Errorf("size of decoded message is %d which is larger than the provided max limit of %d", msgLen, maxSize)
 	}
-	b := make([]byte, e.MaxLength(int(msgLen)))
-	numOfBytes, err := r.Read(b)
-	if err != nil {
+	// The length prefix is the size of the uncompressed message, which may
+	// span several snappy frames or reads from the stream.
+	b := make([]byte, msgLen)
+	if _, err := io.ReadFull(r, b); err != nil {
 		return err
 	}
-	return e.doDecode(b[:numOfBytes], to)
+	return e.doDecode(b, to)
 }
 
 // ProtocolSuffix returns the appropriate suffix for protocol IDs.
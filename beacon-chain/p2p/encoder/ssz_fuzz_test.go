@@ -0,0 +1,100 @@
+package encoder_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	fuzz "github.com/google/gofuzz"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/encoder"
+	testpb "github.com/prysmaticlabs/prysm/proto/testing"
+)
+
+var fuzzEncoders = []*encoder.SszNetworkEncoder{
+	{UseSnappyCompression: false},
+	{UseSnappyCompression: true},
+}
+
+func fuzzedMessage(fuzzer *fuzz.Fuzzer) *testpb.TestSimpleMessage {
+	msg := &testpb.TestSimpleMessage{}
+	fuzzer.Fuzz(&msg.Foo)
+	fuzzer.Fuzz(&msg.Bar)
+	return msg
+}
+
+func TestSszNetworkEncoder_FuzzRoundTrip(t *testing.T) {
+	fuzzer := fuzz.NewWithSeed(0).NilChance(0).NumElements(0, 1<<16)
+	for _, e := range fuzzEncoders {
+		for i := 0; i < 1000; i++ {
+			msg := fuzzedMessage(fuzzer)
+
+			buf := new(bytes.Buffer)
+			if _, err := e.EncodeWithMaxLength(buf, msg, encoder.MaxChunkSize); err != nil {
+				t.Fatal(err)
+			}
+			decoded := &testpb.TestSimpleMessage{}
+			if err := e.DecodeWithMaxLength(buf, decoded, encoder.MaxChunkSize); err != nil {
+				t.Fatalf("Could not decode stream message with %s: %v", e.ProtocolSuffix(), err)
+			}
+			if !proto.Equal(decoded, msg) {
+				t.Fatalf("Decoded stream message is not the same as original with %s", e.ProtocolSuffix())
+			}
+
+			buf = new(bytes.Buffer)
+			if _, err := e.EncodeGossip(buf, msg); err != nil {
+				t.Fatal(err)
+			}
+			decoded = &testpb.TestSimpleMessage{}
+			if err := e.DecodeGossip(buf.Bytes(), decoded); err != nil {
+				t.Fatalf("Could not decode gossip message with %s: %v", e.ProtocolSuffix(), err)
+			}
+			if !proto.Equal(decoded, msg) {
+				t.Fatalf("Decoded gossip message is not the same as original with %s", e.ProtocolSuffix())
+			}
+
+			buf = new(bytes.Buffer)
+			if _, err := e.Encode(buf, msg); err != nil {
+				t.Fatal(err)
+			}
+			decoded = &testpb.TestSimpleMessage{}
+			if err := e.Decode(buf.Bytes(), decoded); err != nil {
+				t.Fatalf("Could not decode message with %s: %v", e.ProtocolSuffix(), err)
+			}
+			if !proto.Equal(decoded, msg) {
+				t.Fatalf("Decoded message is not the same as original with %s", e.ProtocolSuffix())
+			}
+		}
+	}
+}
+
+func TestSszNetworkEncoder_FuzzDecodeRandomInput(t *testing.T) {
+	fuzzer := fuzz.NewWithSeed(0).NilChance(0).NumElements(0, 1024)
+	var input []byte
+	for _, e := range fuzzEncoders {
+		for i := 0; i < 10000; i++ {
+			fuzzer.Fuzz(&input)
+			// Random input must be rejected or decoded, but never panic.
+			_ = e.DecodeWithMaxLength(bytes.NewReader(input), &testpb.TestSimpleMessage{}, encoder.MaxChunkSize)
+			_ = e.DecodeGossip(input, &testpb.TestSimpleMessage{})
+			_ = e.Decode(input, &testpb.TestSimpleMessage{})
+		}
+	}
+}
+
+func TestSszNetworkEncoder_FuzzCrossEncodingRejected(t *testing.T) {
+	fuzzer := fuzz.NewWithSeed(0).NilChance(0).NumElements(1, 1024)
+	plain := &encoder.SszNetworkEncoder{}
+	snappy := &encoder.SszNetworkEncoder{UseSnappyCompression: true}
+	for i := 0; i < 1000; i++ {
+		msg := fuzzedMessage(fuzzer)
+		buf := new(bytes.Buffer)
+		if _, err := plain.EncodeWithLength(buf, msg); err != nil {
+			t.Fatal(err)
+		}
+		// Uncompressed payloads lack the snappy stream identifier and can
+		// never be read as a framed snappy stream.
+		if err := snappy.DecodeWithLength(buf, &testpb.TestSimpleMessage{}); err == nil {
+			t.Fatal("Expected error decoding uncompressed stream as snappy")
+		}
+	}
+}
@@ -0,0 +1,193 @@
+package p2p
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers"
+)
+
+// ipv4SubnetMask is the size of the subnet peers are grouped by when
+// limiting the number of peers per subnet.
+const ipv4SubnetMask = 24
+
+// ipv6SubnetMask is the equivalent grouping for ipv6 addresses.
+const ipv6SubnetMask = 64
+
+// Errors returned when a connection is rejected by the connection gater.
+var (
+	errDeniedAddress    = errors.New("address is in deny list")
+	errIPLimit          = errors.New("too many peers from the same ip")
+	errSubnetLimit      = errors.New("too many peers from the same subnet")
+	errInboundPeerLimit = errors.New("inbound peer limit reached")
+	errPeerLimit        = errors.New("peer limit reached")
+)
+
+// connectionGater decides whether a connection with a peer should be accepted
+// or dialed, based on the peers we are currently connected to. It protects
+// the node against being eclipsed by many peers from a single host or network.
+// Static peers are trusted, and are not subject to the per ip and per subnet limits.
+type connectionGater struct {
+	peers             *peers.Status
+	denyList          []*net.IPNet
+	staticPeers       map[peer.ID]bool
+	maxPeers          int
+	maxPeersPerIP     int
+	maxPeersPerSubnet int
+	outboundSlots     int
+}
+
+// newConnectionGater creates a connection gater from the p2p config. A limit of zero
+// for peers per ip or per subnet disables that check.
+func newConnectionGater(cfg *Config, peerStatus *peers.Status) (*connectionGater, error) {
+	if cfg.OutboundPeerRatio < 0 || cfg.OutboundPeerRatio > 1 {
+		return nil, fmt.Errorf("invalid outbound peer ratio %f", cfg.OutboundPeerRatio)
+	}
+	denyList := make([]*net.IPNet, 0, len(cfg.DenyListCIDR))
+	for _, cidr := range cfg.DenyListCIDR {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid deny list entry %s", cidr)
+		}
+		denyList = append(denyList, ipNet)
+	}
+	staticAddrs, err := peersFromStringAddrs(cfg.StaticPeers)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid static peer")
+	}
+	staticInfos, err := peer.AddrInfosFromP2pAddrs(staticAddrs...)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid static peer")
+	}
+	staticPeers := make(map[peer.ID]bool, len(staticInfos))
+	for _, info := range staticInfos {
+		staticPeers[info.ID] = true
+	}
+	return &connectionGater{
+		peers:             peerStatus,
+		denyList:          denyList,
+		staticPeers:       staticPeers,
+		maxPeers:          int(cfg.MaxPeers),
+		maxPeersPerIP:     int(cfg.MaxPeersPerIP),
+		maxPeersPerSubnet: int(cfg.MaxPeersPerSubnet),
+		outboundSlots:     int(float64(cfg.MaxPeers) * cfg.OutboundPeerRatio),
+	}, nil
+}
+
+// validateDial checks whether we should dial a peer at the given address.
+func (g *connectionGater) validateDial(pid peer.ID, addr ma.Multiaddr) error {
+	if g == nil {
+		return nil
+	}
+	return g.validate(pid, addr, network.DirOutbound)
+}
+
+// validateConnection checks whether an established connection with a peer should be kept.
+// The remote peer itself is excluded from the counts, as it is already tracked in the peer
+// status by the time the connection handler runs.
+func (g *connectionGater) validateConnection(pid peer.ID, addr ma.Multiaddr, direction network.Direction) error {
+	if g == nil {
+		return nil
+	}
+	return g.validate(pid, addr, direction)
+}
+
+func (g *connectionGater) validate(pid peer.ID, addr ma.Multiaddr, direction network.Direction) error {
+	ip, err := ipFromMultiaddr(addr)
+	if err != nil {
+		// Relayed and other non ip addresses are only subject to the peer count limits.
+		ip = nil
+	}
+	if ip != nil && g.denied(ip) {
+		return errDeniedAddress
+	}
+
+	var inbound, sameIP, sameSubnet int
+	active := g.peers.Active()
+	for _, id := range active {
+		if id == pid {
+			continue
+		}
+		if dir, err := g.peers.Direction(id); err == nil && dir == network.DirInbound {
+			inbound++
+		}
+		if ip == nil {
+			continue
+		}
+		peerAddr, err := g.peers.Address(id)
+		if err != nil || peerAddr == nil {
+			continue
+		}
+		peerIP, err := ipFromMultiaddr(peerAddr)
+		if err != nil {
+			continue
+		}
+		if peerIP.Equal(ip) {
+			sameIP++
+		}
+		if sameNetwork(ip, peerIP) {
+			sameSubnet++
+		}
+	}
+	static := pid != "" && g.staticPeers[pid]
+	if !static && g.maxPeersPerIP > 0 && sameIP >= g.maxPeersPerIP {
+		return errIPLimit
+	}
+	if !static && g.maxPeersPerSubnet > 0 && sameSubnet >= g.maxPeersPerSubnet {
+		return errSubnetLimit
+	}
+
+	total := len(active)
+	if pid != "" && g.peers.IsActive(pid) {
+		total--
+	}
+	if total >= g.maxPeers {
+		return errPeerLimit
+	}
+	// A share of our peer slots are reserved for peers we dialed ourselves, as
+	// those are much harder for an attacker to control.
+	if direction == network.DirInbound && inbound >= g.maxPeers-g.outboundSlots {
+		return errInboundPeerLimit
+	}
+	return nil
+}
+
+func (g *connectionGater) denied(ip net.IP) bool {
+	for _, ipNet := range g.denyList {
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func ipFromMultiaddr(addr ma.Multiaddr) (net.IP, error) {
+	if addr == nil {
+		return nil, errors.New("nil multiaddr")
+	}
+	if ip4, err := addr.ValueForProtocol(ma.P_IP4); err == nil {
+		return net.ParseIP(ip4), nil
+	}
+	ip6, err := addr.ValueForProtocol(ma.P_IP6)
+	if err != nil {
+		return nil, errors.Wrap(err, "no ip in multiaddr")
+	}
+	return net.ParseIP(ip6), nil
+}
+
+// sameNetwork returns true if both ips belong to the same /24 (ipv4) or /64 (ipv6) network.
+func sameNetwork(a, b net.IP) bool {
+	if a4, b4 := a.To4(), b.To4(); a4 != nil && b4 != nil {
+		mask := net.CIDRMask(ipv4SubnetMask, 32)
+		return a4.Mask(mask).Equal(b4.Mask(mask))
+	}
+	if a.To4() != nil || b.To4() != nil {
+		return false
+	}
+	mask := net.CIDRMask(ipv6SubnetMask, 128)
+	return a.Mask(mask).Equal(b.Mask(mask))
+}
@@ -0,0 +1,153 @@
+package p2p
+
+import (
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers"
+)
+
+func addConnectedPeer(t *testing.T, p *peers.Status, id int, ip string, direction network.Direction) peer.ID {
+	pid := peer.ID(fmt.Sprintf("peer%d", id))
+	addr, err := ma.NewMultiaddr(fmt.Sprintf("/ip4/%s/tcp/13000", ip))
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.Add(nil, pid, addr, direction)
+	p.SetConnectionState(pid, peers.PeerConnected)
+	return pid
+}
+
+func mustMultiaddr(t *testing.T, s string) ma.Multiaddr {
+	addr, err := ma.NewMultiaddr(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return addr
+}
+
+func TestConnectionGater_InvalidConfig(t *testing.T) {
+	if _, err := newConnectionGater(&Config{OutboundPeerRatio: 1.5}, peers.NewStatus(3)); err == nil {
+		t.Error("Expected error for invalid outbound ratio")
+	}
+	if _, err := newConnectionGater(&Config{DenyListCIDR: []string{"not a cidr"}}, peers.NewStatus(3)); err == nil {
+		t.Error("Expected error for invalid deny list entry")
+	}
+}
+
+func TestConnectionGater_DenyList(t *testing.T) {
+	g, err := newConnectionGater(&Config{MaxPeers: 10, DenyListCIDR: []string{"192.168.0.0/16"}}, peers.NewStatus(3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := g.validateDial("", mustMultiaddr(t, "/ip4/192.168.1.1/tcp/13000")); err != errDeniedAddress {
+		t.Errorf("Expected %v, got %v", errDeniedAddress, err)
+	}
+	if err := g.validateDial("", mustMultiaddr(t, "/ip4/10.0.0.1/tcp/13000")); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
+func TestConnectionGater_PerIPAndSubnetLimits(t *testing.T) {
+	p := peers.NewStatus(3)
+	g, err := newConnectionGater(&Config{MaxPeers: 30, MaxPeersPerIP: 2, MaxPeersPerSubnet: 3}, p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	addConnectedPeer(t, p, 0, "10.0.0.1", network.DirOutbound)
+	addConnectedPeer(t, p, 1, "10.0.0.1", network.DirOutbound)
+	if err := g.validateDial("", mustMultiaddr(t, "/ip4/10.0.0.1/tcp/13000")); err != errIPLimit {
+		t.Errorf("Expected %v, got %v", errIPLimit, err)
+	}
+	if err := g.validateDial("", mustMultiaddr(t, "/ip4/10.0.0.2/tcp/13000")); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	addConnectedPeer(t, p, 2, "10.0.0.3", network.DirOutbound)
+	if err := g.validateDial("", mustMultiaddr(t, "/ip4/10.0.0.4/tcp/13000")); err != errSubnetLimit {
+		t.Errorf("Expected %v, got %v", errSubnetLimit, err)
+	}
+	if err := g.validateDial("", mustMultiaddr(t, "/ip4/10.0.1.4/tcp/13000")); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	// The connecting peer itself is not counted against the limits.
+	pid := addConnectedPeer(t, p, 3, "10.0.2.1", network.DirInbound)
+	if err := g.validateConnection(pid, mustMultiaddr(t, "/ip4/10.0.2.1/tcp/13000"), network.DirInbound); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
+func TestConnectionGater_StaticPeersExemptFromIPLimits(t *testing.T) {
+	p := peers.NewStatus(3)
+	staticID := "16Uiu2HAkyWZ4Ni1TpvDS8dPxsozmHY85KaiFjodQuV6Tz5tkHVeR"
+	g, err := newConnectionGater(&Config{
+		MaxPeers:          30,
+		MaxPeersPerIP:     1,
+		MaxPeersPerSubnet: 1,
+		StaticPeers:       []string{"/ip4/10.0.0.1/tcp/13000/p2p/" + staticID},
+	}, p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	addConnectedPeer(t, p, 0, "10.0.0.1", network.DirOutbound)
+	addr := mustMultiaddr(t, "/ip4/10.0.0.1/tcp/13000")
+	if err := g.validateDial("other", addr); err != errIPLimit {
+		t.Errorf("Expected %v, got %v", errIPLimit, err)
+	}
+	pid, err := peer.IDB58Decode(staticID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := g.validateDial(pid, addr); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if err := g.validateConnection(pid, addr, network.DirInbound); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
+func TestConnectionGater_ReservesOutboundSlots(t *testing.T) {
+	p := peers.NewStatus(3)
+	g, err := newConnectionGater(&Config{MaxPeers: 10, OutboundPeerRatio: 0.5}, p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 5; i++ {
+		addConnectedPeer(t, p, i, fmt.Sprintf("10.0.%d.1", i), network.DirInbound)
+	}
+	addr := mustMultiaddr(t, "/ip4/10.1.0.1/tcp/13000")
+	if err := g.validateConnection("new", addr, network.DirInbound); err != errInboundPeerLimit {
+		t.Errorf("Expected %v, got %v", errInboundPeerLimit, err)
+	}
+	if err := g.validateConnection("new", addr, network.DirOutbound); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	for i := 5; i < 10; i++ {
+		addConnectedPeer(t, p, i, fmt.Sprintf("10.0.%d.1", i), network.DirOutbound)
+	}
+	if err := g.validateDial("", addr); err != errPeerLimit {
+		t.Errorf("Expected %v, got %v", errPeerLimit, err)
+	}
+}
+
+func TestSameNetwork(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{a: "10.0.0.1", b: "10.0.0.200", want: true},
+		{a: "10.0.0.1", b: "10.0.1.1", want: false},
+		{a: "2001:db8::1", b: "2001:db8::2", want: true},
+		{a: "2001:db8:0:1::1", b: "2001:db8:0:2::1", want: false},
+		{a: "10.0.0.1", b: "2001:db8::1", want: false},
+	}
+	for _, tt := range tests {
+		if got := sameNetwork(net.ParseIP(tt.a), net.ParseIP(tt.b)); got != tt.want {
+			t.Errorf("sameNetwork(%s, %s) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package p2p
+
+import (
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	pubsub_pb "github.com/libp2p/go-libp2p-pubsub/pb"
+)
+
+// Interval over which gossip message rates are measured.
+var gossipRateInterval = 30 * time.Second
+
+// TopicStats contains aggregate statistics for a gossip topic.
+type TopicStats struct {
+	MessagesReceived  uint64
+	MessagesPerSecond float64
+}
+
+// gossipTracer follows the events emitted by the pubsub router in order to
+// keep track of our gossipsub mesh for each topic and of the number of
+// messages received on each topic.
+type gossipTracer struct {
+	lock          sync.RWMutex
+	mesh          map[string]map[peer.ID]bool
+	received      map[string]uint64
+	lastReceived  map[string]uint64
+	rates         map[string]float64
+	lastRateCheck time.Time
+}
+
+func newGossipTracer() *gossipTracer {
+	return &gossipTracer{
+		mesh:          make(map[string]map[peer.ID]bool),
+		received:      make(map[string]uint64),
+		lastReceived:  make(map[string]uint64),
+		rates:         make(map[string]float64),
+		lastRateCheck: time.Now(),
+	}
+}
+
+// Trace implements pubsub.EventTracer.
+func (g *gossipTracer) Trace(evt *pubsub_pb.TraceEvent) {
+	switch evt.GetType() {
+	case pubsub_pb.TraceEvent_GRAFT:
+		pid, err := peer.IDFromBytes(evt.GetGraft().GetPeerID())
+		if err != nil {
+			return
+		}
+		g.graft(pid, evt.GetGraft().GetTopic())
+	case pubsub_pb.TraceEvent_PRUNE:
+		pid, err := peer.IDFromBytes(evt.GetPrune().GetPeerID())
+		if err != nil {
+			return
+		}
+		g.prune(pid, evt.GetPrune().GetTopic())
+	case pubsub_pb.TraceEvent_RECV_RPC:
+		g.lock.Lock()
+		for _, msg := range evt.GetRecvRPC().GetMeta().GetMessages() {
+			for _, topic := range msg.GetTopics() {
+				g.received[topic]++
+			}
+		}
+		g.lock.Unlock()
+	case pubsub_pb.TraceEvent_REMOVE_PEER:
+		pid, err := peer.IDFromBytes(evt.GetRemovePeer().GetPeerID())
+		if err != nil {
+			return
+		}
+		g.lock.Lock()
+		for _, peers := range g.mesh {
+			delete(peers, pid)
+		}
+		g.lock.Unlock()
+	}
+}
+
+func (g *gossipTracer) graft(pid peer.ID, topic string) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	if _, ok := g.mesh[topic]; !ok {
+		g.mesh[topic] = make(map[peer.ID]bool)
+	}
+	g.mesh[topic][pid] = true
+}
+
+func (g *gossipTracer) prune(pid peer.ID, topic string) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	delete(g.mesh[topic], pid)
+}
+
+// meshPeers returns the peers in our mesh for the given topic.
+func (g *gossipTracer) meshPeers(topic string) []peer.ID {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+	peers := make([]peer.ID, 0, len(g.mesh[topic]))
+	for pid := range g.mesh[topic] {
+		peers = append(peers, pid)
+	}
+	return peers
+}
+
+// updateRates computes the message rate of each topic since the last update.
+func (g *gossipTracer) updateRates() {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	elapsed := time.Since(g.lastRateCheck).Seconds()
+	if elapsed <= 0 {
+		return
+	}
+	for topic, count := range g.received {
+		g.rates[topic] = float64(count-g.lastReceived[topic]) / elapsed
+		g.lastReceived[topic] = count
+	}
+	g.lastRateCheck = time.Now()
+}
+
+// topicStats returns the message statistics of every topic we received messages on.
+func (g *gossipTracer) topicStats() map[string]*TopicStats {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+	stats := make(map[string]*TopicStats, len(g.received))
+	for topic, count := range g.received {
+		stats[topic] = &TopicStats{
+			MessagesReceived:  count,
+			MessagesPerSecond: g.rates[topic],
+		}
+	}
+	return stats
+}
+
+// MeshPeers returns the peers in our gossipsub mesh for the given topic.
+func (s *Service) MeshPeers(topic string) []peer.ID {
+	return s.gossipTracer.meshPeers(topic)
+}
+
+// TopicStats returns the message statistics of every gossip topic, keyed by topic name.
+func (s *Service) TopicStats() map[string]*TopicStats {
+	return s.gossipTracer.topicStats()
+}
@@ -0,0 +1,75 @@
+package p2p
+
+import (
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	pubsub_pb "github.com/libp2p/go-libp2p-pubsub/pb"
+)
+
+func traceEvent(typ pubsub_pb.TraceEvent_Type) *pubsub_pb.TraceEvent {
+	return &pubsub_pb.TraceEvent{Type: &typ}
+}
+
+func TestGossipTracer_Mesh(t *testing.T) {
+	g := newGossipTracer()
+	topic := "/eth2/00000000/beacon_block/ssz_snappy"
+	pid := peer.ID("peer1")
+
+	graft := traceEvent(pubsub_pb.TraceEvent_GRAFT)
+	graft.Graft = &pubsub_pb.TraceEvent_Graft{PeerID: []byte(pid), Topic: &topic}
+	g.Trace(graft)
+	peers := g.meshPeers(topic)
+	if len(peers) != 1 || peers[0] != pid {
+		t.Fatalf("Expected peer in mesh, got %v", peers)
+	}
+
+	prune := traceEvent(pubsub_pb.TraceEvent_PRUNE)
+	prune.Prune = &pubsub_pb.TraceEvent_Prune{PeerID: []byte(pid), Topic: &topic}
+	g.Trace(prune)
+	if peers := g.meshPeers(topic); len(peers) != 0 {
+		t.Errorf("Expected empty mesh after prune, got %v", peers)
+	}
+
+	g.Trace(graft)
+	remove := traceEvent(pubsub_pb.TraceEvent_REMOVE_PEER)
+	remove.RemovePeer = &pubsub_pb.TraceEvent_RemovePeer{PeerID: []byte(pid)}
+	g.Trace(remove)
+	if peers := g.meshPeers(topic); len(peers) != 0 {
+		t.Errorf("Expected empty mesh after peer removal, got %v", peers)
+	}
+}
+
+func TestGossipTracer_MessageRates(t *testing.T) {
+	g := newGossipTracer()
+	topic := "/eth2/00000000/voluntary_exit/ssz_snappy"
+	recv := traceEvent(pubsub_pb.TraceEvent_RECV_RPC)
+	recv.RecvRPC = &pubsub_pb.TraceEvent_RecvRPC{
+		Meta: &pubsub_pb.TraceEvent_RPCMeta{
+			Messages: []*pubsub_pb.TraceEvent_MessageMeta{
+				{Topics: []string{topic}},
+				{Topics: []string{topic}},
+			},
+		},
+	}
+	g.Trace(recv)
+
+	g.lastRateCheck = time.Now().Add(-2 * time.Second)
+	g.updateRates()
+	stats := g.topicStats()
+	if stats[topic] == nil {
+		t.Fatal("Expected stats for topic")
+	}
+	if stats[topic].MessagesReceived != 2 {
+		t.Errorf("Expected 2 messages received, got %d", stats[topic].MessagesReceived)
+	}
+	if stats[topic].MessagesPerSecond <= 0 || stats[topic].MessagesPerSecond > 1 {
+		t.Errorf("Unexpected message rate %f", stats[topic].MessagesPerSecond)
+	}
+
+	g.updateRates()
+	if rate := g.topicStats()[topic].MessagesPerSecond; rate != 0 {
+		t.Errorf("Expected rate to drop to 0 without new messages, got %f", rate)
+	}
+}
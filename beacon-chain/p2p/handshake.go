@@ -27,9 +27,9 @@ func (s *Service) AddConnectionHandler(reqFunc func(ctx context.Context, id peer
 				return
 			}
 			s.peers.Add(nil /* ENR */, conn.RemotePeer(), conn.RemoteMultiaddr(), conn.Stat().Direction)
-			if len(s.peers.Active()) >= int(s.cfg.MaxPeers) {
+			if err := s.gater.validateConnection(conn.RemotePeer(), conn.RemoteMultiaddr(), conn.Stat().Direction); err != nil {
 				go func() {
-					log.WithField("reason", "at peer limit").Trace("Ignoring connection request")
+					log.WithField("reason", err.Error()).Trace("Ignoring connection request")
 					if err := goodbyeFunc(context.Background(), conn.RemotePeer()); err != nil {
 						log.WithError(err).Trace("Unable to send goodbye message to peer")
 					}
@@ -44,6 +44,7 @@ type ConnectionHandler interface {
 // EncodingProvider provides p2p network encoding.
 type EncodingProvider interface {
 	Encoding() encoder.NetworkEncoding
+	EncodingRegistry() *encoder.Registry
 }
 
 // PubSubProvider provides the p2p pubsub protocol.
@@ -75,3 +76,9 @@ type MetadataProvider interface {
 	Metadata() *pb.MetaData
 	MetadataSeq() uint64
 }
+
+// GossipInspector provides information about the gossipsub router of the local peer.
+type GossipInspector interface {
+	MeshPeers(topic string) []peer.ID
+	TopicStats() map[string]*TopicStats
+}
@@ -475,6 +475,62 @@ func (p *Status) BestFinalized(maxPeers int, ourFinalizedEpoch uint64) ([]byte,
 	return targetRoot[:], targetEpoch, potentialPIDs
 }
 
+// BestNonFinalized returns the highest head epoch, beyond our own head epoch, that is shared by at
+// least minPeers connected peers, along with the peers at or beyond that epoch sorted by their
+// head slot in decreasing order. This is used to catch up with the chain head when finality
+// has stalled and peers' finalized checkpoints are no longer ahead of us.
+func (p *Status) BestNonFinalized(minPeers int, ourHeadEpoch uint64) (uint64, []peer.ID) {
+	connected := p.Connected()
+	epochVotes := make(map[uint64]uint64)
+	pidHead := make(map[peer.ID]uint64)
+	potentialPIDs := make([]peer.ID, 0, len(connected))
+	for _, pid := range connected {
+		peerChainState, err := p.ChainState(pid)
+		if err == nil && peerChainState != nil && helpers.SlotToEpoch(peerChainState.HeadSlot) > ourHeadEpoch {
+			epochVotes[helpers.SlotToEpoch(peerChainState.HeadSlot)]++
+			pidHead[pid] = peerChainState.HeadSlot
+			potentialPIDs = append(potentialPIDs, pid)
+		}
+	}
+
+	// Select the target epoch, which is the highest epoch that at least minPeers have reached.
+	// A peer ahead of an epoch has reached it as well, so votes are accumulated from the top.
+	epochs := make([]uint64, 0, len(epochVotes))
+	for epoch := range epochVotes {
+		epochs = append(epochs, epoch)
+	}
+	sort.Slice(epochs, func(i, j int) bool {
+		return epochs[i] > epochs[j]
+	})
+	var targetEpoch uint64
+	var votes uint64
+	for _, epoch := range epochs {
+		votes += epochVotes[epoch]
+		if votes >= uint64(minPeers) {
+			targetEpoch = epoch
+			break
+		}
+	}
+	if targetEpoch == 0 {
+		return 0, []peer.ID{}
+	}
+
+	// Sort PIDs by head slot, in decreasing order.
+	sort.Slice(potentialPIDs, func(i, j int) bool {
+		return pidHead[potentialPIDs[i]] > pidHead[potentialPIDs[j]]
+	})
+
+	// Trim potential peers to those on or after target epoch.
+	for i, pid := range potentialPIDs {
+		if helpers.SlotToEpoch(pidHead[pid]) < targetEpoch {
+			potentialPIDs = potentialPIDs[:i]
+			break
+		}
+	}
+
+	return targetEpoch, potentialPIDs
+}
+
 // fetch is a helper function that fetches a peer status, possibly creating it.
 func (p *Status) fetch(pid peer.ID) *peerStatus {
 	if _, ok := p.status[pid]; !ok {
@@ -652,6 +652,49 @@ func TestBestFinalized_returnsMaxValue(t *testing.T) {
 	}
 }
 
+func TestBestNonFinalized(t *testing.T) {
+	maxBadResponses := 2
+	p := peers.NewStatus(maxBadResponses)
+	slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+
+	headSlots := []uint64{
+		slotsPerEpoch*3 + 1, // Behind our head epoch, not considered.
+		slotsPerEpoch * 5,
+		slotsPerEpoch*5 + 3,
+		slotsPerEpoch*6 + 2,
+		slotsPerEpoch * 9, // Only a single peer has reached epoch 9.
+	}
+	pids := make([]peer.ID, len(headSlots))
+	for i, headSlot := range headSlots {
+		pids[i] = addPeer(t, p, peers.PeerConnected)
+		p.SetChainState(pids[i], &pb.Status{
+			HeadSlot: headSlot,
+		})
+	}
+
+	targetEpoch, bestPeers := p.BestNonFinalized(3, 4)
+	if targetEpoch != 5 {
+		t.Errorf("Wanted target epoch 5, received %d", targetEpoch)
+	}
+	wanted := []peer.ID{pids[4], pids[3], pids[2], pids[1]}
+	if !reflect.DeepEqual(bestPeers, wanted) {
+		t.Errorf("Wanted peers %v sorted by head slot, received %v", wanted, bestPeers)
+	}
+
+	targetEpoch, bestPeers = p.BestNonFinalized(2, 4)
+	if targetEpoch != 6 {
+		t.Errorf("Wanted target epoch 6, received %d", targetEpoch)
+	}
+	if len(bestPeers) != 2 {
+		t.Errorf("Wanted 2 peers, received %d", len(bestPeers))
+	}
+
+	targetEpoch, bestPeers = p.BestNonFinalized(1, 9)
+	if targetEpoch != 0 || len(bestPeers) != 0 {
+		t.Errorf("Expected no peers ahead of our head, received epoch %d and %d peers", targetEpoch, len(bestPeers))
+	}
+}
+
 func TestStatus_CurrentEpoch(t *testing.T) {
 	maxBadResponses := 2
 	p := peers.NewStatus(maxBadResponses)
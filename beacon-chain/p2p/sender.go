@@ -17,19 +17,32 @@ import (
 func (s *Service) Send(ctx context.Context, message interface{}, baseTopic string, pid peer.ID) (network.Stream, error) {
 	ctx, span := trace.StartSpan(ctx, "p2p.Send")
 	defer span.End()
-	topic := baseTopic + s.Encoding().ProtocolSuffix()
-	span.AddAttributes(trace.StringAttribute("topic", topic))
+	span.AddAttributes(trace.StringAttribute("topic", baseTopic))
 
 	// TTFB_TIME (5s) + RESP_TIMEOUT (10s).
 	var deadline = params.BeaconNetworkConfig().TtfbTimeout + params.BeaconNetworkConfig().RespTimeout
 	ctx, cancel := context.WithTimeout(ctx, deadline)
 	defer cancel()
 
-	stream, err := s.host.NewStream(ctx, pid, protocol.ID(topic))
+	// Offer every supported encoding in order of preference and let the
+	// remote peer pick the one used for this stream.
+	registry := s.EncodingRegistry()
+	ids := registry.ProtocolIDs(baseTopic)
+	protocols := make([]protocol.ID, len(ids))
+	for i, id := range ids {
+		protocols[i] = protocol.ID(id)
+	}
+	stream, err := s.host.NewStream(ctx, pid, protocols...)
+	if err != nil {
+		traceutil.AnnotateError(span, err)
+		return nil, err
+	}
+	encoding, err := registry.ForProtocol(string(stream.Protocol()))
 	if err != nil {
 		traceutil.AnnotateError(span, err)
 		return nil, err
 	}
+	span.AddAttributes(trace.StringAttribute("protocol", string(stream.Protocol())))
 	if err := stream.SetReadDeadline(time.Now().Add(deadline)); err != nil {
 		traceutil.AnnotateError(span, err)
 		return nil, err
@@ -43,7 +56,7 @@ func (s *Service) Send(ctx context.Context, message interface{}, baseTopic strin
 		return stream, nil
 	}
 
-	if _, err := s.Encoding().EncodeWithLength(stream, message); err != nil {
+	if _, err := encoding.EncodeWithLength(stream, message); err != nil {
 		traceutil.AnnotateError(span, err)
 		return nil, err
 	}
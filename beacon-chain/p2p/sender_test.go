@@ -61,3 +61,69 @@ func TestService_Send(t *testing.T) {
 		t.Errorf("Expected identical message to be received. got %v want %v", rcvd, msg)
 	}
 }
+
+func TestService_Send_NegotiatesEncoding(t *testing.T) {
+	p1 := testp2p.NewTestP2P(t)
+	p2 := testp2p.NewTestP2P(t)
+	p1.Connect(p2)
+
+	cfg := &Config{Encoding: "ssz-snappy", RPCEncodings: []string{"ssz-snappy", "ssz"}}
+	registry, err := encodingRegistry(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	svc := &Service{
+		host:      p1.Host,
+		cfg:       cfg,
+		encodings: registry,
+	}
+
+	msg := &testpb.TestSimpleMessage{
+		Foo: []byte("hello"),
+		Bar: 55,
+	}
+
+	// The remote peer only speaks uncompressed ssz.
+	var wg sync.WaitGroup
+	wg.Add(1)
+	p2.SetStreamHandler("/testing/1/ssz", func(stream network.Stream) {
+		defer wg.Done()
+		rcvd := &testpb.TestSimpleMessage{}
+		if err := p2.Encoding().DecodeWithLength(stream, rcvd); err != nil {
+			t.Error(err)
+			return
+		}
+		if !proto.Equal(rcvd, msg) {
+			t.Errorf("Expected identical message to be received. got %v want %v", rcvd, msg)
+		}
+		if err := stream.Close(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	stream, err := svc.Send(context.Background(), msg, "/testing/1", p2.Host.ID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stream.Protocol() != "/testing/1/ssz" {
+		t.Errorf("Expected stream to negotiate ssz encoding, got %s", stream.Protocol())
+	}
+	testutil.WaitTimeout(&wg, 1*time.Second)
+}
+
+func TestEncodingRegistry(t *testing.T) {
+	registry, err := encodingRegistry(&Config{Encoding: "ssz", RPCEncodings: []string{"ssz-snappy"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	encodings := registry.Encodings()
+	if len(encodings) != 2 {
+		t.Fatalf("Expected 2 encodings, got %d", len(encodings))
+	}
+	if encodings[0].ProtocolSuffix() != "/ssz" || encodings[1].ProtocolSuffix() != "/ssz_snappy" {
+		t.Errorf("Unexpected encoding order %s, %s", encodings[0].ProtocolSuffix(), encodings[1].ProtocolSuffix())
+	}
+	if _, err := encodingRegistry(&Config{Encoding: "ssz", RPCEncodings: []string{"xml"}}); err == nil {
+		t.Error("Expected error for unknown encoding")
+	}
+}
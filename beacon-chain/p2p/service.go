@@ -27,7 +27,6 @@ import (
 	ma "github.com/multiformats/go-multiaddr"
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/go-bitfield"
-	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
 	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
@@ -37,7 +36,6 @@ import (
 	"github.com/prysmaticlabs/prysm/shared"
 	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/prysmaticlabs/prysm/shared/runutil"
-	"github.com/prysmaticlabs/prysm/shared/sliceutil"
 	"github.com/sirupsen/logrus"
 )
 
@@ -74,6 +72,9 @@ type Service struct {
 	cancel                context.CancelFunc
 	cfg                   *Config
 	peers                 *peers.Status
+	gater                 *connectionGater
+	gossipTracer          *gossipTracer
+	encodings             *encoder.Registry
 	dht                   *kaddht.IpfsDHT
 	privKey               *ecdsa.PrivateKey
 	exclusionList         *ristretto.Cache
@@ -109,6 +110,13 @@ func NewService(cfg *Config) (*Service, error) {
 		cfg:           cfg,
 		exclusionList: cache,
 		isPreGenesis:  true,
+		gossipTracer:  newGossipTracer(),
+	}
+
+	s.encodings, err = encodingRegistry(s.cfg)
+	if err != nil {
+		log.WithError(err).Error("Failed to set up network encodings")
+		return nil, err
 	}
 
 	dv5Nodes, kadDHTNodes := parseBootStrapAddrs(s.cfg.BootstrapNodeAddr)
@@ -162,6 +170,7 @@ func NewService(cfg *Config) (*Service, error) {
 		pubsub.WithMessageSigning(false),
 		pubsub.WithStrictSignatureVerification(false),
 		pubsub.WithMessageIdFn(msgIDFunction),
+		pubsub.WithEventTracer(s.gossipTracer),
 	}
 
 	var gs *pubsub.PubSub
@@ -184,6 +193,11 @@ func NewService(cfg *Config) (*Service, error) {
 	s.pubsub = gs
 
 	s.peers = peers.NewStatus(maxBadResponses)
+	s.gater, err = newConnectionGater(s.cfg, s.peers)
+	if err != nil {
+		log.WithError(err).Error("Failed to create connection gater")
+		return nil, err
+	}
 
 	return s, nil
 }
@@ -276,6 +290,8 @@ func (s *Service) Start() {
 	})
 	runutil.RunEvery(s.ctx, time.Hour, s.Peers().Decay)
 	runutil.RunEvery(s.ctx, 10*time.Second, s.updateMetrics)
+	runutil.RunEvery(s.ctx, refreshRate, s.tagSubnetPeers)
+	runutil.RunEvery(s.ctx, gossipRateInterval, s.gossipTracer.updateRates)
 	runutil.RunEvery(s.ctx, refreshRate, func() {
 		currentEpoch := helpers.SlotToEpoch(helpers.SlotsSince(s.genesisTime))
 		s.RefreshENR(currentEpoch)
@@ -327,6 +343,15 @@ func (s *Service) Started() bool {
 	return s.started
 }
 
+// EncodingRegistry returns the encodings supported for req/resp streams, with
+// the configured network encoding as the preferred one.
+func (s *Service) EncodingRegistry() *encoder.Registry {
+	if s.encodings == nil {
+		return encoder.NewRegistry(s.Encoding())
+	}
+	return s.encodings
+}
+
 // Encoding returns the configured networking encoding.
 func (s *Service) Encoding() encoder.NetworkEncoding {
 	encoding := s.cfg.Encoding
@@ -340,6 +365,29 @@ func (s *Service) Encoding() encoder.NetworkEncoding {
 	}
 }
 
+// encodingRegistry registers the configured network encoding followed by the
+// additional encodings accepted on req/resp streams.
+func encodingRegistry(cfg *Config) (*encoder.Registry, error) {
+	preferred, err := encoder.FromName(cfg.Encoding)
+	if err != nil {
+		return nil, err
+	}
+	registry := encoder.NewRegistry(preferred)
+	for _, name := range cfg.RPCEncodings {
+		if name == cfg.Encoding {
+			continue
+		}
+		e, err := encoder.FromName(name)
+		if err != nil {
+			return nil, err
+		}
+		if err := registry.Register(e); err != nil {
+			return nil, err
+		}
+	}
+	return registry, nil
+}
+
 // PubSub returns the p2p pubsub framework.
 func (s *Service) PubSub() *pubsub.PubSub {
 	return s.pubsub
@@ -379,20 +427,18 @@ func (s *Service) MetadataSeq() uint64 {
 // RefreshENR uses an epoch to refresh the enr entry for our node
 // with the tracked committee id's for the epoch, allowing our node
 // to be dynamically discoverable by others given our tracked committee id's.
+// The node's long lived random subnets are rotated here once they expire, and
+// are always advertised alongside the committee id's requested by validators.
 func (s *Service) RefreshENR(epoch uint64) {
+	// The persistent subnets are subscribed to even without discovery, so they
+	// are rotated before checking for discv5.
+	rotatePersistentSubnets(epoch, subnetRandGenerator)
 	// return early if discv5 isnt running
 	if s.dv5Listener == nil {
 		return
 	}
 	bitV := bitfield.NewBitvector64()
-
-	var committees []uint64
-	epochStartSlot := helpers.StartSlot(epoch)
-	for i := epochStartSlot; i < epochStartSlot+2*params.BeaconConfig().SlotsPerEpoch; i++ {
-		committees = append(committees, sliceutil.UnionUint64(cache.CommitteeIDs.GetAttesterCommitteeIDs(i),
-			cache.CommitteeIDs.GetAggregatorCommitteeIDs(i))...)
-	}
-	for _, idx := range committees {
+	for _, idx := range neededSubnets(epoch) {
 		bitV.SetBitAt(idx, true)
 	}
 	currentBitV, err := retrieveBitvector(s.dv5Listener.Self().Record())
@@ -411,16 +457,28 @@ func (s *Service) RefreshENR(epoch uint64) {
 
 // FindPeersWithSubnet performs a network search for peers
 // subscribed to a particular subnet. Then we try to connect
-// with those peers.
+// with those peers until we have at least MinimumPeersInSubnet
+// connected peers for that subnet. Returns true if we have at
+// least one peer subscribed to the subnet.
 func (s *Service) FindPeersWithSubnet(index uint64) (bool, error) {
-	nodes := make([]*enode.Node, searchLimit)
 	if s.dv5Listener == nil {
 		// return if discovery isn't set
 		return false, nil
 	}
+	minPeers := int(params.BeaconNetworkConfig().MinimumPeersInSubnet)
+	subnetPeers := make(map[peer.ID]bool)
+	for _, pid := range s.peers.SubscribedToSubnet(index) {
+		subnetPeers[pid] = true
+	}
+	if len(subnetPeers) >= minPeers {
+		return true, nil
+	}
+	nodes := make([]*enode.Node, searchLimit)
 	num := s.dv5Listener.ReadRandomNodes(nodes)
-	exists := false
 	for _, node := range nodes[:num] {
+		if len(subnetPeers) >= minPeers {
+			break
+		}
 		if node.IP() == nil {
 			continue
 		}
@@ -437,33 +495,31 @@ func (s *Service) FindPeersWithSubnet(index uint64) (bool, error) {
 			continue
 		}
 		for _, comIdx := range subnets {
-			if comIdx == index {
-				multiAddr, err := convertToSingleMultiAddr(node)
-				if err != nil {
-					return false, err
-				}
-				info, err := peer.AddrInfoFromP2pAddr(multiAddr)
-				if err != nil {
-					return false, err
-				}
-				if s.peers.IsActive(info.ID) {
-					exists = true
-					continue
-				}
-				if s.host.Network().Connectedness(info.ID) == network.Connected {
-					exists = true
-					continue
-				}
-				s.peers.Add(node.Record(), info.ID, multiAddr, network.DirUnknown)
-				if err := s.connectWithPeer(*info); err != nil {
-					log.WithError(err).Tracef("Could not connect with peer %s", info.String())
-					continue
-				}
-				exists = true
+			if comIdx != index {
+				continue
+			}
+			multiAddr, err := convertToSingleMultiAddr(node)
+			if err != nil {
+				return len(subnetPeers) > 0, err
+			}
+			info, err := peer.AddrInfoFromP2pAddr(multiAddr)
+			if err != nil {
+				return len(subnetPeers) > 0, err
+			}
+			if s.peers.IsActive(info.ID) || s.host.Network().Connectedness(info.ID) == network.Connected {
+				subnetPeers[info.ID] = true
+				break
 			}
+			s.peers.Add(node.Record(), info.ID, multiAddr, network.DirUnknown)
+			if err := s.connectWithPeer(*info); err != nil {
+				log.WithError(err).Tracef("Could not connect with peer %s", info.String())
+				break
+			}
+			subnetPeers[info.ID] = true
+			break
 		}
 	}
-	return exists, nil
+	return len(subnetPeers) > 0, nil
 }
 
 // AddPingMethod adds the metadata ping rpc method to the p2p service, so that it can
@@ -549,6 +605,11 @@ func (s *Service) connectWithPeer(info peer.AddrInfo) error {
 	if s.Peers().IsBad(info.ID) {
 		return nil
 	}
+	for _, addr := range info.Addrs {
+		if err := s.gater.validateDial(info.ID, addr); err != nil {
+			return errors.Wrapf(err, "not dialing peer at %s", addr)
+		}
+	}
 	if err := s.host.Connect(s.ctx, info); err != nil {
 		s.Peers().IncrementBadResponses(info.ID)
 		return err
@@ -0,0 +1,235 @@
+// Package simnet provides an in-process simulated p2p network for multi-node tests.
+// Every node is backed by a libp2p mocknet host and gossipsub router, and satisfies
+// the p2p.P2P interface so it can be handed to the sync, blockchain and rpc services
+// in place of a real p2p.Service. The network allows configuring link latency,
+// partitions and message drops from a seeded source of randomness so that test
+// scenarios are reproducible.
+package simnet
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/peer"
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/pkg/errors"
+)
+
+// Config for the simulated network.
+type Config struct {
+	// Nodes is the number of nodes to spin up.
+	Nodes int
+	// Seed is used for every random decision taken by the simulated network,
+	// such as node identities and message drops.
+	Seed int64
+	// Latency is the default latency of each link between two nodes.
+	Latency time.Duration
+	// DropRate is the probability in the range [0, 1] of a message being dropped.
+	DropRate float64
+	// Digest is the fork digest used by all nodes when mapping messages to topics.
+	Digest [4]byte
+}
+
+// Network is a set of simulated nodes connected through an in-memory mocknet.
+type Network struct {
+	ctx      context.Context
+	cancel   context.CancelFunc
+	mn       mocknet.Mocknet
+	nodes    []*Node
+	rngLock  sync.Mutex
+	rng      *rand.Rand
+	dropLock sync.RWMutex
+	dropRate float64
+}
+
+// NewNetwork creates a simulated network with cfg.Nodes nodes. The nodes are linked
+// but not connected, call ConnectAll or Connect to establish connections.
+func NewNetwork(ctx context.Context, cfg *Config) (*Network, error) {
+	if cfg.Nodes <= 0 {
+		return nil, errors.New("network requires at least one node")
+	}
+	if cfg.DropRate < 0 || cfg.DropRate > 1 {
+		return nil, fmt.Errorf("invalid drop rate %f", cfg.DropRate)
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	n := &Network{
+		ctx:      ctx,
+		cancel:   cancel,
+		mn:       mocknet.New(ctx),
+		rng:      rand.New(rand.NewSource(cfg.Seed)),
+		dropRate: cfg.DropRate,
+	}
+	n.mn.SetLinkDefaults(mocknet.LinkOptions{Latency: cfg.Latency})
+
+	for i := 0; i < cfg.Nodes; i++ {
+		node, err := n.addNode(i, cfg.Digest)
+		if err != nil {
+			cancel()
+			return nil, errors.Wrapf(err, "could not create node %d", i)
+		}
+		n.nodes = append(n.nodes, node)
+	}
+	if err := n.mn.LinkAll(); err != nil {
+		cancel()
+		return nil, errors.Wrap(err, "could not link nodes")
+	}
+	return n, nil
+}
+
+func (n *Network) addNode(index int, digest [4]byte) (*Node, error) {
+	n.rngLock.Lock()
+	privKey, _, err := crypto.GenerateSecp256k1Key(n.rng)
+	n.rngLock.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	// Each node gets its own address so that IP based logic can tell them apart.
+	addr, err := ma.NewMultiaddr(fmt.Sprintf("/ip4/10.0.%d.%d/tcp/13000", index/256, index%256))
+	if err != nil {
+		return nil, err
+	}
+	h, err := n.mn.AddPeer(privKey, addr)
+	if err != nil {
+		return nil, err
+	}
+	return newNode(n, h, digest)
+}
+
+// Nodes returns all the nodes in the network.
+func (n *Network) Nodes() []*Node {
+	return n.nodes
+}
+
+// Node returns the node at the given index.
+func (n *Network) Node(i int) *Node {
+	return n.nodes[i]
+}
+
+// Connect establishes a connection between the nodes at indices a and b.
+func (n *Network) Connect(a, b int) error {
+	_, err := n.mn.ConnectPeers(n.nodes[a].PeerID(), n.nodes[b].PeerID())
+	return err
+}
+
+// ConnectAll connects every node with every other node.
+func (n *Network) ConnectAll() error {
+	for i := 0; i < len(n.nodes); i++ {
+		for j := i + 1; j < len(n.nodes); j++ {
+			if err := n.Connect(i, j); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// SetLatency sets the latency on the link between the nodes at indices a and b.
+func (n *Network) SetLatency(a, b int, latency time.Duration) {
+	links := n.mn.LinksBetweenPeers(n.nodes[a].PeerID(), n.nodes[b].PeerID())
+	for _, l := range links {
+		opts := l.Options()
+		opts.Latency = latency
+		l.SetOptions(opts)
+	}
+}
+
+// SetDropRate changes the probability of a message being dropped.
+func (n *Network) SetDropRate(rate float64) error {
+	if rate < 0 || rate > 1 {
+		return fmt.Errorf("invalid drop rate %f", rate)
+	}
+	n.dropLock.Lock()
+	defer n.dropLock.Unlock()
+	n.dropRate = rate
+	return nil
+}
+
+// Partition splits the network into the given groups of node indices. All links
+// between nodes of different groups are removed and existing connections are closed.
+// Nodes which are not part of any group are isolated from everyone.
+func (n *Network) Partition(groups ...[]int) error {
+	group := make(map[int]int, len(n.nodes))
+	for i := range n.nodes {
+		group[i] = -1 - i
+	}
+	for g, indices := range groups {
+		for _, i := range indices {
+			if i < 0 || i >= len(n.nodes) {
+				return fmt.Errorf("node index %d out of range", i)
+			}
+			group[i] = g
+		}
+	}
+	for i := 0; i < len(n.nodes); i++ {
+		for j := i + 1; j < len(n.nodes); j++ {
+			if group[i] == group[j] {
+				continue
+			}
+			if err := n.unlink(n.nodes[i].PeerID(), n.nodes[j].PeerID()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Heal removes all partitions by linking every pair of nodes again. Connections
+// which were closed by a partition are not re-established automatically.
+func (n *Network) Heal() error {
+	for i := 0; i < len(n.nodes); i++ {
+		for j := i + 1; j < len(n.nodes); j++ {
+			a, b := n.nodes[i].PeerID(), n.nodes[j].PeerID()
+			if len(n.mn.LinksBetweenPeers(a, b)) > 0 {
+				continue
+			}
+			if _, err := n.mn.LinkPeers(a, b); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Linked returns true if the nodes at indices a and b can reach each other.
+func (n *Network) Linked(a, b int) bool {
+	return len(n.mn.LinksBetweenPeers(n.nodes[a].PeerID(), n.nodes[b].PeerID())) > 0
+}
+
+// Close shuts down all the nodes of the network.
+func (n *Network) Close() error {
+	defer n.cancel()
+	for _, node := range n.nodes {
+		if err := node.Host.Close(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (n *Network) unlink(a, b peer.ID) error {
+	if len(n.mn.LinksBetweenPeers(a, b)) == 0 {
+		return nil
+	}
+	if err := n.mn.DisconnectPeers(a, b); err != nil {
+		return err
+	}
+	return n.mn.UnlinkPeers(a, b)
+}
+
+// shouldDrop decides whether the next message is dropped using the network seed.
+func (n *Network) shouldDrop() bool {
+	n.dropLock.RLock()
+	rate := n.dropRate
+	n.dropLock.RUnlock()
+	if rate == 0 {
+		return false
+	}
+	n.rngLock.Lock()
+	defer n.rngLock.Unlock()
+	return n.rng.Float64() < rate
+}
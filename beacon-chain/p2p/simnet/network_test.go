@@ -0,0 +1,165 @@
+package simnet
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	eth "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
+)
+
+func blockTopic(n *Node) string {
+	format := p2p.GossipTypeMapping[reflect.TypeOf(&eth.SignedBeaconBlock{})]
+	return fmt.Sprintf(format, n.Digest) + n.Encoding().ProtocolSuffix()
+}
+
+func TestNetwork_BroadcastReachesAllNodes(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	net, err := NewNetwork(ctx, &Config{Nodes: 4, Seed: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := net.Close(); err != nil {
+			t.Error(err)
+		}
+	}()
+	if err := net.ConnectAll(); err != nil {
+		t.Fatal(err)
+	}
+
+	var subs []*pubsub.Subscription
+	for _, n := range net.Nodes()[1:] {
+		sub, err := n.PubSub().Subscribe(blockTopic(n))
+		if err != nil {
+			t.Fatal(err)
+		}
+		subs = append(subs, sub)
+	}
+	// Allow gossipsub to form its mesh.
+	time.Sleep(time.Second)
+
+	blk := &eth.SignedBeaconBlock{Block: &eth.BeaconBlock{Slot: 5}}
+	if err := net.Node(0).Broadcast(ctx, blk); err != nil {
+		t.Fatal(err)
+	}
+	for i, s := range subs {
+		msg, err := s.Next(ctx)
+		if err != nil {
+			t.Fatalf("Node %d did not receive block: %v", i+1, err)
+		}
+		if len(msg.Data) == 0 {
+			t.Errorf("Node %d received empty message", i+1)
+		}
+	}
+}
+
+func TestNetwork_Partition(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	net, err := NewNetwork(ctx, &Config{Nodes: 4, Seed: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := net.Close(); err != nil {
+			t.Error(err)
+		}
+	}()
+	if err := net.ConnectAll(); err != nil {
+		t.Fatal(err)
+	}
+	if err := net.Partition([]int{0, 1}, []int{2, 3}); err != nil {
+		t.Fatal(err)
+	}
+	if !net.Linked(0, 1) || !net.Linked(2, 3) {
+		t.Error("Expected nodes within a partition to stay linked")
+	}
+	if net.Linked(1, 2) || net.Linked(0, 3) {
+		t.Error("Expected nodes across partitions to be unlinked")
+	}
+	if err := net.Connect(0, 2); err == nil {
+		t.Error("Expected connection across partition to fail")
+	}
+	if err := net.Heal(); err != nil {
+		t.Fatal(err)
+	}
+	if !net.Linked(1, 2) {
+		t.Error("Expected nodes to be linked after healing")
+	}
+	if err := net.Connect(0, 2); err != nil {
+		t.Errorf("Could not connect after healing: %v", err)
+	}
+}
+
+func TestNetwork_DropRateIsDeterministic(t *testing.T) {
+	decisions := func() []bool {
+		net, err := NewNetwork(context.Background(), &Config{Nodes: 1, Seed: 42, DropRate: 0.5})
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer func() {
+			if err := net.Close(); err != nil {
+				t.Error(err)
+			}
+		}()
+		res := make([]bool, 64)
+		for i := range res {
+			res[i] = net.shouldDrop()
+		}
+		return res
+	}
+	first, second := decisions(), decisions()
+	if !reflect.DeepEqual(first, second) {
+		t.Error("Expected identical drop decisions for the same seed")
+	}
+	dropped := 0
+	for _, d := range first {
+		if d {
+			dropped++
+		}
+	}
+	if dropped == 0 || dropped == len(first) {
+		t.Errorf("Unexpected number of dropped messages %d", dropped)
+	}
+}
+
+func TestNetwork_SameSeedSameIdentities(t *testing.T) {
+	a, err := NewNetwork(context.Background(), &Config{Nodes: 3, Seed: 7})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := a.Close(); err != nil {
+			t.Error(err)
+		}
+	}()
+	b, err := NewNetwork(context.Background(), &Config{Nodes: 3, Seed: 7})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := b.Close(); err != nil {
+			t.Error(err)
+		}
+	}()
+	for i := range a.Nodes() {
+		if a.Node(i).PeerID() != b.Node(i).PeerID() {
+			t.Errorf("Node %d has different identities across networks", i)
+		}
+	}
+}
+
+func TestNetwork_InvalidConfig(t *testing.T) {
+	if _, err := NewNetwork(context.Background(), &Config{}); err == nil {
+		t.Error("Expected error for empty network")
+	}
+	if _, err := NewNetwork(context.Background(), &Config{Nodes: 1, DropRate: 2}); err == nil {
+		t.Error("Expected error for invalid drop rate")
+	}
+}
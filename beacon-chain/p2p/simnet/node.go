@@ -0,0 +1,246 @@
+package simnet
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/libp2p/go-libp2p-core/host"
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/protocol"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pubsub_pb "github.com/libp2p/go-libp2p-pubsub/pb"
+	"github.com/pkg/errors"
+	eth "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/encoder"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/hashutil"
+	"github.com/sirupsen/logrus"
+)
+
+var _ = p2p.P2P(&Node{})
+
+// maxBadResponses mirrors the value used by the p2p service.
+const maxBadResponses = 3
+
+// ErrMessageDropped is returned by Send when the simulated network drops the request.
+var ErrMessageDropped = errors.New("message dropped by simulated network")
+
+// Node is a single member of the simulated network. It implements p2p.P2P.
+type Node struct {
+	Host       host.Host
+	Digest     [4]byte
+	net        *Network
+	pubsub     *pubsub.PubSub
+	peers      *peers.Status
+	metaLock   sync.RWMutex
+	metaData   *pb.MetaData
+	pingMethod func(ctx context.Context, id peer.ID) error
+}
+
+func newNode(n *Network, h host.Host, digest [4]byte) (*Node, error) {
+	ps, err := pubsub.NewGossipSub(n.ctx, h,
+		pubsub.WithMessageSigning(false),
+		pubsub.WithStrictSignatureVerification(false),
+		pubsub.WithMessageIdFn(msgID),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &Node{
+		Host:     h,
+		Digest:   digest,
+		net:      n,
+		pubsub:   ps,
+		peers:    peers.NewStatus(maxBadResponses),
+		metaData: &pb.MetaData{Attnets: make([]byte, 8)},
+	}, nil
+}
+
+// Broadcast a message to the simulated network. The message is silently discarded
+// if the network decides to drop it.
+func (n *Node) Broadcast(ctx context.Context, msg proto.Message) error {
+	var topic string
+	switch m := msg.(type) {
+	case *eth.Attestation:
+		if m.Data == nil {
+			return errors.New("nil attestation data")
+		}
+		format := p2p.GossipTypeMapping[reflect.TypeOf(msg)]
+		topic = fmt.Sprintf(format, n.Digest, m.Data.CommitteeIndex)
+	default:
+		format, ok := p2p.GossipTypeMapping[reflect.TypeOf(msg)]
+		if !ok {
+			return p2p.ErrMessageNotMapped
+		}
+		topic = fmt.Sprintf(format, n.Digest)
+	}
+	buf := new(bytes.Buffer)
+	if _, err := n.Encoding().EncodeGossip(buf, msg); err != nil {
+		return errors.Wrap(err, "could not encode message")
+	}
+	if n.net.shouldDrop() {
+		return nil
+	}
+	return n.pubsub.Publish(topic+n.Encoding().ProtocolSuffix(), buf.Bytes())
+}
+
+// SetStreamHandler sets the protocol handler on the node's host.
+func (n *Node) SetStreamHandler(topic string, handler network.StreamHandler) {
+	n.Host.SetStreamHandler(protocol.ID(topic), handler)
+}
+
+// Encoding returns the ssz snappy encoding used by all simulated nodes.
+func (n *Node) Encoding() encoder.NetworkEncoding {
+	return &encoder.SszNetworkEncoder{UseSnappyCompression: true}
+}
+
+// EncodingRegistry returns a registry with only the ssz snappy encoding.
+func (n *Node) EncodingRegistry() *encoder.Registry {
+	return encoder.NewRegistry(n.Encoding())
+}
+
+// PubSub returns the node's gossipsub router.
+func (n *Node) PubSub() *pubsub.PubSub {
+	return n.pubsub
+}
+
+// Disconnect from a peer.
+func (n *Node) Disconnect(pid peer.ID) error {
+	return n.Host.Network().ClosePeer(pid)
+}
+
+// PeerID returns the peer ID of the node.
+func (n *Node) PeerID() peer.ID {
+	return n.Host.ID()
+}
+
+// RefreshENR is a no-op as simulated nodes do not run discovery.
+func (n *Node) RefreshENR(epoch uint64) {}
+
+// FindPeersWithSubnet returns true if the node is connected to a peer which
+// advertises the subnet in its metadata.
+func (n *Node) FindPeersWithSubnet(index uint64) (bool, error) {
+	return len(n.peers.SubscribedToSubnet(index)) > 0, nil
+}
+
+// AddPingMethod sets the method used to ping peers after a metadata update.
+func (n *Node) AddPingMethod(reqFunc func(ctx context.Context, id peer.ID) error) {
+	n.pingMethod = reqFunc
+}
+
+// Send a message to a specific peer. The returned stream may be used for reading, but has
+// been closed for writing. ErrMessageDropped is returned if the network drops the request.
+func (n *Node) Send(ctx context.Context, msg interface{}, baseTopic string, pid peer.ID) (network.Stream, error) {
+	if n.net.shouldDrop() {
+		return nil, ErrMessageDropped
+	}
+	stream, err := n.Host.NewStream(ctx, pid, protocol.ID(baseTopic+n.Encoding().ProtocolSuffix()))
+	if err != nil {
+		return nil, err
+	}
+	if baseTopic != p2p.RPCMetaDataTopic {
+		if _, err := n.Encoding().EncodeWithLength(stream, msg); err != nil {
+			return nil, err
+		}
+	}
+	if err := stream.Close(); err != nil {
+		return nil, err
+	}
+	return stream, nil
+}
+
+// AddConnectionHandler handles the connection with a newly connected peer by running
+// the handshake function.
+func (n *Node) AddConnectionHandler(reqFunc func(ctx context.Context, id peer.ID) error,
+	goodbyeFunc func(ctx context.Context, id peer.ID) error) {
+	n.Host.Network().Notify(&network.NotifyBundle{
+		ConnectedF: func(net network.Network, conn network.Conn) {
+			// Must be handled in a goroutine as this callback cannot be blocking.
+			go func() {
+				remote := conn.RemotePeer()
+				n.peers.Add(nil /* ENR */, remote, conn.RemoteMultiaddr(), conn.Stat().Direction)
+				n.peers.SetConnectionState(remote, peers.PeerConnecting)
+				if err := reqFunc(n.net.ctx, remote); err != nil {
+					logrus.WithError(err).WithField("peer", remote).Debug("Handshake failed")
+					n.peers.SetConnectionState(remote, peers.PeerDisconnecting)
+					if err := n.Disconnect(remote); err != nil {
+						logrus.WithError(err).Debug("Unable to disconnect from peer")
+					}
+					n.peers.SetConnectionState(remote, peers.PeerDisconnected)
+					return
+				}
+				n.peers.SetConnectionState(remote, peers.PeerConnected)
+			}()
+		},
+	})
+}
+
+// AddDisconnectionHandler updates the peer status and runs the handler on disconnection.
+func (n *Node) AddDisconnectionHandler(handler func(ctx context.Context, id peer.ID) error) {
+	n.Host.Network().Notify(&network.NotifyBundle{
+		DisconnectedF: func(net network.Network, conn network.Conn) {
+			// Must be handled in a goroutine as this callback cannot be blocking.
+			go func() {
+				n.peers.SetConnectionState(conn.RemotePeer(), peers.PeerDisconnecting)
+				if err := handler(n.net.ctx, conn.RemotePeer()); err != nil {
+					logrus.WithError(err).Debug("Disconnect handler failed")
+				}
+				n.peers.SetConnectionState(conn.RemotePeer(), peers.PeerDisconnected)
+			}()
+		},
+	})
+}
+
+// Peers returns the peer status of the node.
+func (n *Node) Peers() *peers.Status {
+	return n.peers
+}
+
+// Metadata returns a copy of the node's metadata.
+func (n *Node) Metadata() *pb.MetaData {
+	n.metaLock.RLock()
+	defer n.metaLock.RUnlock()
+	return proto.Clone(n.metaData).(*pb.MetaData)
+}
+
+// MetadataSeq returns the node's metadata sequence number.
+func (n *Node) MetadataSeq() uint64 {
+	n.metaLock.RLock()
+	defer n.metaLock.RUnlock()
+	return n.metaData.SeqNumber
+}
+
+// SetAttnets updates the attestation subnets advertised in the node's metadata and
+// pings all connected peers if a ping method is registered.
+func (n *Node) SetAttnets(attnets []byte) {
+	n.metaLock.Lock()
+	n.metaData = &pb.MetaData{
+		SeqNumber: n.metaData.SeqNumber + 1,
+		Attnets:   attnets,
+	}
+	n.metaLock.Unlock()
+	if n.pingMethod == nil {
+		return
+	}
+	for _, pid := range n.peers.Connected() {
+		go func(id peer.ID) {
+			if err := n.pingMethod(n.net.ctx, id); err != nil {
+				logrus.WithError(err).WithField("peer", id).Debug("Failed to ping peer")
+			}
+		}(pid)
+	}
+}
+
+// msgID is the same content addressable message ID function used by the p2p service.
+func msgID(pmsg *pubsub_pb.Message) string {
+	h := hashutil.FastSum256(pmsg.Data)
+	return base64.URLEncoding.EncodeToString(h[:])
+}
@@ -1,16 +1,36 @@
 package p2p
 
 import (
+	"math/rand"
+	"sync"
+
 	"github.com/ethereum/go-ethereum/p2p/enode"
 	"github.com/ethereum/go-ethereum/p2p/enr"
 	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/roughtime"
+	"github.com/prysmaticlabs/prysm/shared/sliceutil"
+	"github.com/sirupsen/logrus"
 )
 
 var attestationSubnetCount = params.BeaconNetworkConfig().AttestationSubnetCount
 
 var attSubnetEnrKey = params.BeaconNetworkConfig().AttSubnetKey
 
+// Connection manager tag for peers which are subscribed to subnets we need.
+const subnetPeerTag = "subnets"
+
+// Connection manager tag value for each needed subnet a peer is subscribed to.
+const subnetPeerTagValue = 10
+
+var subnetRandGenerator = rand.New(rand.NewSource(roughtime.Now().UnixNano()))
+
+// rotateSubnetsLock serializes rotations of the persistent subnets, guarding the
+// random generator, which is not safe for concurrent use, and the expiration check.
+var rotateSubnetsLock sync.Mutex
+
 func intializeAttSubnets(node *enode.LocalNode) *enode.LocalNode {
 	bitV := bitfield.NewBitvector64()
 	entry := enr.WithEntry(attSubnetEnrKey, bitV.Bytes())
@@ -41,3 +61,81 @@ func retrieveBitvector(record *enr.Record) (bitfield.Bitvector64, error) {
 	}
 	return bitV, nil
 }
+
+// Rotates the long lived attestation subnets of the node once the current
+// subscription has expired. Every node subscribes to a random set of subnets
+// regardless of the validators attached to it, so that each subnet has a stable
+// backbone of peers. The subscription lasts for a random number of epochs
+// in the range [EPOCHS_PER_RANDOM_SUBNET_SUBSCRIPTION, 2*EPOCHS_PER_RANDOM_SUBNET_SUBSCRIPTION)
+// so that rotations are spread out across the network. Returns true if the
+// subnets were rotated.
+func rotatePersistentSubnets(epoch uint64, randGen *rand.Rand) bool {
+	rotateSubnetsLock.Lock()
+	defer rotateSubnetsLock.Unlock()
+	current := cache.CommitteeIDs.GetPersistentCommitteeIDs()
+	if len(current) > 0 && epoch < cache.CommitteeIDs.PersistentCommitteeIDsExpiration() {
+		return false
+	}
+	count := params.BeaconNetworkConfig().RandomSubnetsPerNode
+	if count > attestationSubnetCount {
+		count = attestationSubnetCount
+	}
+	if count == 0 {
+		return false
+	}
+	perm := randGen.Perm(int(attestationSubnetCount))
+	subnets := make([]uint64, 0, count)
+	for _, idx := range perm[:count] {
+		subnets = append(subnets, uint64(idx))
+	}
+	period := params.BeaconNetworkConfig().EpochsPerRandomSubnetSubscription
+	expiration := epoch + period
+	if period > 0 {
+		expiration += uint64(randGen.Int63n(int64(period)))
+	}
+	cache.CommitteeIDs.SetPersistentCommitteeIDs(subnets, expiration)
+	log.WithFields(logrus.Fields{
+		"subnets":         subnets,
+		"expirationEpoch": expiration,
+	}).Debug("Rotated persistent attestation subnets")
+	return true
+}
+
+// Returns the attestation subnets the node needs at the given epoch, which are its long lived
+// subnets along with the committee subnets requested by validators for this epoch and the next.
+func neededSubnets(epoch uint64) []uint64 {
+	committees := cache.CommitteeIDs.GetPersistentCommitteeIDs()
+	epochStartSlot := helpers.StartSlot(epoch)
+	for i := epochStartSlot; i < epochStartSlot+2*params.BeaconConfig().SlotsPerEpoch; i++ {
+		committees = append(committees, sliceutil.UnionUint64(cache.CommitteeIDs.GetAttesterCommitteeIDs(i),
+			cache.CommitteeIDs.GetAggregatorCommitteeIDs(i))...)
+	}
+	return sliceutil.SetUint64(committees)
+}
+
+// Tags connected peers with the number of needed subnets they are subscribed to, so that
+// the connection manager prunes peers which are of no use to our subnets first.
+func (s *Service) tagSubnetPeers() {
+	currentEpoch := helpers.SlotToEpoch(helpers.SlotsSince(s.genesisTime))
+	subnets := neededSubnets(currentEpoch)
+	cm := s.host.ConnManager()
+	for _, pid := range s.peers.Connected() {
+		md, err := s.peers.Metadata(pid)
+		if err != nil || md == nil {
+			cm.UntagPeer(pid, subnetPeerTag)
+			continue
+		}
+		attnets := bitfield.Bitvector64(md.Attnets)
+		count := 0
+		for _, idx := range subnets {
+			if idx < attnets.Len() && attnets.BitAt(idx) {
+				count++
+			}
+		}
+		if count == 0 {
+			cm.UntagPeer(pid, subnetPeerTag)
+			continue
+		}
+		cm.TagPeer(pid, subnetPeerTag, count*subnetPeerTagValue)
+	}
+}
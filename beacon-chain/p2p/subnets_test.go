@@ -1,6 +1,7 @@
 package p2p
 
 import (
+	"math/rand"
 	"testing"
 	"time"
 
@@ -13,6 +14,7 @@ import (
 	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
 	testDB "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
 	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/params"
 )
 
 func TestStartDiscV5_DiscoverPeersWithSubnets(t *testing.T) {
@@ -144,3 +146,47 @@ func TestStartDiscV5_DiscoverPeersWithSubnets(t *testing.T) {
 	}
 	exitRoutine <- true
 }
+
+func TestRotatePersistentSubnets(t *testing.T) {
+	defer cache.CommitteeIDs.SetPersistentCommitteeIDs(nil, 0)
+	randGen := rand.New(rand.NewSource(1))
+	period := params.BeaconNetworkConfig().EpochsPerRandomSubnetSubscription
+
+	if !rotatePersistentSubnets(10, randGen) {
+		t.Fatal("Expected subnets to be rotated with no prior subscription")
+	}
+	subnets := cache.CommitteeIDs.GetPersistentCommitteeIDs()
+	if uint64(len(subnets)) != params.BeaconNetworkConfig().RandomSubnetsPerNode {
+		t.Errorf("Wanted %d subnets, got %d", params.BeaconNetworkConfig().RandomSubnetsPerNode, len(subnets))
+	}
+	for _, idx := range subnets {
+		if idx >= attestationSubnetCount {
+			t.Errorf("Subnet %d out of range", idx)
+		}
+	}
+	expiration := cache.CommitteeIDs.PersistentCommitteeIDsExpiration()
+	if expiration < 10+period || expiration >= 10+2*period {
+		t.Errorf("Expiration epoch %d outside of expected range", expiration)
+	}
+
+	if rotatePersistentSubnets(expiration-1, randGen) {
+		t.Error("Did not expect subnets to be rotated before expiration")
+	}
+	if !rotatePersistentSubnets(expiration, randGen) {
+		t.Error("Expected subnets to be rotated at expiration")
+	}
+	if cache.CommitteeIDs.PersistentCommitteeIDsExpiration() <= expiration {
+		t.Error("Expected expiration to move forward after rotation")
+	}
+}
+
+func TestRefreshENR_RotatesSubnetsWithoutDiscovery(t *testing.T) {
+	defer cache.CommitteeIDs.SetPersistentCommitteeIDs(nil, 0)
+	cache.CommitteeIDs.SetPersistentCommitteeIDs(nil, 0)
+
+	s := &Service{}
+	s.RefreshENR(10)
+	if len(cache.CommitteeIDs.GetPersistentCommitteeIDs()) == 0 {
+		t.Error("Expected persistent subnets to be rotated without a discv5 listener")
+	}
+}
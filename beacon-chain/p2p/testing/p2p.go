@@ -151,6 +151,11 @@ func (p *TestP2P) Encoding() encoder.NetworkEncoding {
 	return &encoder.SszNetworkEncoder{}
 }
 
+// EncodingRegistry returns a registry with only the ssz encoding.
+func (p *TestP2P) EncodingRegistry() *encoder.Registry {
+	return encoder.NewRegistry(p.Encoding())
+}
+
 // PubSub returns reference underlying floodsub. This test library uses floodsub
 // to ensure all connected peers receive the message.
 func (p *TestP2P) PubSub() *pubsub.PubSub {
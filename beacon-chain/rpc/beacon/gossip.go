@@ -0,0 +1,180 @@
+package beacon
+
+import (
+	"bytes"
+	"context"
+
+	ptypes "github.com/gogo/protobuf/types"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/blockchain"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
+	gossipfeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/gossip"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
+	pbrpc "github.com/prysmaticlabs/prysm/proto/beacon/rpc/v1"
+	"github.com/prysmaticlabs/prysm/shared/attestationutil"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// gossipStreamBufferSize is the number of gossip objects buffered for each gossip stream.
+// Objects received while the buffer of a stream is full are dropped for that stream, so that
+// a slow client does not hold up gossip validation, which sends the objects over the gossip feed.
+const gossipStreamBufferSize = 1024
+
+var _ = pbrpc.GossipServer(&Server{})
+
+// StreamGossipBlocks to clients every time a block is received over gossip, before it is
+// validated against the chain of the beacon node. Blocks rejected afterwards, such as a second
+// block of a proposer for a slot, are streamed as well. Blocks without a valid proposer signature
+// according to the head state are not streamed.
+func (bs *Server) StreamGossipBlocks(_ *ptypes.Empty, stream pbrpc.Gossip_StreamGossipBlocksServer) error {
+	blocksChannel := make(chan *feed.Event, 1)
+	blockSub := bs.GossipNotifier.GossipFeed().Subscribe(blocksChannel)
+	defer blockSub.Unsubscribe()
+	gossipBlocks := make(chan *ethpb.SignedBeaconBlock, gossipStreamBufferSize)
+	go func() {
+		for {
+			select {
+			case event := <-blocksChannel:
+				if event.Type != gossipfeed.BlockReceived {
+					continue
+				}
+				data, ok := event.Data.(*gossipfeed.BlockReceivedData)
+				if !ok || data.SignedBlock == nil || data.SignedBlock.Block == nil {
+					continue
+				}
+				select {
+				case gossipBlocks <- data.SignedBlock:
+				default:
+				}
+			case <-stream.Context().Done():
+				return
+			}
+		}
+	}()
+
+	verifier := &gossipVerifier{headFetcher: bs.HeadFetcher}
+	for {
+		select {
+		case blk := <-gossipBlocks:
+			headState, err := verifier.headState(stream.Context())
+			if err != nil {
+				return status.Errorf(codes.Internal, "Could not get head state: %v", err)
+			}
+			if err := blocks.VerifyBlockHeaderSignature(headState, blk); err != nil {
+				continue
+			}
+			if err := stream.Send(blk); err != nil {
+				return status.Errorf(codes.Unavailable, "Could not send over stream: %v", err)
+			}
+		case <-blockSub.Err():
+			return status.Error(codes.Aborted, "Subscriber closed, exiting goroutine")
+		case <-bs.Ctx.Done():
+			return status.Error(codes.Canceled, "Context canceled")
+		case <-stream.Context().Done():
+			return status.Error(codes.Canceled, "Context canceled")
+		}
+	}
+}
+
+// StreamGossipIndexedAttestations to clients every time an attestation, aggregated or not, is
+// received over gossip, before it is validated against the chain of the beacon node. Attestations
+// rejected afterwards, such as votes for a block the beacon node does not know of, are streamed
+// as well. Attestations are converted into indexed form with the committees of the head state, and
+// are not streamed unless their signature is valid. Unlike StreamIndexedAttestations, attestations
+// are streamed one by one as they arrive, from a buffer of the stream.
+func (bs *Server) StreamGossipIndexedAttestations(
+	_ *ptypes.Empty, stream pbrpc.Gossip_StreamGossipIndexedAttestationsServer,
+) error {
+	attestationsChannel := make(chan *feed.Event, 1)
+	attSub := bs.GossipNotifier.GossipFeed().Subscribe(attestationsChannel)
+	defer attSub.Unsubscribe()
+	gossipAtts := make(chan *ethpb.Attestation, gossipStreamBufferSize)
+	go func() {
+		for {
+			select {
+			case event := <-attestationsChannel:
+				if event.Type != gossipfeed.AttReceived {
+					continue
+				}
+				data, ok := event.Data.(*gossipfeed.AttReceivedData)
+				if !ok || data.Attestation == nil || data.Attestation.Data == nil || data.Attestation.Data.Target == nil {
+					continue
+				}
+				select {
+				case gossipAtts <- data.Attestation:
+				default:
+				}
+			case <-stream.Context().Done():
+				return
+			}
+		}
+	}()
+
+	verifier := &gossipVerifier{headFetcher: bs.HeadFetcher}
+	for {
+		select {
+		case att := <-gossipAtts:
+			headState, err := verifier.headState(stream.Context())
+			if err != nil {
+				return status.Errorf(codes.Internal, "Could not get head state: %v", err)
+			}
+			// Committees are only computed for the epochs around the head, the shuffling of other
+			// epochs is not computed for unvalidated gossip.
+			epoch := helpers.SlotToEpoch(att.Data.Slot)
+			headEpoch := helpers.CurrentEpoch(headState)
+			if epoch+1 < headEpoch || epoch > headEpoch+1 {
+				continue
+			}
+			committee, err := helpers.BeaconCommitteeFromState(headState, att.Data.Slot, att.Data.CommitteeIndex)
+			if err != nil {
+				continue
+			}
+			if att.AggregationBits.Len() != uint64(len(committee)) {
+				continue
+			}
+			idxAtt := attestationutil.ConvertToIndexed(stream.Context(), att, committee)
+			if err := blocks.VerifyIndexedAttestation(stream.Context(), headState, idxAtt); err != nil {
+				continue
+			}
+			if err := stream.Send(idxAtt); err != nil {
+				return status.Errorf(codes.Unavailable, "Could not send over stream: %v", err)
+			}
+		case <-attSub.Err():
+			return status.Error(codes.Aborted, "Subscriber closed, exiting goroutine")
+		case <-bs.Ctx.Done():
+			return status.Error(codes.Canceled, "Context canceled")
+		case <-stream.Context().Done():
+			return status.Error(codes.Canceled, "Context canceled")
+		}
+	}
+}
+
+// gossipVerifier keeps the head state used to verify the gossip objects of a stream, which
+// is only retrieved again once the head changes.
+type gossipVerifier struct {
+	headFetcher blockchain.HeadFetcher
+	root        []byte
+	state       *stateTrie.BeaconState
+}
+
+func (v *gossipVerifier) headState(ctx context.Context) (*stateTrie.BeaconState, error) {
+	root, err := v.headFetcher.HeadRoot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if v.state != nil && bytes.Equal(root, v.root) {
+		return v.state, nil
+	}
+	st, err := v.headFetcher.HeadState(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if st == nil {
+		return nil, status.Error(codes.Internal, "Nil head state")
+	}
+	v.root, v.state = root, st
+	return st, nil
+}
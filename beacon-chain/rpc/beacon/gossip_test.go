@@ -0,0 +1,188 @@
+package beacon
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	ptypes "github.com/gogo/protobuf/types"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-bitfield"
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
+	gossipfeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/gossip"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"google.golang.org/grpc"
+)
+
+type mockGossipBlocksStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent chan *ethpb.SignedBeaconBlock
+}
+
+func (m *mockGossipBlocksStream) Send(b *ethpb.SignedBeaconBlock) error {
+	m.sent <- b
+	return nil
+}
+
+func (m *mockGossipBlocksStream) Context() context.Context {
+	return m.ctx
+}
+
+type mockGossipAttestationsStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent chan *ethpb.IndexedAttestation
+}
+
+func (m *mockGossipAttestationsStream) Send(a *ethpb.IndexedAttestation) error {
+	m.sent <- a
+	return nil
+}
+
+func (m *mockGossipAttestationsStream) Context() context.Context {
+	return m.ctx
+}
+
+func TestServer_StreamGossipBlocks_StreamsDoubleProposals(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	headState, privKeys := testutil.DeterministicGenesisState(t, 64)
+	chainService := &mock.ChainService{State: headState}
+	server := &Server{
+		Ctx:            ctx,
+		HeadFetcher:    chainService,
+		GossipNotifier: chainService.GossipNotifier(),
+	}
+	domain, err := helpers.Domain(headState.Fork(), 0, params.BeaconConfig().DomainBeaconProposer, headState.GenesisValidatorRoot())
+	if err != nil {
+		t.Fatal(err)
+	}
+	signedBlock := func(graffiti string, signerIdx int) *ethpb.SignedBeaconBlock {
+		b := &ethpb.BeaconBlock{
+			Slot:          1,
+			ProposerIndex: 0,
+			ParentRoot:    make([]byte, 32),
+			StateRoot:     make([]byte, 32),
+			Body: &ethpb.BeaconBlockBody{
+				RandaoReveal: make([]byte, 96),
+				Eth1Data: &ethpb.Eth1Data{
+					DepositRoot: make([]byte, 32),
+					BlockHash:   make([]byte, 32),
+				},
+				Graffiti: bytesutil.PadTo([]byte(graffiti), 32),
+			},
+		}
+		root, err := helpers.ComputeSigningRoot(b, domain)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &ethpb.SignedBeaconBlock{Block: b, Signature: privKeys[signerIdx].Sign(root[:]).Marshal()}
+	}
+	forged := signedBlock("forged", 1)
+	first := signedBlock("first", 0)
+	second := signedBlock("second", 0)
+
+	stream := &mockGossipBlocksStream{ctx: ctx, sent: make(chan *ethpb.SignedBeaconBlock, 3)}
+	go func(tt *testing.T) {
+		if err := server.StreamGossipBlocks(&ptypes.Empty{}, stream); err == nil {
+			tt.Error("Expected error on context cancellation")
+		}
+	}(t)
+
+	// Send in a loop to ensure it is delivered (busy wait for the service to subscribe to the gossip feed).
+	for sent := 0; sent == 0; {
+		sent = server.GossipNotifier.GossipFeed().Send(&feed.Event{
+			Type: gossipfeed.BlockReceived,
+			Data: &gossipfeed.BlockReceivedData{SignedBlock: forged},
+		})
+	}
+	for _, blk := range []*ethpb.SignedBeaconBlock{first, second} {
+		server.GossipNotifier.GossipFeed().Send(&feed.Event{
+			Type: gossipfeed.BlockReceived,
+			Data: &gossipfeed.BlockReceivedData{SignedBlock: blk},
+		})
+	}
+
+	// The block with an invalid signature is dropped, both blocks of the proposer for the slot are streamed.
+	if b := <-stream.sent; !proto.Equal(b, first) {
+		t.Errorf("Expected the first block to be streamed, received %v", b)
+	}
+	if b := <-stream.sent; !proto.Equal(b, second) {
+		t.Errorf("Expected the second block to be streamed, received %v", b)
+	}
+}
+
+func TestServer_StreamGossipIndexedAttestations_StreamsUnknownBlockVotes(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	headState, privKeys := testutil.DeterministicGenesisState(t, 64)
+	chainService := &mock.ChainService{State: headState}
+	server := &Server{
+		Ctx:            ctx,
+		HeadFetcher:    chainService,
+		GossipNotifier: chainService.GossipNotifier(),
+	}
+	committee, err := helpers.BeaconCommitteeFromState(headState, 1, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	domain, err := helpers.Domain(headState.Fork(), 0, params.BeaconConfig().DomainBeaconAttester, headState.GenesisValidatorRoot())
+	if err != nil {
+		t.Fatal(err)
+	}
+	signedAtt := func(signerIdx uint64) *ethpb.Attestation {
+		// The attestation votes for a block the beacon node does not know of.
+		data := &ethpb.AttestationData{
+			Slot:            1,
+			CommitteeIndex:  0,
+			BeaconBlockRoot: bytesutil.PadTo([]byte("unknown block"), 32),
+			Source:          &ethpb.Checkpoint{Root: make([]byte, 32)},
+			Target:          &ethpb.Checkpoint{Root: bytesutil.PadTo([]byte("unknown block"), 32)},
+		}
+		root, err := helpers.ComputeSigningRoot(data, domain)
+		if err != nil {
+			t.Fatal(err)
+		}
+		bits := bitfield.NewBitlist(uint64(len(committee)))
+		bits.SetBitAt(0, true)
+		return &ethpb.Attestation{
+			AggregationBits: bits,
+			Data:            data,
+			Signature:       privKeys[signerIdx].Sign(root[:]).Marshal(),
+		}
+	}
+	forged := signedAtt(committee[0] + 1)
+	valid := signedAtt(committee[0])
+
+	stream := &mockGossipAttestationsStream{ctx: ctx, sent: make(chan *ethpb.IndexedAttestation, 2)}
+	go func(tt *testing.T) {
+		if err := server.StreamGossipIndexedAttestations(&ptypes.Empty{}, stream); err == nil {
+			tt.Error("Expected error on context cancellation")
+		}
+	}(t)
+
+	// Send in a loop to ensure it is delivered (busy wait for the service to subscribe to the gossip feed).
+	for sent := 0; sent == 0; {
+		sent = server.GossipNotifier.GossipFeed().Send(&feed.Event{
+			Type: gossipfeed.AttReceived,
+			Data: &gossipfeed.AttReceivedData{Attestation: forged},
+		})
+	}
+	server.GossipNotifier.GossipFeed().Send(&feed.Event{
+		Type: gossipfeed.AttReceived,
+		Data: &gossipfeed.AttReceivedData{Attestation: valid},
+	})
+
+	idxAtt := <-stream.sent
+	if len(idxAtt.AttestingIndices) != 1 || idxAtt.AttestingIndices[0] != committee[0] {
+		t.Errorf("Expected attesting indices [%d], received %v", committee[0], idxAtt.AttestingIndices)
+	}
+	if !proto.Equal(idxAtt.Data, valid.Data) {
+		t.Error("Expected the attestation with a valid signature to be streamed")
+	}
+}
@@ -0,0 +1,70 @@
+package debug
+
+import (
+	"context"
+
+	ptypes "github.com/gogo/protobuf/types"
+	"github.com/prysmaticlabs/prysm/beacon-chain/forkchoice/protoarray"
+	pbrpc "github.com/prysmaticlabs/prysm/proto/beacon/rpc/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GetForkChoice returns every block node of the fork choice store along with the
+// justified balances used to compute their weights.
+func (ds *Server) GetForkChoice(ctx context.Context, _ *ptypes.Empty) (*pbrpc.ForkChoiceResponse, error) {
+	store := ds.ForkChoiceFetcher.ForkChoiceStore()
+	if store == nil {
+		return nil, status.Error(codes.Unavailable, "Fork choice store is not initialized")
+	}
+
+	nodes := store.Nodes()
+	// Node indices are resolved to block roots so the response can be compared across clients.
+	rootAt := func(index uint64) []byte {
+		if index >= uint64(len(nodes)) {
+			return nil
+		}
+		root := nodes[index].Root()
+		return root[:]
+	}
+	res := make([]*pbrpc.ForkChoiceNode, len(nodes))
+	for i, n := range nodes {
+		root := n.Root()
+		res[i] = &pbrpc.ForkChoiceNode{
+			Root:           root[:],
+			ParentRoot:     rootAt(n.Parent),
+			Slot:           n.Slot,
+			JustifiedEpoch: n.JustifiedEpoch(),
+			FinalizedEpoch: n.FinalizedEpoch(),
+			Weight:         n.Weight,
+			BestChild:      rootAt(n.BestChild()),
+			BestDescendant: rootAt(n.BestDescendent),
+		}
+	}
+	return &pbrpc.ForkChoiceResponse{
+		JustifiedEpoch:    store.JustifiedEpoch(),
+		FinalizedEpoch:    store.FinalizedEpoch(),
+		Nodes:             res,
+		JustifiedBalances: store.Balances(),
+	}, nil
+}
+
+// GetForkChoiceVote returns the latest vote of a validator as tracked by fork choice.
+func (ds *Server) GetForkChoiceVote(ctx context.Context, req *pbrpc.ForkChoiceVoteRequest) (*pbrpc.ForkChoiceVote, error) {
+	store := ds.ForkChoiceFetcher.ForkChoiceStore()
+	if store == nil {
+		return nil, status.Error(codes.Unavailable, "Fork choice store is not initialized")
+	}
+	v, ok := store.Vote(req.ValidatorIndex)
+	if !ok || v == (protoarray.Vote{}) {
+		return nil, status.Errorf(codes.NotFound, "No vote found for validator index %d", req.ValidatorIndex)
+	}
+	currentRoot := v.CurrentRoot()
+	nextRoot := v.NextRoot()
+	return &pbrpc.ForkChoiceVote{
+		ValidatorIndex: req.ValidatorIndex,
+		CurrentRoot:    currentRoot[:],
+		NextRoot:       nextRoot[:],
+		NextEpoch:      v.NextEpoch(),
+	}, nil
+}
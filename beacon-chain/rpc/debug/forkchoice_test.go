@@ -0,0 +1,80 @@
+package debug
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	ptypes "github.com/gogo/protobuf/types"
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/forkchoice/protoarray"
+	pbrpc "github.com/prysmaticlabs/prysm/proto/beacon/rpc/v1"
+)
+
+func TestServer_GetForkChoice(t *testing.T) {
+	ctx := context.Background()
+	store := protoarray.New(0, 0, [32]byte{'a'})
+	if err := store.ProcessBlock(ctx, 0, [32]byte{'a'}, [32]byte{}, 0, 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.ProcessBlock(ctx, 1, [32]byte{'b'}, [32]byte{'a'}, 0, 0); err != nil {
+		t.Fatal(err)
+	}
+	store.ProcessAttestation(ctx, []uint64{0}, [32]byte{'b'}, 0)
+	if _, err := store.Head(ctx, 0, [32]byte{'a'}, []uint64{32}, 0); err != nil {
+		t.Fatal(err)
+	}
+	ds := &Server{ForkChoiceFetcher: &mock.ChainService{ForkChoice: store}}
+
+	res, err := ds.GetForkChoice(ctx, &ptypes.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Nodes) != 2 {
+		t.Fatalf("Expected 2 nodes, got %d", len(res.Nodes))
+	}
+	genesis, child := res.Nodes[0], res.Nodes[1]
+	if genesis.ParentRoot != nil {
+		t.Errorf("Expected no parent root for first node, got %#x", genesis.ParentRoot)
+	}
+	if !bytes.Equal(child.ParentRoot, genesis.Root) {
+		t.Errorf("Expected parent root %#x, got %#x", genesis.Root, child.ParentRoot)
+	}
+	if !bytes.Equal(genesis.BestChild, child.Root) || !bytes.Equal(genesis.BestDescendant, child.Root) {
+		t.Errorf("Expected best child and descendant %#x, got %#x and %#x", child.Root, genesis.BestChild, genesis.BestDescendant)
+	}
+	if child.Weight != 32 || child.Slot != 1 {
+		t.Errorf("Unexpected child node %v", child)
+	}
+	if len(res.JustifiedBalances) != 1 || res.JustifiedBalances[0] != 32 {
+		t.Errorf("Unexpected justified balances %v", res.JustifiedBalances)
+	}
+}
+
+func TestServer_GetForkChoiceVote(t *testing.T) {
+	ctx := context.Background()
+	store := protoarray.New(0, 0, [32]byte{'a'})
+	store.ProcessAttestation(ctx, []uint64{1}, [32]byte{'b'}, 3)
+	ds := &Server{ForkChoiceFetcher: &mock.ChainService{ForkChoice: store}}
+
+	res, err := ds.GetForkChoiceVote(ctx, &pbrpc.ForkChoiceVoteRequest{ValidatorIndex: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.NextEpoch != 3 || !bytes.Equal(res.NextRoot, []byte{'b', 31: 0}) {
+		t.Errorf("Unexpected vote %v", res)
+	}
+	if _, err := ds.GetForkChoiceVote(ctx, &pbrpc.ForkChoiceVoteRequest{ValidatorIndex: 0}); err == nil {
+		t.Error("Expected error for validator without vote")
+	}
+	if _, err := ds.GetForkChoiceVote(ctx, &pbrpc.ForkChoiceVoteRequest{ValidatorIndex: 5}); err == nil {
+		t.Error("Expected error for unknown validator")
+	}
+}
+
+func TestServer_GetForkChoice_Uninitialized(t *testing.T) {
+	ds := &Server{ForkChoiceFetcher: &mock.ChainService{}}
+	if _, err := ds.GetForkChoice(context.Background(), &ptypes.Empty{}); err == nil {
+		t.Error("Expected error without fork choice store")
+	}
+}
@@ -0,0 +1,103 @@
+package debug
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	ptypes "github.com/gogo/protobuf/types"
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	pbrpc "github.com/prysmaticlabs/prysm/proto/beacon/rpc/v1"
+)
+
+// ListPeers returns the gossip and request/response state of every connected peer, along
+// with aggregate statistics for each gossip topic.
+func (ds *Server) ListPeers(ctx context.Context, _ *ptypes.Empty) (*pbrpc.DebugPeerResponses, error) {
+	ps := ds.PubSubProvider.PubSub()
+	topics := ps.GetTopics()
+	sort.Strings(topics)
+
+	peerTopics := make(map[peer.ID][]string)
+	peerMeshTopics := make(map[peer.ID][]string)
+	topicStats := make(map[string]*pbrpc.TopicStats, len(topics))
+	for _, topic := range topics {
+		subscribed := ps.ListPeers(topic)
+		for _, pid := range subscribed {
+			peerTopics[pid] = append(peerTopics[pid], topic)
+		}
+		mesh := ds.GossipInspector.MeshPeers(topic)
+		for _, pid := range mesh {
+			peerMeshTopics[pid] = append(peerMeshTopics[pid], topic)
+		}
+		topicStats[topic] = &pbrpc.TopicStats{
+			Topic:         topic,
+			PeerCount:     uint64(len(subscribed)),
+			MeshPeerCount: uint64(len(mesh)),
+		}
+	}
+	for topic, stats := range ds.GossipInspector.TopicStats() {
+		if _, ok := topicStats[topic]; !ok {
+			topicStats[topic] = &pbrpc.TopicStats{Topic: topic}
+		}
+		topicStats[topic].MessagesReceived = stats.MessagesReceived
+		topicStats[topic].MessagesPerSecond = stats.MessagesPerSecond
+	}
+
+	peerStatus := ds.PeersFetcher.Peers()
+	connected := peerStatus.Connected()
+	sort.Slice(connected, func(i, j int) bool {
+		return connected[i] < connected[j]
+	})
+	responses := make([]*pbrpc.DebugPeerResponse, 0, len(connected))
+	for _, pid := range connected {
+		res := &pbrpc.DebugPeerResponse{
+			PeerId:     pid.String(),
+			Topics:     peerTopics[pid],
+			MeshTopics: peerMeshTopics[pid],
+		}
+		if addr, err := peerStatus.Address(pid); err == nil && addr != nil {
+			res.Address = fmt.Sprintf("%s/p2p/%s", addr.String(), pid.Pretty())
+		}
+		if direction, err := peerStatus.Direction(pid); err == nil {
+			switch direction {
+			case network.DirInbound:
+				res.Direction = pbrpc.DebugPeerResponse_INBOUND
+			case network.DirOutbound:
+				res.Direction = pbrpc.DebugPeerResponse_OUTBOUND
+			}
+		}
+		if chainState, err := peerStatus.ChainState(pid); err == nil && chainState != nil {
+			res.ChainState = &pbrpc.PeerChainState{
+				ForkDigest:     chainState.ForkDigest,
+				FinalizedRoot:  chainState.FinalizedRoot,
+				FinalizedEpoch: chainState.FinalizedEpoch,
+				HeadRoot:       chainState.HeadRoot,
+				HeadSlot:       chainState.HeadSlot,
+			}
+			if lastUpdated, err := peerStatus.ChainStateLastUpdated(pid); err == nil {
+				res.ChainState.LastUpdated = uint64(lastUpdated.Unix())
+			}
+		}
+		if metadata, err := peerStatus.Metadata(pid); err == nil && metadata != nil {
+			res.MetadataSeqNumber = metadata.SeqNumber
+			res.Attnets = metadata.Attnets
+		}
+		if badResponses, err := peerStatus.BadResponses(pid); err == nil {
+			res.BadResponses = uint64(badResponses)
+		}
+		responses = append(responses, res)
+	}
+
+	stats := make([]*pbrpc.TopicStats, 0, len(topicStats))
+	for _, s := range topicStats {
+		stats = append(stats, s)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].Topic < stats[j].Topic
+	})
+	return &pbrpc.DebugPeerResponses{
+		Responses:  responses,
+		TopicStats: stats,
+	}, nil
+}
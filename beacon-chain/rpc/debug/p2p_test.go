@@ -0,0 +1,94 @@
+package debug
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	ptypes "github.com/gogo/protobuf/types"
+	"github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/peers"
+	mockP2p "github.com/prysmaticlabs/prysm/beacon-chain/p2p/testing"
+	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	pbrpc "github.com/prysmaticlabs/prysm/proto/beacon/rpc/v1"
+)
+
+type mockGossipInspector struct {
+	mesh  map[string][]peer.ID
+	stats map[string]*p2p.TopicStats
+}
+
+func (m *mockGossipInspector) MeshPeers(topic string) []peer.ID {
+	return m.mesh[topic]
+}
+
+func (m *mockGossipInspector) TopicStats() map[string]*p2p.TopicStats {
+	return m.stats
+}
+
+func TestServer_ListPeers(t *testing.T) {
+	p1 := mockP2p.NewTestP2P(t)
+	topic := "/eth2/00000000/beacon_block/ssz"
+	if _, err := p1.PubSub().Subscribe(topic); err != nil {
+		t.Fatal(err)
+	}
+
+	pid := peer.ID("peer1")
+	addr, err := ma.NewMultiaddr("/ip4/10.0.0.1/tcp/13000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	p1.Peers().Add(nil, pid, addr, network.DirOutbound)
+	p1.Peers().SetConnectionState(pid, peers.PeerConnected)
+	p1.Peers().SetChainState(pid, &pb.Status{HeadSlot: 64, FinalizedEpoch: 1, HeadRoot: []byte{'a'}})
+	p1.Peers().SetMetadata(pid, &pb.MetaData{SeqNumber: 3, Attnets: []byte{1, 0, 0, 0, 0, 0, 0, 0}})
+	p1.Peers().IncrementBadResponses(pid)
+
+	ds := &Server{
+		PeersFetcher:   p1,
+		PubSubProvider: p1,
+		GossipInspector: &mockGossipInspector{
+			mesh: map[string][]peer.ID{topic: {pid}},
+			stats: map[string]*p2p.TopicStats{
+				topic: {MessagesReceived: 10, MessagesPerSecond: 0.5},
+			},
+		},
+	}
+	res, err := ds.ListPeers(context.Background(), &ptypes.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Responses) != 1 {
+		t.Fatalf("Expected 1 peer, received %d", len(res.Responses))
+	}
+	peerRes := res.Responses[0]
+	if peerRes.PeerId != pid.String() {
+		t.Errorf("Unexpected peer id %s", peerRes.PeerId)
+	}
+	if peerRes.Direction != pbrpc.DebugPeerResponse_OUTBOUND {
+		t.Errorf("Unexpected direction %v", peerRes.Direction)
+	}
+	if peerRes.ChainState == nil || peerRes.ChainState.HeadSlot != 64 || !bytes.Equal(peerRes.ChainState.HeadRoot, []byte{'a'}) {
+		t.Errorf("Unexpected chain state %v", peerRes.ChainState)
+	}
+	if peerRes.MetadataSeqNumber != 3 || peerRes.Attnets[0] != 1 {
+		t.Errorf("Unexpected metadata seq %d attnets %v", peerRes.MetadataSeqNumber, peerRes.Attnets)
+	}
+	if peerRes.BadResponses != 1 {
+		t.Errorf("Expected 1 bad response, received %d", peerRes.BadResponses)
+	}
+	if len(peerRes.MeshTopics) != 1 || peerRes.MeshTopics[0] != topic {
+		t.Errorf("Unexpected mesh topics %v", peerRes.MeshTopics)
+	}
+
+	if len(res.TopicStats) != 1 {
+		t.Fatalf("Expected stats for 1 topic, received %d", len(res.TopicStats))
+	}
+	stats := res.TopicStats[0]
+	if stats.Topic != topic || stats.MeshPeerCount != 1 || stats.MessagesReceived != 10 || stats.MessagesPerSecond != 0.5 {
+		t.Errorf("Unexpected topic stats %v", stats)
+	}
+}
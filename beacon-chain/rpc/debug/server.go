@@ -0,0 +1,18 @@
+// Package debug defines a gRPC server implementation of prysm specific debug
+// endpoints, used to introspect the internal state of a beacon node.
+package debug
+
+import (
+	"github.com/prysmaticlabs/prysm/beacon-chain/blockchain"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
+)
+
+// Server defines a server implementation of the gRPC Debug service,
+// providing RPC endpoints for inspecting the internal state of the
+// beacon node such as its peers, gossip topics and fork choice store.
+type Server struct {
+	PeersFetcher      p2p.PeersProvider
+	PubSubProvider    p2p.PubSubProvider
+	GossipInspector   p2p.GossipInspector
+	ForkChoiceFetcher blockchain.ForkChoiceFetcher
+}
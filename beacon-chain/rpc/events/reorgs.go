@@ -0,0 +1,67 @@
+package events
+
+import (
+	ptypes "github.com/gogo/protobuf/types"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
+	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
+	pbrpc "github.com/prysmaticlabs/prysm/proto/beacon/rpc/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// StreamReorgs to clients every time the head of the chain switches to a block which
+// does not descend from the previous head.
+func (es *Server) StreamReorgs(_ *ptypes.Empty, stream pbrpc.Events_StreamReorgsServer) error {
+	stateChannel := make(chan *feed.Event, 1)
+	stateSub := es.StateNotifier.StateFeed().Subscribe(stateChannel)
+	defer stateSub.Unsubscribe()
+	reorgs := make(chan *pbrpc.ReorgEvent, eventStreamBufferSize)
+	go func() {
+		for {
+			select {
+			case event := <-stateChannel:
+				if event.Type != statefeed.Reorg {
+					continue
+				}
+				data, ok := event.Data.(*statefeed.ReorgData)
+				if !ok {
+					log.Error("Received incorrect data type over reorg feed")
+					continue
+				}
+				select {
+				case reorgs <- reorgEvent(data):
+				default:
+					log.Warn("Reorg stream buffer full, dropping reorg")
+				}
+			case <-stream.Context().Done():
+				return
+			}
+		}
+	}()
+	for {
+		select {
+		case reorg := <-reorgs:
+			if err := stream.Send(reorg); err != nil {
+				return status.Errorf(codes.Unavailable, "Could not send over stream: %v", err)
+			}
+		case <-stateSub.Err():
+			return status.Error(codes.Aborted, "Subscriber closed, exiting goroutine")
+		case <-es.Ctx.Done():
+			return status.Error(codes.Canceled, "Context canceled")
+		case <-stream.Context().Done():
+			return status.Error(codes.Canceled, "Context canceled")
+		}
+	}
+}
+
+func reorgEvent(data *statefeed.ReorgData) *pbrpc.ReorgEvent {
+	return &pbrpc.ReorgEvent{
+		OldHeadRoot:        data.OldHeadRoot[:],
+		OldHeadSlot:        data.OldHeadSlot,
+		NewHeadRoot:        data.NewHeadRoot[:],
+		NewHeadSlot:        data.NewHeadSlot,
+		CommonAncestorRoot: data.CommonAncestorRoot[:],
+		CommonAncestorSlot: data.CommonAncestorSlot,
+		Depth:              data.Depth,
+	}
+}
@@ -0,0 +1,72 @@
+package events
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	ptypes "github.com/gogo/protobuf/types"
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
+	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
+	pbrpc "github.com/prysmaticlabs/prysm/proto/beacon/rpc/v1"
+	"google.golang.org/grpc"
+)
+
+type mockReorgsStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent chan *pbrpc.ReorgEvent
+}
+
+func (m *mockReorgsStream) Send(e *pbrpc.ReorgEvent) error {
+	m.sent <- e
+	return nil
+}
+
+func (m *mockReorgsStream) Context() context.Context {
+	return m.ctx
+}
+
+func TestServer_StreamReorgs(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	chainService := &mock.ChainService{}
+	server := &Server{
+		Ctx:           ctx,
+		StateNotifier: chainService.StateNotifier(),
+	}
+	stream := &mockReorgsStream{ctx: ctx, sent: make(chan *pbrpc.ReorgEvent, 1)}
+	go func(tt *testing.T) {
+		if err := server.StreamReorgs(&ptypes.Empty{}, stream); err == nil {
+			tt.Error("Expected error on context cancellation")
+		}
+	}(t)
+
+	data := &statefeed.ReorgData{
+		OldHeadRoot:        [32]byte{'a'},
+		OldHeadSlot:        5,
+		NewHeadRoot:        [32]byte{'b'},
+		NewHeadSlot:        6,
+		CommonAncestorRoot: [32]byte{'c'},
+		CommonAncestorSlot: 3,
+		Depth:              2,
+	}
+	// Send in a loop to ensure it is delivered (busy wait for the service to subscribe to the state feed).
+	for sent := 0; sent == 0; {
+		sent = server.StateNotifier.StateFeed().Send(&feed.Event{Type: statefeed.Reorg, Data: data})
+	}
+	got := <-stream.sent
+	want := &pbrpc.ReorgEvent{
+		OldHeadRoot:        data.OldHeadRoot[:],
+		OldHeadSlot:        5,
+		NewHeadRoot:        data.NewHeadRoot[:],
+		NewHeadSlot:        6,
+		CommonAncestorRoot: data.CommonAncestorRoot[:],
+		CommonAncestorSlot: 3,
+		Depth:              2,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Wanted %v, got %v", want, got)
+	}
+}
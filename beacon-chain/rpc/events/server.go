@@ -0,0 +1,28 @@
+// Package events defines a gRPC server implementation of the event stream
+// endpoints, pushing changes of the beacon node's view of the chain to
+// subscribers as they happen.
+package events
+
+import (
+	"context"
+
+	blockfeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/block"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed/operation"
+	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
+	"github.com/sirupsen/logrus"
+)
+
+var log logrus.FieldLogger
+
+func init() {
+	log = logrus.WithField("prefix", "rpc/events")
+}
+
+// Server defines a server implementation of the gRPC Events service,
+// providing RPC endpoints to subscribe to chain events.
+type Server struct {
+	Ctx               context.Context
+	StateNotifier     statefeed.Notifier
+	BlockNotifier     blockfeed.Notifier
+	OperationNotifier operation.Notifier
+}
@@ -0,0 +1,203 @@
+package events
+
+import (
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
+	blockfeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/block"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed/operation"
+	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/beacon-chain/state/stateutil"
+	pbrpc "github.com/prysmaticlabs/prysm/proto/beacon/rpc/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// eventStreamBufferSize is the number of events buffered for each event stream. Events
+// received while the buffer of a stream is full are dropped for that stream, so that a slow
+// client does not hold up the services sending over the state, block and operation feeds.
+const eventStreamBufferSize = 256
+
+// StreamEvents to clients for every requested topic as the events happen. All topics
+// are streamed if none is requested.
+func (es *Server) StreamEvents(req *pbrpc.StreamEventsRequest, stream pbrpc.Events_StreamEventsServer) error {
+	topics := make(map[pbrpc.EventTopic]bool)
+	for _, topic := range req.Topics {
+		if _, ok := pbrpc.EventTopic_name[int32(topic)]; !ok || topic == pbrpc.EventTopic_UNKNOWN_TOPIC {
+			return status.Errorf(codes.InvalidArgument, "Unknown event topic %d", topic)
+		}
+		topics[topic] = true
+	}
+	if len(topics) == 0 {
+		for value := range pbrpc.EventTopic_name {
+			topics[pbrpc.EventTopic(value)] = true
+		}
+	}
+
+	// Only the feeds carrying requested topics are subscribed to, the channels
+	// of the other feeds stay nil and never deliver.
+	var stateChannel, blockChannel, opChannel chan *feed.Event
+	var stateErr, blockErr, opErr <-chan error
+	if topics[pbrpc.EventTopic_HEAD] || topics[pbrpc.EventTopic_FINALIZED_CHECKPOINT] || topics[pbrpc.EventTopic_CHAIN_REORG] {
+		stateChannel = make(chan *feed.Event, 1)
+		stateSub := es.StateNotifier.StateFeed().Subscribe(stateChannel)
+		defer stateSub.Unsubscribe()
+		stateErr = stateSub.Err()
+	}
+	if topics[pbrpc.EventTopic_BLOCK] {
+		blockChannel = make(chan *feed.Event, 1)
+		blockSub := es.BlockNotifier.BlockFeed().Subscribe(blockChannel)
+		defer blockSub.Unsubscribe()
+		blockErr = blockSub.Err()
+	}
+	if topics[pbrpc.EventTopic_ATTESTATION] || topics[pbrpc.EventTopic_VOLUNTARY_EXIT] {
+		opChannel = make(chan *feed.Event, 1)
+		opSub := es.OperationNotifier.OperationFeed().Subscribe(opChannel)
+		defer opSub.Unsubscribe()
+		opErr = opSub.Err()
+	}
+
+	events := make(chan *pbrpc.Event, eventStreamBufferSize)
+	go func() {
+		for {
+			var e *pbrpc.Event
+			select {
+			case event := <-stateChannel:
+				e = stateEvent(event)
+			case event := <-blockChannel:
+				e = blockEvent(event)
+			case event := <-opChannel:
+				e = operationEvent(event)
+			case <-stream.Context().Done():
+				return
+			}
+			if e == nil || !topics[e.Topic] {
+				continue
+			}
+			select {
+			case events <- e:
+			default:
+				log.WithField("topic", e.Topic).Warn("Event stream buffer full, dropping event")
+			}
+		}
+	}()
+
+	for {
+		select {
+		case e := <-events:
+			if err := stream.Send(e); err != nil {
+				return status.Errorf(codes.Unavailable, "Could not send over stream: %v", err)
+			}
+		case <-stateErr:
+			return status.Error(codes.Aborted, "Subscriber closed, exiting goroutine")
+		case <-blockErr:
+			return status.Error(codes.Aborted, "Subscriber closed, exiting goroutine")
+		case <-opErr:
+			return status.Error(codes.Aborted, "Subscriber closed, exiting goroutine")
+		case <-es.Ctx.Done():
+			return status.Error(codes.Canceled, "Context canceled")
+		case <-stream.Context().Done():
+			return status.Error(codes.Canceled, "Context canceled")
+		}
+	}
+}
+
+// stateEvent converts a state feed event, it returns nil for events which are not streamed.
+func stateEvent(event *feed.Event) *pbrpc.Event {
+	switch data := event.Data.(type) {
+	case *statefeed.NewHeadData:
+		return &pbrpc.Event{
+			Topic: pbrpc.EventTopic_HEAD,
+			Data: &pbrpc.Event_Head{Head: &pbrpc.HeadEvent{
+				Slot:      data.Slot,
+				BlockRoot: data.BlockRoot[:],
+			}},
+		}
+	case *statefeed.FinalizedCheckpointData:
+		return &pbrpc.Event{
+			Topic: pbrpc.EventTopic_FINALIZED_CHECKPOINT,
+			Data: &pbrpc.Event_FinalizedCheckpoint{FinalizedCheckpoint: &pbrpc.FinalizedCheckpointEvent{
+				Epoch:     data.Epoch,
+				BlockRoot: data.BlockRoot[:],
+			}},
+		}
+	case *statefeed.ReorgData:
+		return &pbrpc.Event{
+			Topic: pbrpc.EventTopic_CHAIN_REORG,
+			Data:  &pbrpc.Event_ChainReorg{ChainReorg: reorgEvent(data)},
+		}
+	}
+	return nil
+}
+
+// blockEvent converts a block feed event, it returns nil for events which are not streamed.
+func blockEvent(event *feed.Event) *pbrpc.Event {
+	data, ok := event.Data.(*blockfeed.ReceivedBlockData)
+	if event.Type != blockfeed.ReceivedBlock || !ok || data.SignedBlock == nil || data.SignedBlock.Block == nil {
+		return nil
+	}
+	b := data.SignedBlock.Block
+	root, err := stateutil.BlockRoot(b)
+	if err != nil {
+		log.WithError(err).Error("Could not compute block root")
+		return nil
+	}
+	return &pbrpc.Event{
+		Topic: pbrpc.EventTopic_BLOCK,
+		Data: &pbrpc.Event_Block{Block: &pbrpc.BlockEvent{
+			Slot:          b.Slot,
+			BlockRoot:     root[:],
+			ParentRoot:    b.ParentRoot,
+			ProposerIndex: b.ProposerIndex,
+		}},
+	}
+}
+
+// operationEvent converts an operation feed event, it returns nil for events which are not streamed.
+func operationEvent(event *feed.Event) *pbrpc.Event {
+	switch data := event.Data.(type) {
+	case *operation.UnAggregatedAttReceivedData:
+		if data.Attestation == nil || data.Attestation.Data == nil {
+			return nil
+		}
+		return attestationEvent(data.Attestation.AggregationBits, data.Attestation.Data, false)
+	case *operation.AggregatedAttReceivedData:
+		if data.Attestation == nil || data.Attestation.Aggregate == nil || data.Attestation.Aggregate.Data == nil {
+			return nil
+		}
+		return attestationEvent(data.Attestation.Aggregate.AggregationBits, data.Attestation.Aggregate.Data, true)
+	case *operation.ExitReceivedData:
+		if data.Exit == nil || data.Exit.Exit == nil {
+			return nil
+		}
+		return &pbrpc.Event{
+			Topic: pbrpc.EventTopic_VOLUNTARY_EXIT,
+			Data: &pbrpc.Event_VoluntaryExit{VoluntaryExit: &pbrpc.VoluntaryExitEvent{
+				Epoch:          data.Exit.Exit.Epoch,
+				ValidatorIndex: data.Exit.Exit.ValidatorIndex,
+			}},
+		}
+	}
+	return nil
+}
+
+func attestationEvent(bits bitfield.Bitlist, data *ethpb.AttestationData, aggregate bool) *pbrpc.Event {
+	e := &pbrpc.AttestationEvent{
+		Slot:            data.Slot,
+		CommitteeIndex:  data.CommitteeIndex,
+		BeaconBlockRoot: data.BeaconBlockRoot,
+		AggregationBits: bits,
+		Aggregate:       aggregate,
+	}
+	if data.Source != nil {
+		e.SourceEpoch = data.Source.Epoch
+	}
+	if data.Target != nil {
+		e.TargetEpoch = data.Target.Epoch
+		e.TargetRoot = data.Target.Root
+	}
+	return &pbrpc.Event{
+		Topic: pbrpc.EventTopic_ATTESTATION,
+		Data:  &pbrpc.Event_Attestation{Attestation: e},
+	}
+}
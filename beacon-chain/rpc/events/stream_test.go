@@ -0,0 +1,154 @@
+package events
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
+	blockfeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/block"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed/operation"
+	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
+	pbrpc "github.com/prysmaticlabs/prysm/proto/beacon/rpc/v1"
+	"google.golang.org/grpc"
+)
+
+type mockEventsStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent chan *pbrpc.Event
+}
+
+func (m *mockEventsStream) Send(e *pbrpc.Event) error {
+	m.sent <- e
+	return nil
+}
+
+func (m *mockEventsStream) Context() context.Context {
+	return m.ctx
+}
+
+func setupEventsServer(ctx context.Context) *Server {
+	chainService := &mock.ChainService{}
+	return &Server{
+		Ctx:               ctx,
+		StateNotifier:     chainService.StateNotifier(),
+		BlockNotifier:     chainService.BlockNotifier(),
+		OperationNotifier: chainService.OperationNotifier(),
+	}
+}
+
+func TestServer_StreamEvents_FiltersTopics(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	server := setupEventsServer(ctx)
+	stream := &mockEventsStream{ctx: ctx, sent: make(chan *pbrpc.Event, 1)}
+	req := &pbrpc.StreamEventsRequest{Topics: []pbrpc.EventTopic{pbrpc.EventTopic_HEAD}}
+	go func(tt *testing.T) {
+		if err := server.StreamEvents(req, stream); err == nil {
+			tt.Error("Expected error on context cancellation")
+		}
+	}(t)
+
+	// Send in a loop to ensure it is delivered (busy wait for the service to subscribe to the state feed).
+	for sent := 0; sent == 0; {
+		sent = server.StateNotifier.StateFeed().Send(&feed.Event{
+			Type: statefeed.FinalizedCheckpoint,
+			Data: &statefeed.FinalizedCheckpointData{Epoch: 1},
+		})
+	}
+	if sent := server.BlockNotifier.BlockFeed().Send(&feed.Event{Type: blockfeed.ReceivedBlock}); sent != 0 {
+		t.Error("Expected no subscription to the block feed")
+	}
+	server.StateNotifier.StateFeed().Send(&feed.Event{
+		Type: statefeed.NewHead,
+		Data: &statefeed.NewHeadData{Slot: 5, BlockRoot: [32]byte{'a'}},
+	})
+
+	e := <-stream.sent
+	if e.Topic != pbrpc.EventTopic_HEAD || e.GetHead().Slot != 5 {
+		t.Errorf("Expected head event for slot 5, got %v", e)
+	}
+}
+
+func TestServer_StreamEvents_AllTopics(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	server := setupEventsServer(ctx)
+	stream := &mockEventsStream{ctx: ctx, sent: make(chan *pbrpc.Event, 1)}
+	go func(tt *testing.T) {
+		if err := server.StreamEvents(&pbrpc.StreamEventsRequest{}, stream); err == nil {
+			tt.Error("Expected error on context cancellation")
+		}
+	}(t)
+
+	for sent := 0; sent == 0; {
+		sent = server.OperationNotifier.OperationFeed().Send(&feed.Event{
+			Type: operation.ExitReceived,
+			Data: &operation.ExitReceivedData{
+				Exit: &ethpb.SignedVoluntaryExit{Exit: &ethpb.VoluntaryExit{Epoch: 2, ValidatorIndex: 3}},
+			},
+		})
+	}
+	e := <-stream.sent
+	if e.Topic != pbrpc.EventTopic_VOLUNTARY_EXIT || e.GetVoluntaryExit().ValidatorIndex != 3 {
+		t.Errorf("Expected voluntary exit event, got %v", e)
+	}
+
+	for sent := 0; sent == 0; {
+		sent = server.BlockNotifier.BlockFeed().Send(&feed.Event{
+			Type: blockfeed.ReceivedBlock,
+			Data: &blockfeed.ReceivedBlockData{
+				SignedBlock: &ethpb.SignedBeaconBlock{Block: &ethpb.BeaconBlock{Slot: 7, ProposerIndex: 4}},
+			},
+		})
+	}
+	e = <-stream.sent
+	if e.Topic != pbrpc.EventTopic_BLOCK || e.GetBlock().Slot != 7 || e.GetBlock().ProposerIndex != 4 {
+		t.Errorf("Expected block event, got %v", e)
+	}
+}
+
+func TestServer_StreamEvents_SlowSubscriberDoesNotBlockFeed(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	server := setupEventsServer(ctx)
+	// The stream never takes any event off its unbuffered channel.
+	stream := &mockEventsStream{ctx: ctx, sent: make(chan *pbrpc.Event)}
+	req := &pbrpc.StreamEventsRequest{Topics: []pbrpc.EventTopic{pbrpc.EventTopic_HEAD}}
+	go func() {
+		_ = server.StreamEvents(req, stream)
+	}()
+	headEvent := &feed.Event{
+		Type: statefeed.NewHead,
+		Data: &statefeed.NewHeadData{Slot: 5, BlockRoot: [32]byte{'a'}},
+	}
+	for sent := 0; sent == 0; {
+		sent = server.StateNotifier.StateFeed().Send(headEvent)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < 2*eventStreamBufferSize; i++ {
+			server.StateNotifier.StateFeed().Send(headEvent)
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Sending events over the state feed was blocked by a slow stream")
+	}
+}
+
+func TestServer_StreamEvents_UnknownTopic(t *testing.T) {
+	ctx := context.Background()
+	server := setupEventsServer(ctx)
+	stream := &mockEventsStream{ctx: ctx, sent: make(chan *pbrpc.Event, 1)}
+	req := &pbrpc.StreamEventsRequest{Topics: []pbrpc.EventTopic{pbrpc.EventTopic_UNKNOWN_TOPIC}}
+	if err := server.StreamEvents(req, stream); err == nil {
+		t.Error("Expected error for unknown topic")
+	}
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/prysmaticlabs/prysm/shared/version"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
@@ -22,15 +23,36 @@ import (
 // providing RPC endpoints for verifying a beacon node's sync status, genesis and
 // version information, and services the node implements and runs.
 type Server struct {
-	SyncChecker        sync.Checker
-	Server             *grpc.Server
-	BeaconDB           db.ReadOnlyDatabase
-	PeersFetcher       p2p.PeersProvider
-	GenesisTimeFetcher blockchain.TimeFetcher
+	SyncChecker             sync.Checker
+	Server                  *grpc.Server
+	BeaconDB                db.ReadOnlyDatabase
+	PeersFetcher            p2p.PeersProvider
+	GenesisTimeFetcher      blockchain.TimeFetcher
+	WeakSubjectivityFetcher blockchain.WeakSubjectivityFetcher
 }
 
-// GetSyncStatus checks the current network sync status of the node.
+// Response headers of GetSyncStatus, carrying sync related information the sync status message
+// itself has no field for.
+const (
+	// SyncModeHeader carries the sync mode of the node.
+	SyncModeHeader = "x-sync-mode"
+	// WeakSubjectivityHeader carries the status of the weak subjectivity checkpoint check.
+	WeakSubjectivityHeader = "x-weak-subjectivity-status"
+)
+
+// GetSyncStatus checks the current network sync status of the node. The sync mode the node is in,
+// either synced, initial sync or head sync, is returned in the SyncModeHeader response header, and
+// the status of the weak subjectivity checkpoint check in the WeakSubjectivityHeader.
 func (ns *Server) GetSyncStatus(ctx context.Context, _ *ptypes.Empty) (*ethpb.SyncStatus, error) {
+	if grpc.ServerTransportStreamFromContext(ctx) != nil {
+		md := metadata.Pairs(SyncModeHeader, ns.SyncChecker.SyncMode())
+		if ns.WeakSubjectivityFetcher != nil {
+			md.Set(WeakSubjectivityHeader, ns.WeakSubjectivityFetcher.WeakSubjectivityStatus())
+		}
+		if err := grpc.SetHeader(ctx, md); err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not set sync status headers: %v", err)
+		}
+	}
 	return &ethpb.SyncStatus{
 		Syncing: ns.SyncChecker.Syncing(),
 	}, nil
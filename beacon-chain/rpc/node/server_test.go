@@ -9,12 +9,15 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	ptypes "github.com/gogo/protobuf/types"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/blockchain"
 	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
 	dbutil "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
 	mockP2p "github.com/prysmaticlabs/prysm/beacon-chain/p2p/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/sync"
 	mockSync "github.com/prysmaticlabs/prysm/beacon-chain/sync/initial-sync/testing"
 	"github.com/prysmaticlabs/prysm/shared/version"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/reflection"
 )
 
@@ -40,6 +43,41 @@ func TestNodeServer_GetSyncStatus(t *testing.T) {
 	}
 }
 
+type headerStream struct {
+	header metadata.MD
+}
+
+func (s *headerStream) Method() string { return "" }
+
+func (s *headerStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+func (s *headerStream) SendHeader(md metadata.MD) error { return nil }
+
+func (s *headerStream) SetTrailer(md metadata.MD) error { return nil }
+
+func TestNodeServer_GetSyncStatus_Headers(t *testing.T) {
+	ns := &Server{
+		SyncChecker:             &mockSync.Sync{IsSyncing: true, Mode: sync.SyncModeHead},
+		WeakSubjectivityFetcher: &mock.ChainService{WeakSubjectivity: blockchain.WeakSubjectivityVerified},
+	}
+	stream := &headerStream{}
+	ctx := grpc.NewContextWithServerTransportStream(context.Background(), stream)
+	if _, err := ns.GetSyncStatus(ctx, &ptypes.Empty{}); err != nil {
+		t.Fatal(err)
+	}
+	mode := stream.header.Get(SyncModeHeader)
+	if len(mode) != 1 || mode[0] != sync.SyncModeHead {
+		t.Errorf("Wanted sync mode header %v, received %v", []string{sync.SyncModeHead}, mode)
+	}
+	wsStatus := stream.header.Get(WeakSubjectivityHeader)
+	if len(wsStatus) != 1 || wsStatus[0] != blockchain.WeakSubjectivityVerified {
+		t.Errorf("Wanted weak subjectivity header %v, received %v", []string{blockchain.WeakSubjectivityVerified}, wsStatus)
+	}
+}
+
 func TestNodeServer_GetGenesis(t *testing.T) {
 	db := dbutil.SetupDB(t)
 	defer dbutil.TeardownDB(t, db)
@@ -17,6 +17,7 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
 	"github.com/prysmaticlabs/prysm/beacon-chain/cache/depositcache"
 	blockfeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/block"
+	gossipfeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/gossip"
 	opfeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/operation"
 	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
 	"github.com/prysmaticlabs/prysm/beacon-chain/db"
@@ -26,11 +27,14 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
 	"github.com/prysmaticlabs/prysm/beacon-chain/powchain"
 	"github.com/prysmaticlabs/prysm/beacon-chain/rpc/beacon"
+	"github.com/prysmaticlabs/prysm/beacon-chain/rpc/debug"
+	"github.com/prysmaticlabs/prysm/beacon-chain/rpc/events"
 	"github.com/prysmaticlabs/prysm/beacon-chain/rpc/node"
 	"github.com/prysmaticlabs/prysm/beacon-chain/rpc/validator"
 	"github.com/prysmaticlabs/prysm/beacon-chain/state/stategen"
 	"github.com/prysmaticlabs/prysm/beacon-chain/sync"
 	pbp2p "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	pbrpc "github.com/prysmaticlabs/prysm/proto/beacon/rpc/v1"
 	slashpb "github.com/prysmaticlabs/prysm/proto/slashing"
 	"github.com/prysmaticlabs/prysm/shared/featureconfig"
 	"github.com/prysmaticlabs/prysm/shared/params"
@@ -58,6 +62,8 @@ type Service struct {
 	forkFetcher            blockchain.ForkFetcher
 	finalizationFetcher    blockchain.FinalizationFetcher
 	participationFetcher   blockchain.ParticipationFetcher
+	forkChoiceFetcher      blockchain.ForkChoiceFetcher
+	wsFetcher              blockchain.WeakSubjectivityFetcher
 	genesisTimeFetcher     blockchain.TimeFetcher
 	attestationReceiver    blockchain.AttestationReceiver
 	blockReceiver          blockchain.BlockReceiver
@@ -79,11 +85,14 @@ type Service struct {
 	credentialError        error
 	p2p                    p2p.Broadcaster
 	peersFetcher           p2p.PeersProvider
+	pubSubProvider         p2p.PubSubProvider
+	gossipInspector        p2p.GossipInspector
 	depositFetcher         depositcache.DepositFetcher
 	pendingDepositFetcher  depositcache.PendingDepositsFetcher
 	stateNotifier          statefeed.Notifier
 	blockNotifier          blockfeed.Notifier
 	operationNotifier      opfeed.Notifier
+	gossipNotifier         gossipfeed.Notifier
 	slasherConn            *grpc.ClientConn
 	slasherProvider        string
 	slasherCert            string
@@ -94,35 +103,40 @@ type Service struct {
 
 // Config options for the beacon node RPC server.
 type Config struct {
-	Host                  string
-	Port                  string
-	CertFlag              string
-	KeyFlag               string
-	BeaconDB              db.HeadAccessDatabase
-	HeadFetcher           blockchain.HeadFetcher
-	ForkFetcher           blockchain.ForkFetcher
-	FinalizationFetcher   blockchain.FinalizationFetcher
-	ParticipationFetcher  blockchain.ParticipationFetcher
-	AttestationReceiver   blockchain.AttestationReceiver
-	BlockReceiver         blockchain.BlockReceiver
-	POWChainService       powchain.Chain
-	ChainStartFetcher     powchain.ChainStartFetcher
-	GenesisTimeFetcher    blockchain.TimeFetcher
-	MockEth1Votes         bool
-	AttestationsPool      attestations.Pool
-	ExitPool              *voluntaryexits.Pool
-	SlashingsPool         *slashings.Pool
-	SyncService           sync.Checker
-	Broadcaster           p2p.Broadcaster
-	PeersFetcher          p2p.PeersProvider
-	DepositFetcher        depositcache.DepositFetcher
-	PendingDepositFetcher depositcache.PendingDepositsFetcher
-	SlasherProvider       string
-	SlasherCert           string
-	StateNotifier         statefeed.Notifier
-	BlockNotifier         blockfeed.Notifier
-	OperationNotifier     opfeed.Notifier
-	StateGen              *stategen.State
+	Host                    string
+	Port                    string
+	CertFlag                string
+	KeyFlag                 string
+	BeaconDB                db.HeadAccessDatabase
+	HeadFetcher             blockchain.HeadFetcher
+	ForkFetcher             blockchain.ForkFetcher
+	FinalizationFetcher     blockchain.FinalizationFetcher
+	ParticipationFetcher    blockchain.ParticipationFetcher
+	ForkChoiceFetcher       blockchain.ForkChoiceFetcher
+	WeakSubjectivityFetcher blockchain.WeakSubjectivityFetcher
+	AttestationReceiver     blockchain.AttestationReceiver
+	BlockReceiver           blockchain.BlockReceiver
+	POWChainService         powchain.Chain
+	ChainStartFetcher       powchain.ChainStartFetcher
+	GenesisTimeFetcher      blockchain.TimeFetcher
+	MockEth1Votes           bool
+	AttestationsPool        attestations.Pool
+	ExitPool                *voluntaryexits.Pool
+	SlashingsPool           *slashings.Pool
+	SyncService             sync.Checker
+	Broadcaster             p2p.Broadcaster
+	PeersFetcher            p2p.PeersProvider
+	PubSubProvider          p2p.PubSubProvider
+	GossipInspector         p2p.GossipInspector
+	DepositFetcher          depositcache.DepositFetcher
+	PendingDepositFetcher   depositcache.PendingDepositsFetcher
+	SlasherProvider         string
+	SlasherCert             string
+	StateNotifier           statefeed.Notifier
+	BlockNotifier           blockfeed.Notifier
+	OperationNotifier       opfeed.Notifier
+	GossipNotifier          gossipfeed.Notifier
+	StateGen                *stategen.State
 }
 
 // NewService instantiates a new RPC service instance that will
@@ -137,11 +151,15 @@ func NewService(ctx context.Context, cfg *Config) *Service {
 		forkFetcher:           cfg.ForkFetcher,
 		finalizationFetcher:   cfg.FinalizationFetcher,
 		participationFetcher:  cfg.ParticipationFetcher,
+		forkChoiceFetcher:     cfg.ForkChoiceFetcher,
+		wsFetcher:             cfg.WeakSubjectivityFetcher,
 		genesisTimeFetcher:    cfg.GenesisTimeFetcher,
 		attestationReceiver:   cfg.AttestationReceiver,
 		blockReceiver:         cfg.BlockReceiver,
 		p2p:                   cfg.Broadcaster,
 		peersFetcher:          cfg.PeersFetcher,
+		pubSubProvider:        cfg.PubSubProvider,
+		gossipInspector:       cfg.GossipInspector,
 		powChainService:       cfg.POWChainService,
 		chainStartFetcher:     cfg.ChainStartFetcher,
 		mockEth1Votes:         cfg.MockEth1Votes,
@@ -160,6 +178,7 @@ func NewService(ctx context.Context, cfg *Config) *Service {
 		stateNotifier:         cfg.StateNotifier,
 		blockNotifier:         cfg.BlockNotifier,
 		operationNotifier:     cfg.OperationNotifier,
+		gossipNotifier:        cfg.GossipNotifier,
 		slasherProvider:       cfg.SlasherProvider,
 		slasherCert:           cfg.SlasherCert,
 		stateGen:              cfg.StateGen,
@@ -236,11 +255,12 @@ func (s *Service) Start() {
 		StateGen:               s.stateGen,
 	}
 	nodeServer := &node.Server{
-		BeaconDB:           s.beaconDB,
-		Server:             s.grpcServer,
-		SyncChecker:        s.syncService,
-		GenesisTimeFetcher: s.genesisTimeFetcher,
-		PeersFetcher:       s.peersFetcher,
+		BeaconDB:                s.beaconDB,
+		Server:                  s.grpcServer,
+		SyncChecker:             s.syncService,
+		GenesisTimeFetcher:      s.genesisTimeFetcher,
+		PeersFetcher:            s.peersFetcher,
+		WeakSubjectivityFetcher: s.wsFetcher,
 	}
 	beaconChainServer := &beacon.Server{
 		Ctx:                         s.ctx,
@@ -258,14 +278,30 @@ func (s *Service) Start() {
 		StateNotifier:               s.stateNotifier,
 		BlockNotifier:               s.blockNotifier,
 		AttestationNotifier:         s.operationNotifier,
+		GossipNotifier:              s.gossipNotifier,
 		Broadcaster:                 s.p2p,
 		StateGen:                    s.stateGen,
 		ReceivedAttestationsBuffer:  make(chan *ethpb.Attestation, 100),
 		CollectedAttestationsBuffer: make(chan []*ethpb.Attestation, 100),
 	}
+	debugServer := &debug.Server{
+		PeersFetcher:      s.peersFetcher,
+		PubSubProvider:    s.pubSubProvider,
+		GossipInspector:   s.gossipInspector,
+		ForkChoiceFetcher: s.forkChoiceFetcher,
+	}
+	eventsServer := &events.Server{
+		Ctx:               s.ctx,
+		StateNotifier:     s.stateNotifier,
+		BlockNotifier:     s.blockNotifier,
+		OperationNotifier: s.operationNotifier,
+	}
 	ethpb.RegisterNodeServer(s.grpcServer, nodeServer)
 	ethpb.RegisterBeaconChainServer(s.grpcServer, beaconChainServer)
 	ethpb.RegisterBeaconNodeValidatorServer(s.grpcServer, validatorServer)
+	pbrpc.RegisterDebugServer(s.grpcServer, debugServer)
+	pbrpc.RegisterEventsServer(s.grpcServer, eventsServer)
+	pbrpc.RegisterGossipServer(s.grpcServer, beaconChainServer)
 
 	// Register reflection service on gRPC server.
 	reflection.Register(s.grpcServer)
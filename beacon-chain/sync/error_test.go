@@ -11,7 +11,7 @@ func TestRegularSync_generateErrorResponse(t *testing.T) {
 	r := &Service{
 		p2p: p2ptest.NewTestP2P(t),
 	}
-	data, err := r.generateErrorResponse(responseCodeServerError, "something bad happened")
+	data, err := r.generateErrorResponse(responseCodeServerError, "something bad happened", r.p2p.Encoding())
 	if err != nil {
 		t.Fatal(err)
 	}
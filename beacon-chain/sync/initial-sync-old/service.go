@@ -14,6 +14,7 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/db"
 	"github.com/prysmaticlabs/prysm/beacon-chain/flags"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
+	prysmsync "github.com/prysmaticlabs/prysm/beacon-chain/sync"
 	"github.com/prysmaticlabs/prysm/shared"
 	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/prysmaticlabs/prysm/shared/roughtime"
@@ -181,6 +182,19 @@ func (s *Service) Resync() error {
 	return nil
 }
 
+// ResyncToHead is not supported by the legacy initial sync implementation.
+func (s *Service) ResyncToHead() error {
+	return errors.New("head sync is not supported by the legacy initial sync, run without --disable-init-sync-queue")
+}
+
+// SyncMode returns how the node is currently syncing with its peers.
+func (s *Service) SyncMode() string {
+	if !s.Syncing() {
+		return prysmsync.SyncModeSynced
+	}
+	return prysmsync.SyncModeInitial
+}
+
 func (s *Service) waitForMinimumPeers() {
 	required := params.BeaconConfig().MaxPeersToSync
 	if flags.Get().MinimumSyncPeers < required {
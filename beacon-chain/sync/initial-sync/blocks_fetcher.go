@@ -47,8 +47,8 @@ type blocksFetcherConfig struct {
 }
 
 // blocksFetcher is a service to fetch chain data from peers.
-// On an incoming requests, requested block range is evenly divided
-// among available peers (for fair network load distribution).
+// On an incoming requests, requested block range is divided among available peers,
+// proportionally to the throughput observed for each peer.
 type blocksFetcher struct {
 	sync.Mutex
 	ctx            context.Context
@@ -56,6 +56,8 @@ type blocksFetcher struct {
 	headFetcher    blockchain.HeadFetcher
 	p2p            p2p.P2P
 	rateLimiter    *leakybucket.Collector
+	peerStats      map[peer.ID]float64 // observed throughput of peers, in slots per second
+	peerStatsLock  sync.RWMutex
 	fetchRequests  chan *fetchRequestParams
 	fetchResponses chan *fetchRequestResponse
 	quit           chan struct{} // termination notifier
@@ -70,9 +72,11 @@ type fetchRequestParams struct {
 
 // fetchRequestResponse is a combined type to hold results of both successful executions and errors.
 // Valid usage pattern will be to check whether result's `err` is nil, before using `blocks`.
+// Response can be partial, in which case sub-ranges that could not be fetched are listed in `missing`.
 type fetchRequestResponse struct {
 	start, count uint64
 	blocks       []*eth.SignedBeaconBlock
+	missing      []*fetchRequestParams
 	err          error
 	peers        []peer.ID
 }
@@ -91,6 +95,7 @@ func newBlocksFetcher(ctx context.Context, cfg *blocksFetcherConfig) *blocksFetc
 		headFetcher:    cfg.headFetcher,
 		p2p:            cfg.p2p,
 		rateLimiter:    rateLimiter,
+		peerStats:      make(map[peer.ID]float64),
 		fetchRequests:  make(chan *fetchRequestParams, maxPendingRequests),
 		fetchResponses: make(chan *fetchRequestResponse, maxPendingRequests),
 		quit:           make(chan struct{}),
@@ -205,117 +210,111 @@ func (f *blocksFetcher) handleRequest(ctx context.Context, start, count uint64)
 		return response
 	}
 
-	blocks, err := f.collectPeerResponses(ctx, root, finalizedEpoch, start, 1, count, peers)
+	blocks, missing, err := f.collectPeerResponses(ctx, root, finalizedEpoch, start, count, peers)
 	if err != nil {
 		response.err = err
 		return response
 	}
 
 	response.blocks = blocks
+	response.missing = missing
 	response.peers = peers
 	return response
 }
 
 // collectPeerResponses orchestrates block fetching from the available peers.
-// In each request a range of blocks is to be requested from multiple peers.
-// Example:
-//   - number of peers = 4
-//   - range of block slots is 64...128
-//   Four requests will be spread across the peers using step argument to distribute the load
-//   i.e. the first peer is asked for block 64, 68, 72... while the second peer is asked for
-//   65, 69, 73... and so on for other peers.
+// In each request a range of blocks is split into contiguous windows, one per peer, sized
+// proportionally to the throughput observed for the peer.
+// For example, with three peers, the first one being twice as fast as the others, and a range of
+// slots 64...96, the first peer is asked for blocks 64...79, the second for 80...87 and the third
+// for 88...95.
+// Windows that could not be fetched are returned as missing, so that only they are re-requested.
 func (f *blocksFetcher) collectPeerResponses(
 	ctx context.Context,
 	root []byte,
-	finalizedEpoch, start, step, count uint64,
+	finalizedEpoch, start, count uint64,
 	peers []peer.ID,
-) ([]*eth.SignedBeaconBlock, error) {
+) ([]*eth.SignedBeaconBlock, []*fetchRequestParams, error) {
 	ctx, span := trace.StartSpan(ctx, "initialsync.collectPeerResponses")
 	defer span.End()
 
 	if ctx.Err() != nil {
-		return nil, ctx.Err()
+		return nil, nil, ctx.Err()
 	}
 
 	peers = f.selectPeers(peers)
 	if len(peers) == 0 {
-		return nil, errNoPeersAvailable
+		return nil, nil, errNoPeersAvailable
 	}
 
-	p2pRequests := new(sync.WaitGroup)
-	errChan := make(chan error)
-	blocksChan := make(chan []*eth.SignedBeaconBlock)
-
-	p2pRequests.Add(len(peers))
-	go func() {
-		p2pRequests.Wait()
-		close(blocksChan)
-	}()
-
 	// Short circuit start far exceeding the highest finalized epoch in some infinite loop.
 	highestFinalizedSlot := helpers.StartSlot(finalizedEpoch + 1)
 	if start > highestFinalizedSlot {
-		return nil, errSlotIsTooHigh
+		return nil, nil, errSlotIsTooHigh
 	}
 
-	// Spread load evenly among available peers.
-	perPeerCount := mathutil.Min(count/uint64(len(peers)), allowedBlocksPerSecond)
-	remainder := int(count % uint64(len(peers)))
-	for i, pid := range peers {
-		start, step := start+uint64(i)*step, step*uint64(len(peers))
-
-		// If the count was divided by an odd number of peers, there will be some blocks
-		// missing from the first requests so we accommodate that scenario.
-		count := perPeerCount
-		if i < remainder {
-			count++
-		}
-		// Asking for no blocks may cause the client to hang.
-		if count == 0 {
-			p2pRequests.Done()
-			continue
-		}
-
-		go func(ctx context.Context, pid peer.ID) {
-			defer p2pRequests.Done()
-
-			blocks, err := f.requestBeaconBlocksByRange(ctx, pid, root, start, step, count)
-			if err != nil {
-				select {
-				case <-ctx.Done():
-				case errChan <- err:
-					return
-				}
-			}
-			select {
-			case <-ctx.Done():
-			case blocksChan <- blocks:
+	type windowResponse struct {
+		window *peerWindow
+		blocks []*eth.SignedBeaconBlock
+		err    error
+	}
+	windows := f.peerWindows(peers, start, count)
+	responses := make(chan *windowResponse, len(windows))
+	for _, window := range windows {
+		go func(window *peerWindow) {
+			blocks, err := f.requestBeaconBlocksByRange(ctx, window.pid, root, window.start, 1, window.count)
+			responses <- &windowResponse{
+				window: window,
+				blocks: blocks,
+				err:    err,
 			}
-		}(ctx, pid)
+		}(window)
 	}
 
 	var unionRespBlocks []*eth.SignedBeaconBlock
-	for {
+	var missing []*fetchRequestParams
+	var lastErr error
+	var assigned uint64
+	for range windows {
 		select {
 		case <-ctx.Done():
-			return nil, ctx.Err()
-		case err := <-errChan:
-			return nil, err
-		case resp, ok := <-blocksChan:
-			if ok {
-				unionRespBlocks = append(unionRespBlocks, resp...)
-			} else {
-				sort.Slice(unionRespBlocks, func(i, j int) bool {
-					return unionRespBlocks[i].Block.Slot < unionRespBlocks[j].Block.Slot
+			return nil, nil, ctx.Err()
+		case resp := <-responses:
+			assigned += resp.window.count
+			if resp.err != nil {
+				lastErr = resp.err
+				missing = append(missing, &fetchRequestParams{
+					start: resp.window.start,
+					count: resp.window.count,
 				})
-				return unionRespBlocks, nil
+				continue
 			}
+			unionRespBlocks = append(unionRespBlocks, resp.blocks...)
 		}
 	}
+	if len(windows) > 0 && len(missing) == len(windows) {
+		return nil, nil, lastErr
+	}
+	// Slots that didn't fit into windows need to be requested separately.
+	if assigned < count {
+		missing = append(missing, &fetchRequestParams{
+			start: start + assigned,
+			count: count - assigned,
+		})
+	}
+
+	sort.Slice(unionRespBlocks, func(i, j int) bool {
+		return unionRespBlocks[i].Block.Slot < unionRespBlocks[j].Block.Slot
+	})
+	sort.Slice(missing, func(i, j int) bool {
+		return missing[i].start < missing[j].start
+	})
+	return unionRespBlocks, missing, nil
 }
 
 // requestBeaconBlocksByRange prepares BeaconBlocksByRange request, and handles possible stale peers
-// (by resending the request).
+// (by resending the request). If some blocks are received before the request fails, only the
+// remaining part of the range is resent.
 func (f *blocksFetcher) requestBeaconBlocksByRange(
 	ctx context.Context,
 	pid peer.ID,
@@ -332,8 +331,28 @@ func (f *blocksFetcher) requestBeaconBlocksByRange(
 		Step:      step,
 	}
 
+	requestStart := time.Now()
 	resp, respErr := f.requestBlocks(ctx, req, pid)
 	if respErr != nil {
+		f.penalizePeerThroughput(pid)
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		// Keep blocks received so far, and only ask for the rest of the range.
+		if len(resp) > 0 {
+			lastSlot := resp[len(resp)-1].Block.Slot
+			if lastSlot >= start {
+				served := (lastSlot-start)/step + 1
+				if served >= count {
+					return resp, nil
+				}
+				start, count = lastSlot+step, count-served
+			} else {
+				resp = nil
+			}
+		}
+
 		// Fail over to some other, randomly selected, peer.
 		headEpoch := helpers.SlotToEpoch(f.headFetcher.HeadSlot())
 		root1, _, peers := f.p2p.Peers().BestFinalized(params.BeaconConfig().MaxPeersToSync, headEpoch)
@@ -351,13 +370,19 @@ func (f *blocksFetcher) requestBeaconBlocksByRange(
 			"newPeer":    newPID.Pretty(),
 		}).Debug("Request failed, trying to forward request to another peer")
 
-		return f.requestBeaconBlocksByRange(ctx, newPID, root, start, step, count)
+		rest, err := f.requestBeaconBlocksByRange(ctx, newPID, root, start, step, count)
+		if err != nil {
+			return nil, err
+		}
+		return append(resp, rest...), nil
 	}
+	f.updatePeerThroughput(pid, count, time.Since(requestStart))
 
 	return resp, nil
 }
 
 // requestBlocks is a wrapper for handling BeaconBlocksByRangeRequest requests/streams.
+// Should the stream fail midway, blocks read so far are returned along with the error.
 func (f *blocksFetcher) requestBlocks(
 	ctx context.Context,
 	req *p2ppb.BeaconBlocksByRangeRequest,
@@ -393,7 +418,7 @@ func (f *blocksFetcher) requestBlocks(
 			break
 		}
 		if err != nil {
-			return nil, err
+			return resp, err
 		}
 		resp = append(resp, blk)
 	}
@@ -469,41 +494,59 @@ func (f *blocksFetcher) selectPeers(peers []peer.ID) []peer.ID {
 }
 
 // nonSkippedSlotAfter checks slots after the given one in an attempt to find non-empty future slot.
+// Sparse regions are probed by spreading the search range among peers using request's step: with N
+// peers, peer i is asked for slots i, i+N, i+2N..., so that a single round of requests covers N times
+// more slots than a request to a single peer would, while still not skipping over any slot.
 func (f *blocksFetcher) nonSkippedSlotAfter(ctx context.Context, slot uint64) (uint64, error) {
 	headEpoch := helpers.SlotToEpoch(f.headFetcher.HeadSlot())
 	_, epoch, peers := f.p2p.Peers().BestFinalized(params.BeaconConfig().MaxPeersToSync, headEpoch)
 	if len(peers) == 0 {
 		return 0, errNoPeersAvailable
 	}
+	peers = f.selectPeers(peers)
 
-	randGenerator := rand.New(rand.NewSource(roughtime.Now().Unix()))
-	nextPID := func() peer.ID {
-		randGenerator.Shuffle(len(peers), func(i, j int) {
-			peers[i], peers[j] = peers[j], peers[i]
-		})
-		return peers[0]
+	type probeResponse struct {
+		blocks []*eth.SignedBeaconBlock
+		err    error
 	}
-
+	step := uint64(len(peers))
 	for slot <= helpers.StartSlot(epoch+1) {
-		req := &p2ppb.BeaconBlocksByRangeRequest{
-			StartSlot: slot + 1,
-			Count:     blockBatchSize,
-			Step:      1,
-		}
-
-		blocks, err := f.requestBlocks(ctx, req, nextPID())
-		if err != nil {
-			return slot, err
+		responses := make(chan *probeResponse, len(peers))
+		for i, pid := range peers {
+			req := &p2ppb.BeaconBlocksByRangeRequest{
+				StartSlot: slot + 1 + uint64(i),
+				Count:     blockBatchSize,
+				Step:      step,
+			}
+			go func(pid peer.ID) {
+				blocks, err := f.requestBlocks(ctx, req, pid)
+				responses <- &probeResponse{
+					blocks: blocks,
+					err:    err,
+				}
+			}(pid)
 		}
 
-		if len(blocks) > 0 {
-			slots := make([]uint64, len(blocks))
-			for i, block := range blocks {
-				slots[i] = block.Block.Slot
+		var nonSkippedSlot uint64
+		for range peers {
+			select {
+			case <-ctx.Done():
+				return slot, ctx.Err()
+			case resp := <-responses:
+				if resp.err != nil {
+					return slot, resp.err
+				}
+				for _, block := range resp.blocks {
+					if nonSkippedSlot == 0 || block.Block.Slot < nonSkippedSlot {
+						nonSkippedSlot = block.Block.Slot
+					}
+				}
 			}
-			return blocks[0].Block.Slot, nil
 		}
-		slot += blockBatchSize
+		if nonSkippedSlot > 0 {
+			return nonSkippedSlot, nil
+		}
+		slot += blockBatchSize * step
 	}
 
 	return slot, nil
@@ -0,0 +1,145 @@
+package initialsync
+
+import (
+	"sort"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+)
+
+const (
+	// peerThroughputDecay is a weight given to the most recent throughput observation of a peer.
+	peerThroughputDecay = 0.3
+	// peerThroughputFailurePenalty is a factor by which throughput is reduced on failed requests.
+	peerThroughputFailurePenalty = 0.5
+	// defaultPeerThroughput is a throughput (in slots per second) assumed for peers with no observations.
+	defaultPeerThroughput = float64(allowedBlocksPerSecond)
+)
+
+// peerWindow is a contiguous range of slots to be requested from a single peer.
+type peerWindow struct {
+	pid          peer.ID
+	start, count uint64
+}
+
+// updatePeerThroughput records number of slots served by a peer in a given time, so that subsequent
+// requests can be sized according to how fast the peer is.
+func (f *blocksFetcher) updatePeerThroughput(pid peer.ID, slots uint64, elapsed time.Duration) {
+	if elapsed <= 0 {
+		return
+	}
+	observed := float64(slots) / elapsed.Seconds()
+
+	f.peerStatsLock.Lock()
+	defer f.peerStatsLock.Unlock()
+	throughput, ok := f.peerStats[pid]
+	if !ok {
+		f.peerStats[pid] = observed
+		return
+	}
+	f.peerStats[pid] = peerThroughputDecay*observed + (1-peerThroughputDecay)*throughput
+}
+
+// penalizePeerThroughput reduces throughput of a peer, which failed to serve a request.
+func (f *blocksFetcher) penalizePeerThroughput(pid peer.ID) {
+	f.peerStatsLock.Lock()
+	defer f.peerStatsLock.Unlock()
+	throughput, ok := f.peerStats[pid]
+	if !ok {
+		throughput = defaultPeerThroughput
+	}
+	f.peerStats[pid] = throughput * peerThroughputFailurePenalty
+}
+
+// peerThroughputs returns observed throughput of given peers. Peers with no observations are
+// assumed to be as fast as an average known peer.
+func (f *blocksFetcher) peerThroughputs(peers []peer.ID) []float64 {
+	f.peerStatsLock.RLock()
+	defer f.peerStatsLock.RUnlock()
+
+	throughputs := make([]float64, len(peers))
+	var total float64
+	var known int
+	for i, pid := range peers {
+		if throughput, ok := f.peerStats[pid]; ok {
+			throughputs[i] = throughput
+			total += throughput
+			known++
+		}
+	}
+	average := defaultPeerThroughput
+	if known > 0 && total > 0 {
+		average = total / float64(known)
+	}
+	for i, pid := range peers {
+		if _, ok := f.peerStats[pid]; !ok || throughputs[i] <= 0 {
+			throughputs[i] = average
+		}
+	}
+	return throughputs
+}
+
+// peerWindows splits a range of slots into contiguous windows, one per peer, sized proportionally
+// to the observed throughput of each peer. No window exceeds allowedBlocksPerSecond slots, slots
+// that can not be fit into windows are left out (and should be requested later).
+func (f *blocksFetcher) peerWindows(peers []peer.ID, start, count uint64) []*peerWindow {
+	if len(peers) == 0 || count == 0 {
+		return nil
+	}
+	throughputs := f.peerThroughputs(peers)
+	var total float64
+	for _, throughput := range throughputs {
+		total += throughput
+	}
+
+	// Faster peers go first, so that they get the remainder (if any).
+	order := make([]int, len(peers))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return throughputs[order[i]] > throughputs[order[j]]
+	})
+
+	sizes := make([]uint64, len(peers))
+	var assigned uint64
+	for _, i := range order {
+		sizes[i] = uint64(float64(count) * throughputs[i] / total)
+		if sizes[i] > allowedBlocksPerSecond {
+			sizes[i] = allowedBlocksPerSecond
+		}
+		assigned += sizes[i]
+	}
+	// Distribute slots lost to rounding, keeping windows within the limit.
+	for assigned < count {
+		progress := false
+		for _, i := range order {
+			if assigned == count {
+				break
+			}
+			if sizes[i] < allowedBlocksPerSecond {
+				sizes[i]++
+				assigned++
+				progress = true
+			}
+		}
+		if !progress {
+			break
+		}
+	}
+
+	windows := make([]*peerWindow, 0, len(peers))
+	for _, i := range order {
+		// Asking for no blocks may cause the client to hang.
+		if sizes[i] == 0 {
+			continue
+		}
+		windows = append(windows, &peerWindow{
+			pid:   peers[i],
+			start: start,
+			count: sizes[i],
+		})
+		start += sizes[i]
+	}
+	return windows
+}
@@ -711,6 +711,54 @@ func TestBlocksFetcherNonSkippedSlotAfter(t *testing.T) {
 	}
 }
 
+func TestBlocksFetcherPeerWindows(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	fetcher := newBlocksFetcher(ctx, &blocksFetcherConfig{})
+	peers := []peer.ID{"a", "b", "c"}
+
+	checkWindows := func(windows []*peerWindow, want []*peerWindow) {
+		if len(windows) != len(want) {
+			t.Fatalf("unexpected number of windows, want: %v, got: %v", len(want), len(windows))
+		}
+		for i := range want {
+			if *windows[i] != *want[i] {
+				t.Errorf("unexpected window, want: %v, got: %v", *want[i], *windows[i])
+			}
+		}
+	}
+
+	// No throughput observed yet, so range is split evenly.
+	checkWindows(fetcher.peerWindows(peers, 64, 30), []*peerWindow{
+		{pid: "a", start: 64, count: 10},
+		{pid: "b", start: 74, count: 10},
+		{pid: "c", start: 84, count: 10},
+	})
+
+	// The fastest peer gets the largest window.
+	fetcher.updatePeerThroughput("a", 32, time.Second)
+	fetcher.updatePeerThroughput("b", 64, time.Second)
+	fetcher.updatePeerThroughput("c", 32, time.Second)
+	checkWindows(fetcher.peerWindows(peers, 64, 32), []*peerWindow{
+		{pid: "b", start: 64, count: 16},
+		{pid: "a", start: 80, count: 8},
+		{pid: "c", start: 88, count: 8},
+	})
+
+	// Failed requests reduce peer's share.
+	fetcher.penalizePeerThroughput("b")
+	checkWindows(fetcher.peerWindows(peers, 64, 30), []*peerWindow{
+		{pid: "a", start: 64, count: 10},
+		{pid: "b", start: 74, count: 10},
+		{pid: "c", start: 84, count: 10},
+	})
+
+	// Windows never exceed the number of blocks a peer is allowed to serve at once.
+	checkWindows(fetcher.peerWindows(peers[:1], 0, 100), []*peerWindow{
+		{pid: "a", start: 0, count: allowedBlocksPerSecond},
+	})
+}
+
 func initializeTestServices(t *testing.T, blocks []uint64, peers []*peerData) (*mock.ChainService, *p2pt.TestP2P, db.Database) {
 	cache.initializeRootCache(blocks, t)
 	beaconDB := dbtest.SetupDB(t)
@@ -3,6 +3,7 @@ package initialsync
 import (
 	"context"
 	"errors"
+	"sort"
 	"time"
 
 	eth "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
@@ -10,6 +11,7 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
 	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/roughtime"
 	"github.com/sirupsen/logrus"
 )
 
@@ -91,8 +93,15 @@ func newBlocksQueue(ctx context.Context, cfg *blocksQueueConfig) *blocksQueue {
 
 	// Configure state machine.
 	queue.state = newStateMachine()
+	// A trigger cascades into the later added handlers of the states an epoch transitions to, so a
+	// response must only be handled once along any transitions: sub-range responses are handled in
+	// the rescheduled state, which no data received event transitions to.
 	queue.state.addHandler(stateNew, eventSchedule, queue.onScheduleEvent(ctx))
 	queue.state.addHandler(stateScheduled, eventDataReceived, queue.onDataReceivedEvent(ctx))
+	queue.state.addHandler(stateDataPartial, eventSchedule, queue.onRescheduleEvent(ctx))
+	queue.state.addHandler(stateDataPartial, eventCheckStale, queue.onCheckStaleEvent(ctx))
+	queue.state.addHandler(stateRescheduled, eventDataReceived, queue.onPartialDataReceivedEvent(ctx))
+	queue.state.addHandler(stateRescheduled, eventCheckStale, queue.onCheckStaleEvent(ctx))
 	queue.state.addHandler(stateDataParsed, eventReadyToSend, queue.onReadyToSendEvent(ctx))
 	queue.state.addHandler(stateSkipped, eventExtendWindow, queue.onExtendWindowEvent(ctx))
 	queue.state.addHandler(stateSent, eventCheckStale, queue.onCheckStaleEvent(ctx))
@@ -258,11 +267,94 @@ func (q *blocksQueue) onDataReceivedEvent(ctx context.Context) eventHandlerFn {
 			return es.state, response.err
 		}
 
+		// Ignore late responses to sub-range requests of an epoch, which has been reset since.
+		if response.start != helpers.StartSlot(epoch) || response.count != params.BeaconConfig().SlotsPerEpoch {
+			return es.state, nil
+		}
+
 		ind, ok := q.state.findEpochState(epoch)
 		if !ok {
 			return es.state, errNoEpochState
 		}
 		q.state.epochs[ind].blocks = response.blocks
+		if len(response.missing) > 0 {
+			q.state.epochs[ind].missing = response.missing
+			q.state.epochs[ind].pending = nil
+			return stateDataPartial, nil
+		}
+		return stateDataParsed, nil
+	}
+}
+
+// onRescheduleEvent is an event called on epochs with partially received data.
+// Only sub-ranges that are still missing are requested again. Transforms state to rescheduled.
+func (q *blocksQueue) onRescheduleEvent(ctx context.Context) eventHandlerFn {
+	return func(es *epochState, in interface{}) (stateID, error) {
+		if ctx.Err() != nil {
+			return es.state, ctx.Err()
+		}
+
+		for len(es.missing) > 0 {
+			missing := es.missing[0]
+			if err := q.blocksFetcher.scheduleRequest(ctx, missing.start, missing.count); err != nil {
+				if len(es.pending) > 0 {
+					// Remaining sub-ranges are requested again once the pending ones are received.
+					return stateRescheduled, nil
+				}
+				return es.state, err
+			}
+			es.missing = es.missing[1:]
+			es.pending = append(es.pending, missing)
+		}
+		return stateRescheduled, nil
+	}
+}
+
+// onPartialDataReceivedEvent is an event called when data for a missing sub-range is received from fetcher.
+// Responses to ranges which are not pending, such as late responses to a request of the whole epoch, are
+// discarded, so that their blocks are not received twice. Once no more responses are pending, sub-ranges
+// which are still missing are requested again from the data partial state. Once all the sub-ranges are
+// received, epoch's data is considered complete.
+func (q *blocksQueue) onPartialDataReceivedEvent(ctx context.Context) eventHandlerFn {
+	return func(es *epochState, in interface{}) (stateID, error) {
+		if ctx.Err() != nil {
+			return es.state, ctx.Err()
+		}
+
+		response, ok := in.(*fetchRequestResponse)
+		if !ok {
+			return 0, errInputNotFetchRequestParams
+		}
+		pendingInd := -1
+		for i, pending := range es.pending {
+			if pending.start == response.start && pending.count == response.count {
+				pendingInd = i
+				break
+			}
+		}
+		if pendingInd < 0 {
+			return es.state, nil
+		}
+		es.pending = append(es.pending[:pendingInd], es.pending[pendingInd+1:]...)
+		es.updated = roughtime.Now()
+		if response.err != nil {
+			es.missing = append(es.missing, &fetchRequestParams{
+				start: response.start,
+				count: response.count,
+			})
+		} else {
+			es.blocks = append(es.blocks, response.blocks...)
+			es.missing = append(es.missing, response.missing...)
+		}
+		if len(es.pending) > 0 {
+			return stateRescheduled, nil
+		}
+		if len(es.missing) > 0 {
+			return stateDataPartial, nil
+		}
+		sort.Slice(es.blocks, func(i, j int) bool {
+			return es.blocks[i].Block.Slot < es.blocks[j].Block.Slot
+		})
 		return stateDataParsed, nil
 	}
 }
@@ -308,7 +400,7 @@ func (q *blocksQueue) onReadyToSendEvent(ctx context.Context) eventHandlerFn {
 			// Review only previous slots.
 			if state.epoch < epoch {
 				switch state.state {
-				case stateNew, stateScheduled, stateDataParsed:
+				case stateNew, stateScheduled, stateDataParsed, stateDataPartial, stateRescheduled:
 					return es.state, nil
 				default:
 				}
@@ -401,6 +493,13 @@ func (q *blocksQueue) onCheckStaleEvent(ctx context.Context) eventHandlerFn {
 		}
 
 		if time.Since(es.updated) > staleEpochTimeout {
+			// Epochs stuck waiting for missing sub-ranges are requested again as a whole.
+			if es.state == stateDataPartial || es.state == stateRescheduled {
+				es.blocks = es.blocks[:0]
+				es.missing = nil
+				es.pending = nil
+				return stateNew, nil
+			}
 			return stateSkipped, nil
 		}
 
@@ -6,9 +6,11 @@ import (
 	"testing"
 
 	eth "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
 	dbtest "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
 	"github.com/prysmaticlabs/prysm/shared/featureconfig"
+	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/prysmaticlabs/prysm/shared/sliceutil"
 )
 
@@ -318,3 +320,107 @@ func TestBlocksQueueLoop(t *testing.T) {
 		})
 	}
 }
+
+func TestBlocksQueuePartialResponse(t *testing.T) {
+	mc, p2p, beaconDB := initializeTestServices(t, []uint64{}, []*peerData{})
+	defer dbtest.TeardownDB(t, beaconDB)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	fetcher := newBlocksFetcher(ctx, &blocksFetcherConfig{
+		headFetcher: mc,
+		p2p:         p2p,
+	})
+	queue := newBlocksQueue(ctx, &blocksQueueConfig{
+		blocksFetcher:       fetcher,
+		headFetcher:         mc,
+		highestExpectedSlot: 4 * params.BeaconConfig().SlotsPerEpoch,
+	})
+
+	makeBlocks := func(slots []uint64) []*eth.SignedBeaconBlock {
+		blocks := make([]*eth.SignedBeaconBlock, len(slots))
+		for i, slot := range slots {
+			blocks[i] = &eth.SignedBeaconBlock{Block: &eth.BeaconBlock{Slot: slot}}
+		}
+		return blocks
+	}
+
+	epoch := uint64(1)
+	slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+	start, half := helpers.StartSlot(epoch), slotsPerEpoch/2
+	queue.state.addEpochState(epoch)
+	ind, _ := queue.state.findEpochState(epoch)
+	es := queue.state.epochs[ind]
+	es.setState(stateScheduled)
+
+	// Only the first half of the epoch is received.
+	response := &fetchRequestResponse{
+		start:   start,
+		count:   slotsPerEpoch,
+		blocks:  makeBlocks(makeSequence(start, start+half-1)),
+		missing: []*fetchRequestParams{{start: start + half, count: half}},
+	}
+	if err := queue.state.trigger(eventDataReceived, epoch, response); err != nil {
+		t.Fatal(err)
+	}
+	if es.state != stateDataPartial {
+		t.Fatalf("unexpected state, want: %v, got: %v", stateDataPartial, es.state)
+	}
+
+	// Only the missing sub-range is requested again.
+	data := &fetchRequestParams{start: start, count: slotsPerEpoch}
+	if err := queue.state.trigger(eventSchedule, epoch, data); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case req := <-fetcher.fetchRequests:
+		if req.start != start+half || req.count != half {
+			t.Errorf("unexpected request, want: %d/%d, got: %d/%d", start+half, half, req.start, req.count)
+		}
+	default:
+		t.Fatal("missing sub-range is not requested")
+	}
+	if es.state != stateRescheduled {
+		t.Fatalf("unexpected state, want: %v, got: %v", stateRescheduled, es.state)
+	}
+	if len(es.blocks) != int(half) {
+		t.Fatalf("unexpected number of blocks, want: %v, got: %v", half, len(es.blocks))
+	}
+
+	// A late response to an earlier request of the whole epoch is discarded.
+	response = &fetchRequestResponse{
+		start:  start,
+		count:  slotsPerEpoch,
+		blocks: makeBlocks(makeSequence(start, start+slotsPerEpoch-1)),
+	}
+	if err := queue.state.trigger(eventDataReceived, epoch, response); err != nil {
+		t.Fatal(err)
+	}
+	if es.state != stateRescheduled {
+		t.Fatalf("unexpected state, want: %v, got: %v", stateRescheduled, es.state)
+	}
+	if len(es.blocks) != int(half) {
+		t.Fatalf("unexpected number of blocks, want: %v, got: %v", half, len(es.blocks))
+	}
+
+	// Epoch's data is complete once the missing sub-range is received.
+	response = &fetchRequestResponse{
+		start:  start + half,
+		count:  half,
+		blocks: makeBlocks(makeSequence(start+half, start+slotsPerEpoch-1)),
+	}
+	if err := queue.state.trigger(eventDataReceived, epoch, response); err != nil {
+		t.Fatal(err)
+	}
+	if es.state != stateDataParsed {
+		t.Fatalf("unexpected state, want: %v, got: %v", stateDataParsed, es.state)
+	}
+	if uint64(len(es.blocks)) != slotsPerEpoch {
+		t.Errorf("unexpected number of blocks, want: %v, got: %v", slotsPerEpoch, len(es.blocks))
+	}
+	for i, blk := range es.blocks {
+		if blk.Block.Slot != start+uint64(i) {
+			t.Errorf("blocks are not in order, want slot: %v, got: %v", start+uint64(i), blk.Block.Slot)
+		}
+	}
+}
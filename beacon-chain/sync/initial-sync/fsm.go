@@ -9,10 +9,17 @@ import (
 	"github.com/prysmaticlabs/prysm/shared/roughtime"
 )
 
+// Epochs with partial responses go through stateDataPartial, in which sub-ranges of the epoch
+// that could not be fetched are waiting to be requested again, and stateRescheduled, in which
+// responses to the sub-ranges requested again are pending. Skipped slots need no state of their
+// own: a sub-range with no blocks is a complete response, and slots the fetcher could not assign
+// to any peer are returned as missing, like sub-ranges which failed to be fetched.
 const (
 	stateNew stateID = iota
 	stateScheduled
 	stateDataParsed
+	stateDataPartial
+	stateRescheduled
 	stateSkipped
 	stateSent
 	stateSkippedExt
@@ -45,6 +52,8 @@ type epochState struct {
 	epoch   uint64
 	state   stateID
 	blocks  []*eth.SignedBeaconBlock
+	missing []*fetchRequestParams // sub-ranges of a partial response, yet to be re-requested
+	pending []*fetchRequestParams // sub-ranges re-requested, with responses in flight
 	updated time.Time
 }
 
@@ -132,6 +141,7 @@ func (sm *stateMachine) removeEpochState(epoch uint64) error {
 		return fmt.Errorf("state for %v epoch not found", epoch)
 	}
 	sm.epochs[ind].blocks = nil
+	sm.epochs[ind].missing = nil
 	sm.epochs[ind] = sm.epochs[len(sm.epochs)-1]
 	sm.epochs = sm.epochs[:len(sm.epochs)-1]
 	return nil
@@ -207,6 +217,10 @@ func (s stateID) String() (state string) {
 		state = "scheduled"
 	case stateDataParsed:
 		state = "dataParsed"
+	case stateDataPartial:
+		state = "dataPartial"
+	case stateRescheduled:
+		state = "rescheduled"
 	case stateSkipped:
 		state = "skipped"
 	case stateSkippedExt:
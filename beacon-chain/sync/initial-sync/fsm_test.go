@@ -27,8 +27,10 @@ func TestStateMachine_Stringify(t *testing.T) {
 				{epoch: 12, state: stateSkippedExt},
 				{epoch: 13, state: stateComplete},
 				{epoch: 14, state: stateSent},
+				{epoch: 15, state: stateDataPartial},
+				{epoch: 16, state: stateRescheduled},
 			},
-			"[8:new 9:scheduled 10:dataParsed 11:skipped 12:skippedExt 13:complete 14:sent]",
+			"[8:new 9:scheduled 10:dataParsed 11:skipped 12:skippedExt 13:complete 14:sent 15:dataPartial 16:rescheduled]",
 		},
 	}
 	for _, tt := range tests {
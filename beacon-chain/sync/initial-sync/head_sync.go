@@ -0,0 +1,157 @@
+package initialsync
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/pkg/errors"
+	eth "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-ssz"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
+	blockfeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/block"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/flags"
+	p2ppb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/mathutil"
+	"github.com/sirupsen/logrus"
+)
+
+var errUnknownParent = errors.New("parent of the first block is not known")
+
+// Head sync catches up with the head of the chain when finality has stalled. In that case peers'
+// finalized checkpoints are not ahead of us, so round robin sync has nothing to target. Instead,
+// peers are selected by the head slot of their status, and the unfinalized portion of their chain
+// is range synced from our head, or from our finalized checkpoint if our head is on another fork.
+func (s *Service) syncToHead(ctx context.Context, genesis time.Time) error {
+	for {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		headSlot := s.chain.HeadSlot()
+		_, pids := s.p2p.Peers().BestNonFinalized(flags.RequiredSyncPeers(), helpers.SlotToEpoch(headSlot))
+		if len(pids) == 0 {
+			return nil
+		}
+		for _, pid := range pids {
+			if err := s.syncToPeerHead(ctx, genesis, pid); err != nil {
+				log.WithError(err).WithField("peer", pid.Pretty()).Debug("Could not sync to peer's head")
+				continue
+			}
+			break
+		}
+		// Stop if none of the peers could move our head forward.
+		if s.chain.HeadSlot() <= headSlot {
+			return errors.New("could not make progress syncing to the head of peers")
+		}
+	}
+}
+
+// syncToPeerHead range syncs blocks from the given peer, up to its advertised head slot.
+func (s *Service) syncToPeerHead(ctx context.Context, genesis time.Time, pid peer.ID) error {
+	peerChainState, err := s.p2p.Peers().ChainState(pid)
+	if err != nil {
+		return err
+	}
+	if peerChainState == nil {
+		return errors.New("peer has no chain state")
+	}
+	targetSlot := mathutil.Min(peerChainState.HeadSlot, helpers.SlotsSince(genesis))
+	finalizedSlot := helpers.StartSlot(s.chain.FinalizedCheckpt().Epoch)
+
+	log.WithFields(logrus.Fields{
+		"peer":       pid.Pretty(),
+		"headSlot":   s.chain.HeadSlot(),
+		"targetSlot": targetSlot,
+	}).Info("Syncing to the head of peer")
+
+	start := s.chain.HeadSlot() + 1
+	for start <= targetSlot {
+		req := &p2ppb.BeaconBlocksByRangeRequest{
+			StartSlot: start,
+			Count:     mathutil.Min(targetSlot-start+1, allowedBlocksPerSecond),
+			Step:      1,
+		}
+		blks, err := s.requestBlocks(ctx, req, pid)
+		if err != nil {
+			return err
+		}
+		if len(blks) == 0 {
+			// The whole range consists of skipped slots.
+			start += req.Count
+			continue
+		}
+		if err := s.verifyHeadSyncBlocks(ctx, req, blks); err != nil {
+			if err == errUnknownParent && start > finalizedSlot+1 {
+				// Our head is not an ancestor of the peer's chain, sync its chain from our finalized
+				// checkpoint instead, the fork is resolved by fork choice once the blocks are processed.
+				log.WithField("peer", pid.Pretty()).Debug("Peer is on another fork, syncing from finalized checkpoint")
+				start = finalizedSlot + 1
+				continue
+			}
+			s.p2p.Peers().IncrementBadResponses(pid)
+			return err
+		}
+		for _, blk := range blks {
+			if err := s.processHeadSyncBlock(ctx, blk); err != nil {
+				return err
+			}
+		}
+		start = blks[len(blks)-1].Block.Slot + 1
+	}
+	return nil
+}
+
+// verifyHeadSyncBlocks checks the blocks returned for a range request are within the requested range,
+// and that they form a single chain building on a block we already know.
+func (s *Service) verifyHeadSyncBlocks(ctx context.Context, req *p2ppb.BeaconBlocksByRangeRequest, blks []*eth.SignedBeaconBlock) error {
+	var prevSlot uint64
+	var prevRoot [32]byte
+	for i, blk := range blks {
+		if blk == nil || blk.Block == nil {
+			return errors.New("nil block in response")
+		}
+		if blk.Block.Slot < req.StartSlot || blk.Block.Slot >= req.StartSlot+req.Count {
+			return fmt.Errorf("block at slot %d is outside of the requested range", blk.Block.Slot)
+		}
+		if i == 0 {
+			parentRoot := bytesutil.ToBytes32(blk.Block.ParentRoot)
+			if !s.db.HasBlock(ctx, parentRoot) && !s.chain.HasInitSyncBlock(parentRoot) {
+				return errUnknownParent
+			}
+		} else {
+			if blk.Block.Slot <= prevSlot {
+				return fmt.Errorf("block at slot %d is not in ascending order", blk.Block.Slot)
+			}
+			if bytesutil.ToBytes32(blk.Block.ParentRoot) != prevRoot {
+				return fmt.Errorf("block at slot %d does not build on the previous block", blk.Block.Slot)
+			}
+		}
+		root, err := ssz.HashTreeRoot(blk.Block)
+		if err != nil {
+			return errors.Wrap(err, "could not compute block root")
+		}
+		prevSlot = blk.Block.Slot
+		prevRoot = root
+	}
+	return nil
+}
+
+// processHeadSyncBlock fully verifies and processes a block, running fork choice on it since
+// the unfinalized portion of the chain may contain forks.
+func (s *Service) processHeadSyncBlock(ctx context.Context, blk *eth.SignedBeaconBlock) error {
+	root, err := ssz.HashTreeRoot(blk.Block)
+	if err != nil {
+		return errors.Wrap(err, "could not compute block root")
+	}
+	if s.db.HasBlock(ctx, root) || s.chain.HasInitSyncBlock(root) {
+		return nil
+	}
+	s.blockNotifier.BlockFeed().Send(&feed.Event{
+		Type: blockfeed.ReceivedBlock,
+		Data: &blockfeed.ReceivedBlockData{SignedBlock: blk},
+	})
+	return s.chain.ReceiveBlockNoPubsub(ctx, blk)
+}
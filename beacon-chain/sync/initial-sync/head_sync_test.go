@@ -0,0 +1,108 @@
+package initialsync
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	eth "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-ssz"
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	dbtest "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
+	prysmsync "github.com/prysmaticlabs/prysm/beacon-chain/sync"
+	p2ppb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+)
+
+func TestVerifyHeadSyncBlocks(t *testing.T) {
+	beaconDB := dbtest.SetupDB(t)
+	defer dbtest.TeardownDB(t, beaconDB)
+	ctx := context.Background()
+
+	knownBlk := &eth.SignedBeaconBlock{Block: &eth.BeaconBlock{Slot: 10}}
+	if err := beaconDB.SaveBlock(ctx, knownBlk); err != nil {
+		t.Fatal(err)
+	}
+	knownRoot, err := ssz.HashTreeRoot(knownBlk.Block)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Build a chain on top of the known block, with a skipped slot at 13.
+	var chain []*eth.SignedBeaconBlock
+	parentRoot := knownRoot
+	for _, slot := range []uint64{11, 12, 14, 15} {
+		blk := &eth.SignedBeaconBlock{Block: &eth.BeaconBlock{Slot: slot, ParentRoot: parentRoot[:]}}
+		chain = append(chain, blk)
+		parentRoot, err = ssz.HashTreeRoot(blk.Block)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	fork := &eth.SignedBeaconBlock{Block: &eth.BeaconBlock{Slot: 14, ParentRoot: chain[0].Block.ParentRoot}}
+
+	s := &Service{
+		chain: &mock.ChainService{},
+		db:    beaconDB,
+	}
+	req := &p2ppb.BeaconBlocksByRangeRequest{StartSlot: 11, Count: 5, Step: 1}
+
+	tests := []struct {
+		name    string
+		blks    []*eth.SignedBeaconBlock
+		wantErr string
+	}{
+		{
+			name: "valid chain",
+			blks: chain,
+		},
+		{
+			name:    "unknown parent",
+			blks:    chain[1:],
+			wantErr: errUnknownParent.Error(),
+		},
+		{
+			name:    "broken parent linkage",
+			blks:    []*eth.SignedBeaconBlock{chain[0], chain[1], fork},
+			wantErr: "does not build on the previous block",
+		},
+		{
+			name:    "not ascending",
+			blks:    []*eth.SignedBeaconBlock{chain[0], chain[0]},
+			wantErr: "not in ascending order",
+		},
+		{
+			name:    "outside requested range",
+			blks:    append(chain, &eth.SignedBeaconBlock{Block: &eth.BeaconBlock{Slot: 16, ParentRoot: parentRoot[:]}}),
+			wantErr: "outside of the requested range",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := s.verifyHeadSyncBlocks(ctx, req, tt.blks)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, received %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestSyncMode(t *testing.T) {
+	s := NewInitialSync(&Config{})
+	if mode := s.SyncMode(); mode != prysmsync.SyncModeInitial {
+		t.Errorf("Wanted sync mode %s, received %s", prysmsync.SyncModeInitial, mode)
+	}
+	s.setSyncMode(prysmsync.SyncModeHead)
+	if mode := s.SyncMode(); mode != prysmsync.SyncModeHead {
+		t.Errorf("Wanted sync mode %s, received %s", prysmsync.SyncModeHead, mode)
+	}
+	s.synced = true
+	if mode := s.SyncMode(); mode != prysmsync.SyncModeSynced {
+		t.Errorf("Wanted sync mode %s, received %s", prysmsync.SyncModeSynced, mode)
+	}
+}
@@ -56,11 +56,15 @@ func (s *Service) roundRobinSync(genesis time.Time) error {
 	}
 
 	// Step 1 - Sync to end of finalized epoch.
-	for blk := range queue.fetchedBlocks {
-		s.logSyncStatus(genesis, blk.Block, counter)
-		if err := s.processBlock(ctx, blk); err != nil {
-			log.WithError(err).Info("Block is invalid")
-			continue
+	if featureconfig.Get().BatchBlockVerify {
+		s.processFetchedBlocksBatch(ctx, genesis, queue.fetchedBlocks, counter)
+	} else {
+		for blk := range queue.fetchedBlocks {
+			s.logSyncStatus(genesis, blk.Block, counter)
+			if err := s.processBlock(ctx, blk); err != nil {
+				log.WithError(err).Info("Block is invalid")
+				continue
+			}
 		}
 	}
 
@@ -202,14 +206,83 @@ func (s *Service) processBlock(ctx context.Context, blk *eth.SignedBeaconBlock)
 		Type: blockfeed.ReceivedBlock,
 		Data: &blockfeed.ReceivedBlockData{SignedBlock: blk},
 	})
+	return s.receiveBlock(ctx, blk)
+}
+
+// receiveBlock passes a block to the blockchain service, skipping its verification
+// if InitSyncNoVerify is enabled.
+func (s *Service) receiveBlock(ctx context.Context, blk *eth.SignedBeaconBlock) error {
 	if featureconfig.Get().InitSyncNoVerify {
-		if err := s.chain.ReceiveBlockNoVerify(ctx, blk); err != nil {
-			return err
+		return s.chain.ReceiveBlockNoVerify(ctx, blk)
+	}
+	return s.chain.ReceiveBlockNoPubsubForkchoice(ctx, blk)
+}
+
+// processFetchedBlocksBatch reads blocks from the queue until it is closed, grouping blocks that
+// are already available into batches of up to blockBatchSize blocks, so that their signatures can
+// be verified together.
+func (s *Service) processFetchedBlocksBatch(
+	ctx context.Context,
+	genesis time.Time,
+	fetchedBlocks <-chan *eth.SignedBeaconBlock,
+	counter *ratecounter.RateCounter,
+) {
+	for blk := range fetchedBlocks {
+		batch := []*eth.SignedBeaconBlock{blk}
+	drain:
+		for len(batch) < blockBatchSize {
+			select {
+			case blk, ok := <-fetchedBlocks:
+				if !ok {
+					break drain
+				}
+				batch = append(batch, blk)
+			default:
+				break drain
+			}
 		}
-	} else {
-		if err := s.chain.ReceiveBlockNoPubsubForkchoice(ctx, blk); err != nil {
-			return err
+		for _, blk := range batch {
+			s.logSyncStatus(genesis, blk.Block, counter)
+		}
+		s.processBatchedBlocks(ctx, batch)
+	}
+}
+
+// processBatchedBlocks processes a batch of consecutive blocks, verifying all of their signatures
+// at once. If the batch can not be processed as a whole, every block of the batch is processed and
+// verified on its own instead, so that only the invalid blocks are dropped. If InitSyncNoVerify is
+// enabled, there are no signatures to verify and blocks are processed one by one right away.
+func (s *Service) processBatchedBlocks(ctx context.Context, blks []*eth.SignedBeaconBlock) {
+	parentRoot := bytesutil.ToBytes32(blks[0].Block.ParentRoot)
+	if !s.db.HasBlock(ctx, parentRoot) && !s.chain.HasInitSyncBlock(parentRoot) {
+		log.WithField("slot", blks[0].Block.Slot).Info(
+			"Block batch is invalid: beacon node doesn't have the parent of its first block")
+		return
+	}
+	for _, blk := range blks {
+		s.blockNotifier.BlockFeed().Send(&feed.Event{
+			Type: blockfeed.ReceivedBlock,
+			Data: &blockfeed.ReceivedBlockData{SignedBlock: blk},
+		})
+	}
+	if !featureconfig.Get().InitSyncNoVerify {
+		err := s.chain.ReceiveBlockBatch(ctx, blks)
+		if err == nil {
+			return
+		}
+		log.WithError(err).WithFields(logrus.Fields{
+			"startSlot": blks[0].Block.Slot,
+			"endSlot":   blks[len(blks)-1].Block.Slot,
+		}).Debug("Could not process block batch, falling back to processing blocks one by one")
+	}
+	for _, blk := range blks {
+		parentRoot := bytesutil.ToBytes32(blk.Block.ParentRoot)
+		if !s.db.HasBlock(ctx, parentRoot) && !s.chain.HasInitSyncBlock(parentRoot) {
+			log.WithField("slot", blk.Block.Slot).Info("Block is invalid: beacon node doesn't have its parent")
+			continue
+		}
+		if err := s.receiveBlock(ctx, blk); err != nil {
+			log.WithError(err).Info("Block is invalid")
 		}
 	}
-	return nil
 }
@@ -21,6 +21,7 @@ import (
 	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
 	beaconsync "github.com/prysmaticlabs/prysm/beacon-chain/sync"
 	p2ppb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
+	"github.com/prysmaticlabs/prysm/shared/featureconfig"
 	"github.com/prysmaticlabs/prysm/shared/hashutil"
 	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/prysmaticlabs/prysm/shared/roughtime"
@@ -475,3 +476,86 @@ func TestMakeSequence(t *testing.T) {
 		t.Fatalf("Wanted %v, got %v", want, got)
 	}
 }
+
+func TestProcessBatchedBlocks(t *testing.T) {
+	beaconDB := dbtest.SetupDB(t)
+	defer dbtest.TeardownDB(t, beaconDB)
+
+	genesisBlk := &eth.SignedBeaconBlock{Block: &eth.BeaconBlock{Slot: 0}}
+	if err := beaconDB.SaveBlock(context.Background(), genesisBlk); err != nil {
+		t.Fatal(err)
+	}
+	genesisRoot, err := ssz.HashTreeRoot(genesisBlk.Block)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var batch []*eth.SignedBeaconBlock
+	parentRoot := genesisRoot
+	for i := uint64(1); i <= 3; i++ {
+		blk := &eth.SignedBeaconBlock{Block: &eth.BeaconBlock{Slot: i, ParentRoot: parentRoot[:]}}
+		batch = append(batch, blk)
+		parentRoot, err = ssz.HashTreeRoot(blk.Block)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	st, err := stateTrie.InitializeFromProto(&p2ppb.BeaconState{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	mc := &mock.ChainService{
+		State: st,
+		Root:  genesisRoot[:],
+		DB:    beaconDB,
+	}
+	s := &Service{
+		chain:         mc,
+		blockNotifier: mc.BlockNotifier(),
+		db:            beaconDB,
+	}
+
+	// A batch whose first block has an unknown parent is dropped as a whole.
+	s.processBatchedBlocks(context.Background(), batch[1:])
+	if len(mc.BlocksReceived) != 0 {
+		t.Errorf("Expected no blocks to be processed, received %d", len(mc.BlocksReceived))
+	}
+
+	s.processBatchedBlocks(context.Background(), batch)
+	if len(mc.BlocksReceived) != len(batch) {
+		t.Errorf("Wanted %d blocks processed, received %d", len(batch), len(mc.BlocksReceived))
+	}
+	if s.chain.HeadSlot() != 3 {
+		t.Errorf("Wanted head slot 3, received %d", s.chain.HeadSlot())
+	}
+}
+
+func TestProcessBatchedBlocks_InitSyncNoVerify(t *testing.T) {
+	featureconfig.Init(&featureconfig.Flags{InitSyncNoVerify: true})
+	defer featureconfig.Init(&featureconfig.Flags{})
+	beaconDB := dbtest.SetupDB(t)
+	defer dbtest.TeardownDB(t, beaconDB)
+
+	genesisBlk := &eth.SignedBeaconBlock{Block: &eth.BeaconBlock{Slot: 0}}
+	if err := beaconDB.SaveBlock(context.Background(), genesisBlk); err != nil {
+		t.Fatal(err)
+	}
+	genesisRoot, err := ssz.HashTreeRoot(genesisBlk.Block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	batch := []*eth.SignedBeaconBlock{{Block: &eth.BeaconBlock{Slot: 1, ParentRoot: genesisRoot[:]}}}
+
+	mc := &mock.ChainService{Root: genesisRoot[:], DB: beaconDB}
+	s := &Service{
+		chain:         mc,
+		blockNotifier: mc.BlockNotifier(),
+		db:            beaconDB,
+	}
+	// Blocks are received without verification, neither as a batch nor one by one with verification.
+	s.processBatchedBlocks(context.Background(), batch)
+	if len(mc.BlocksReceived) != 0 {
+		t.Errorf("Expected blocks to be received without verification, %d were verified", len(mc.BlocksReceived))
+	}
+}
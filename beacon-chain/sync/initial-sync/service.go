@@ -2,6 +2,7 @@ package initialsync
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"github.com/kevinms/leakybucket-go"
@@ -14,6 +15,7 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/db"
 	"github.com/prysmaticlabs/prysm/beacon-chain/flags"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
+	prysmsync "github.com/prysmaticlabs/prysm/beacon-chain/sync"
 	"github.com/prysmaticlabs/prysm/shared"
 	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/prysmaticlabs/prysm/shared/roughtime"
@@ -56,6 +58,8 @@ type Service struct {
 	stateNotifier     statefeed.Notifier
 	blockNotifier     blockfeed.Notifier
 	blocksRateLimiter *leakybucket.Collector
+	syncMode          string
+	syncModeLock      sync.RWMutex
 }
 
 // NewInitialSync configures the initial sync service responsible for bringing the node up to the
@@ -71,6 +75,7 @@ func NewInitialSync(cfg *Config) *Service {
 		stateNotifier:     cfg.StateNotifier,
 		blockNotifier:     cfg.BlockNotifier,
 		blocksRateLimiter: leakybucket.NewCollector(allowedBlocksPerSecond, allowedBlocksPerSecond, false /* deleteEmptyBuckets */),
+		syncMode:          prysmsync.SyncModeInitial,
 	}
 }
 
@@ -199,11 +204,47 @@ func (s *Service) Resync() error {
 	return nil
 }
 
-func (s *Service) waitForMinimumPeers() {
-	required := params.BeaconConfig().MaxPeersToSync
-	if flags.Get().MinimumSyncPeers < required {
-		required = flags.Get().MinimumSyncPeers
+// ResyncToHead allows a node to catch up with the head of its peers when it has fallen
+// behind while finality is stalled, by syncing the unfinalized portion of the chain.
+func (s *Service) ResyncToHead() error {
+	s.synced = false
+	s.setSyncMode(prysmsync.SyncModeHead)
+	defer func() {
+		s.setSyncMode(prysmsync.SyncModeInitial)
+		s.synced = true
+	}()
+	headState, err := s.chain.HeadState(s.ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not retrieve head state")
 	}
+	genesis := time.Unix(int64(headState.GenesisTime()), 0)
+
+	if err := s.syncToHead(s.ctx, genesis); err != nil {
+		log.WithError(err).Debug("Could not sync to the head of peers")
+	}
+	log.WithField("slot", s.chain.HeadSlot()).Info("Head sync attempt complete")
+
+	return nil
+}
+
+// SyncMode returns how the node is currently syncing with its peers.
+func (s *Service) SyncMode() string {
+	if !s.Syncing() {
+		return prysmsync.SyncModeSynced
+	}
+	s.syncModeLock.RLock()
+	defer s.syncModeLock.RUnlock()
+	return s.syncMode
+}
+
+func (s *Service) setSyncMode(mode string) {
+	s.syncModeLock.Lock()
+	defer s.syncModeLock.Unlock()
+	s.syncMode = mode
+}
+
+func (s *Service) waitForMinimumPeers() {
+	required := flags.RequiredSyncPeers()
 	for {
 		_, _, peers := s.p2p.Peers().BestFinalized(params.BeaconConfig().MaxPeersToSync, s.chain.FinalizedCheckpt().Epoch)
 		if len(peers) >= required {
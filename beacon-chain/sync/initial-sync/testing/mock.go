@@ -3,6 +3,7 @@ package testing
 // Sync defines a mock for the sync service.
 type Sync struct {
 	IsSyncing bool
+	Mode      string
 }
 
 // Syncing --
@@ -19,3 +20,13 @@ func (s *Sync) Status() error {
 func (s *Sync) Resync() error {
 	return nil
 }
+
+// ResyncToHead --
+func (s *Sync) ResyncToHead() error {
+	return nil
+}
+
+// SyncMode --
+func (s *Sync) SyncMode() string {
+	return s.Mode
+}
@@ -45,6 +45,12 @@ var (
 			Help: "Count the number of times a node resyncs.",
 		},
 	)
+	numberOfTimesHeadResyncedCounter = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "number_of_times_head_resynced",
+			Help: "Count the number of times a node resyncs to the head of its peers while finality is stalled.",
+		},
+	)
 	numberOfBlocksRecoveredFromAtt = promauto.NewCounter(
 		prometheus.CounterOpts{
 			Name: "beacon_blocks_recovered_from_attestation_total",
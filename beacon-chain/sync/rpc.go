@@ -8,6 +8,7 @@ import (
 	libp2pcore "github.com/libp2p/go-libp2p-core"
 	"github.com/libp2p/go-libp2p-core/network"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
+	"github.com/prysmaticlabs/prysm/beacon-chain/p2p/encoder"
 	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
 	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/prysmaticlabs/prysm/shared/roughtime"
@@ -64,9 +65,17 @@ func (r *Service) registerRPCHandlers() {
 	)
 }
 
-// registerRPC for a given topic with an expected protobuf message type.
-func (r *Service) registerRPC(topic string, base interface{}, handle rpcHandler) {
-	topic += r.p2p.Encoding().ProtocolSuffix()
+// registerRPC for a given topic with an expected protobuf message type. The handler is
+// registered once for every supported encoding, so that the encoding of each stream is
+// negotiated through its protocol ID.
+func (r *Service) registerRPC(baseTopic string, base interface{}, handle rpcHandler) {
+	for _, encoding := range r.p2p.EncodingRegistry().Encodings() {
+		r.registerRPCWithEncoding(baseTopic, encoding, base, handle)
+	}
+}
+
+func (r *Service) registerRPCWithEncoding(baseTopic string, encoding encoder.NetworkEncoding, base interface{}, handle rpcHandler) {
+	topic := baseTopic + encoding.ProtocolSuffix()
 	log := log.WithField("topic", topic)
 	r.p2p.SetStreamHandler(topic, func(stream network.Stream) {
 		ctx, cancel := context.WithTimeout(context.Background(), ttfbTimeout)
@@ -109,7 +118,7 @@ func (r *Service) registerRPC(topic string, base interface{}, handle rpcHandler)
 		t := reflect.TypeOf(base)
 		if t.Kind() == reflect.Ptr {
 			msg := reflect.New(t.Elem())
-			if err := r.p2p.Encoding().DecodeWithLength(stream, msg.Interface()); err != nil {
+			if err := encoding.DecodeWithLength(stream, msg.Interface()); err != nil {
 				// Debug logs for goodbye/status errors
 				if strings.Contains(topic, p2p.RPCGoodByeTopic) || strings.Contains(topic, p2p.RPCStatusTopic) {
 					log.WithError(err).Debug("Failed to decode goodbye stream message")
@@ -129,7 +138,7 @@ func (r *Service) registerRPC(topic string, base interface{}, handle rpcHandler)
 			}
 		} else {
 			msg := reflect.New(t)
-			if err := r.p2p.Encoding().DecodeWithLength(stream, msg.Interface()); err != nil {
+			if err := encoding.DecodeWithLength(stream, msg.Interface()); err != nil {
 				log.WithError(err).Warn("Failed to decode stream message")
 				traceutil.AnnotateError(span, err)
 				return
@@ -145,3 +154,13 @@ func (r *Service) registerRPC(topic string, base interface{}, handle rpcHandler)
 
 	})
 }
+
+// streamEncoding returns the encoding negotiated for the given stream through its
+// protocol ID, falling back to the configured network encoding.
+func streamEncoding(provider p2p.EncodingProvider, stream network.Stream) encoder.NetworkEncoding {
+	encoding, err := provider.EncodingRegistry().ForProtocol(string(stream.Protocol()))
+	if err != nil {
+		return provider.Encoding()
+	}
+	return encoding
+}
@@ -55,7 +55,7 @@ func (r *Service) beaconBlocksByRangeRPCHandler(ctx context.Context, msg interfa
 				}
 			}()
 		}
-		resp, err := r.generateErrorResponse(responseCodeInvalidRequest, rateLimitedError)
+		resp, err := r.generateErrorResponse(responseCodeInvalidRequest, rateLimitedError, streamEncoding(r.p2p, stream))
 		if err != nil {
 			log.WithError(err).Error("Failed to generate a response error")
 		} else {
@@ -70,7 +70,7 @@ func (r *Service) beaconBlocksByRangeRPCHandler(ctx context.Context, msg interfa
 
 	// TODO(3147): Update this with reasonable constraints.
 	if endSlot-startSlot > 1000 || m.Step == 0 {
-		resp, err := r.generateErrorResponse(responseCodeInvalidRequest, "invalid range or step")
+		resp, err := r.generateErrorResponse(responseCodeInvalidRequest, "invalid range or step", streamEncoding(r.p2p, stream))
 		if err != nil {
 			log.WithError(err).Error("Failed to generate a response error")
 		} else {
@@ -84,7 +84,7 @@ func (r *Service) beaconBlocksByRangeRPCHandler(ctx context.Context, msg interfa
 	}
 
 	var errResponse = func() {
-		resp, err := r.generateErrorResponse(responseCodeServerError, genericError)
+		resp, err := r.generateErrorResponse(responseCodeServerError, genericError, streamEncoding(r.p2p, stream))
 		if err != nil {
 			log.WithError(err).Error("Failed to generate a response error")
 		} else {
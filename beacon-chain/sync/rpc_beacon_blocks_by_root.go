@@ -62,7 +62,7 @@ func (r *Service) beaconBlocksRootRPCHandler(ctx context.Context, msg interface{
 		return errors.New("message is not type [][32]byte")
 	}
 	if len(blockRoots) == 0 {
-		resp, err := r.generateErrorResponse(responseCodeInvalidRequest, "no block roots provided in request")
+		resp, err := r.generateErrorResponse(responseCodeInvalidRequest, "no block roots provided in request", streamEncoding(r.p2p, stream))
 		if err != nil {
 			log.WithError(err).Error("Failed to generate a response error")
 		} else {
@@ -83,7 +83,7 @@ func (r *Service) beaconBlocksRootRPCHandler(ctx context.Context, msg interface{
 				}
 			}()
 		}
-		resp, err := r.generateErrorResponse(responseCodeInvalidRequest, rateLimitedError)
+		resp, err := r.generateErrorResponse(responseCodeInvalidRequest, rateLimitedError, streamEncoding(r.p2p, stream))
 		if err != nil {
 			log.WithError(err).Error("Failed to generate a response error")
 		} else {
@@ -100,7 +100,7 @@ func (r *Service) beaconBlocksRootRPCHandler(ctx context.Context, msg interface{
 		blk, err := r.db.Block(ctx, root)
 		if err != nil {
 			log.WithError(err).Error("Failed to fetch block")
-			resp, err := r.generateErrorResponse(responseCodeServerError, genericError)
+			resp, err := r.generateErrorResponse(responseCodeServerError, genericError, streamEncoding(r.p2p, stream))
 			if err != nil {
 				log.WithError(err).Error("Failed to generate a response error")
 			} else {
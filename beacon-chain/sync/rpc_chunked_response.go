@@ -15,7 +15,7 @@ import (
 // response_chunk ::= | <result> | <encoding-dependent-header> | <encoded-payload>
 func (r *Service) chunkWriter(stream libp2pcore.Stream, msg interface{}) error {
 	setStreamWriteDeadline(stream, defaultWriteDuration)
-	return WriteChunk(stream, r.p2p.Encoding(), msg)
+	return WriteChunk(stream, streamEncoding(r.p2p, stream), msg)
 }
 
 // WriteChunk object to stream.
@@ -42,7 +42,8 @@ func ReadChunkedBlock(stream libp2pcore.Stream, p2p p2p.P2P) (*eth.SignedBeaconB
 // provided message type.
 func readResponseChunk(stream libp2pcore.Stream, p2p p2p.P2P, to interface{}) error {
 	setStreamReadDeadline(stream, 10*time.Second)
-	code, errMsg, err := ReadStatusCode(stream, p2p.Encoding())
+	encoding := streamEncoding(p2p, stream)
+	code, errMsg, err := ReadStatusCode(stream, encoding)
 	if err != nil {
 		return err
 	}
@@ -50,5 +51,5 @@ func readResponseChunk(stream libp2pcore.Stream, p2p p2p.P2P, to interface{}) er
 	if code != 0 {
 		return errors.New(errMsg)
 	}
-	return p2p.Encoding().DecodeWithMaxLength(stream, to, maxChunkSize)
+	return encoding.DecodeWithMaxLength(stream, to, maxChunkSize)
 }
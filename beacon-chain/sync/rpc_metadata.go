@@ -25,7 +25,7 @@ func (r *Service) metaDataHandler(ctx context.Context, msg interface{}, stream l
 	if _, err := stream.Write([]byte{responseCodeSuccess}); err != nil {
 		return err
 	}
-	_, err := r.p2p.Encoding().EncodeWithLength(stream, r.p2p.Metadata())
+	_, err := streamEncoding(r.p2p, stream).EncodeWithLength(stream, r.p2p.Metadata())
 	return err
 }
 
@@ -45,7 +45,7 @@ func (r *Service) sendMetaDataRequest(ctx context.Context, id peer.ID) (*pb.Meta
 			log.WithError(err).Error("Failed to close stream")
 		}
 	}()
-	code, errMsg, err := ReadStatusCode(stream, r.p2p.Encoding())
+	code, errMsg, err := ReadStatusCode(stream, streamEncoding(r.p2p, stream))
 	if err != nil {
 		return nil, err
 	}
@@ -54,7 +54,7 @@ func (r *Service) sendMetaDataRequest(ctx context.Context, id peer.ID) (*pb.Meta
 		return nil, errors.New(errMsg)
 	}
 	msg := new(pb.MetaData)
-	if err := r.p2p.Encoding().DecodeWithLength(stream, msg); err != nil {
+	if err := streamEncoding(r.p2p, stream).DecodeWithLength(stream, msg); err != nil {
 		r.p2p.Peers().IncrementBadResponses(stream.Conn().RemotePeer())
 		return nil, err
 	}
@@ -45,7 +45,7 @@ func (r *Service) pingHandler(ctx context.Context, msg interface{}, stream libp2
 	if _, err := stream.Write([]byte{responseCodeSuccess}); err != nil {
 		return err
 	}
-	_, err = r.p2p.Encoding().EncodeWithLength(stream, r.p2p.MetadataSeq())
+	_, err = streamEncoding(r.p2p, stream).EncodeWithLength(stream, r.p2p.MetadataSeq())
 	return err
 }
 
@@ -59,7 +59,7 @@ func (r *Service) sendPingRequest(ctx context.Context, id peer.ID) error {
 		return err
 	}
 
-	code, errMsg, err := ReadStatusCode(stream, r.p2p.Encoding())
+	code, errMsg, err := ReadStatusCode(stream, streamEncoding(r.p2p, stream))
 	if err != nil {
 		return err
 	}
@@ -69,7 +69,7 @@ func (r *Service) sendPingRequest(ctx context.Context, id peer.ID) error {
 		return errors.New(errMsg)
 	}
 	msg := new(uint64)
-	if err := r.p2p.Encoding().DecodeWithLength(stream, msg); err != nil {
+	if err := streamEncoding(r.p2p, stream).DecodeWithLength(stream, msg); err != nil {
 		r.p2p.Peers().IncrementBadResponses(stream.Conn().RemotePeer())
 		return err
 	}
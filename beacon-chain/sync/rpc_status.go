@@ -10,6 +10,7 @@ import (
 	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/beacon-chain/flags"
 	"github.com/prysmaticlabs/prysm/beacon-chain/p2p"
 	pb "github.com/prysmaticlabs/prysm/proto/beacon/p2p/v1"
 	"github.com/prysmaticlabs/prysm/shared/params"
@@ -43,7 +44,8 @@ func (r *Service) maintainPeerStatuses() {
 }
 
 // resyncIfBehind checks periodically to see if we are in normal sync but have fallen behind our peers by more than an epoch,
-// in which case we attempt a resync using the initial sync method to catch up.
+// in which case we attempt a resync using the initial sync method to catch up. If our peers have not finalized beyond our
+// head, which happens when finality has stalled, we catch up with the head of our peers instead.
 func (r *Service) resyncIfBehind() {
 	// Run sixteen times per epoch.
 	interval := time.Duration(params.BeaconConfig().SecondsPerSlot*params.BeaconConfig().SlotsPerEpoch/16) * time.Second
@@ -63,6 +65,21 @@ func (r *Service) resyncIfBehind() {
 				if err := r.initialSync.Resync(); err != nil {
 					log.Errorf("Could not resync chain: %v", err)
 				}
+				return
+			}
+
+			headEpoch, pids := r.p2p.Peers().BestNonFinalized(flags.RequiredSyncPeers(), syncedEpoch)
+			if len(pids) > 0 && headEpoch > syncedEpoch {
+				log.WithFields(logrus.Fields{
+					"currentEpoch":   currentEpoch,
+					"syncedEpoch":    syncedEpoch,
+					"peersHeadEpoch": headEpoch,
+				}).Info("Fallen behind peers while finality is stalled; syncing to the head of peers to catch up")
+				numberOfTimesHeadResyncedCounter.Inc()
+				r.clearPendingSlots()
+				if err := r.initialSync.ResyncToHead(); err != nil {
+					log.WithError(err).Warn("Could not resync chain to head")
+				}
 			}
 		}
 	})
@@ -94,7 +111,7 @@ func (r *Service) sendRPCStatusRequest(ctx context.Context, id peer.ID) error {
 		return err
 	}
 
-	code, errMsg, err := ReadStatusCode(stream, r.p2p.Encoding())
+	code, errMsg, err := ReadStatusCode(stream, streamEncoding(r.p2p, stream))
 	if err != nil {
 		return err
 	}
@@ -105,7 +122,7 @@ func (r *Service) sendRPCStatusRequest(ctx context.Context, id peer.ID) error {
 	}
 
 	msg := &pb.Status{}
-	if err := r.p2p.Encoding().DecodeWithLength(stream, msg); err != nil {
+	if err := streamEncoding(r.p2p, stream).DecodeWithLength(stream, msg); err != nil {
 		return err
 	}
 	r.p2p.Peers().SetChainState(stream.Conn().RemotePeer(), msg)
@@ -152,7 +169,7 @@ func (r *Service) statusRPCHandler(ctx context.Context, msg interface{}, stream
 		log.WithField("peer", stream.Conn().RemotePeer()).Debug("Invalid fork version from peer")
 		r.p2p.Peers().IncrementBadResponses(stream.Conn().RemotePeer())
 		originalErr := err
-		resp, err := r.generateErrorResponse(responseCodeInvalidRequest, err.Error())
+		resp, err := r.generateErrorResponse(responseCodeInvalidRequest, err.Error(), streamEncoding(r.p2p, stream))
 		if err != nil {
 			log.WithError(err).Error("Failed to generate a response error")
 		} else {
@@ -194,7 +211,7 @@ func (r *Service) statusRPCHandler(ctx context.Context, msg interface{}, stream
 	if _, err := stream.Write([]byte{responseCodeSuccess}); err != nil {
 		log.WithError(err).Error("Failed to write to stream")
 	}
-	_, err = r.p2p.Encoding().EncodeWithLength(stream, resp)
+	_, err = streamEncoding(r.p2p, stream).EncodeWithLength(stream, resp)
 
 	return err
 }
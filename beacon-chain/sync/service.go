@@ -12,6 +12,7 @@ import (
 	"github.com/prysmaticlabs/prysm/beacon-chain/blockchain"
 	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
 	blockfeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/block"
+	gossipfeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/gossip"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed/operation"
 	statefeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/state"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
@@ -47,6 +48,7 @@ type Config struct {
 	StateNotifier       statefeed.Notifier
 	BlockNotifier       blockfeed.Notifier
 	AttestationNotifier operation.Notifier
+	GossipNotifier      gossipfeed.Notifier
 	StateSummaryCache   *cache.StateSummaryCache
 	StateGen            *stategen.State
 }
@@ -85,6 +87,7 @@ type Service struct {
 	blockNotifier             blockfeed.Notifier
 	blocksRateLimiter         *leakybucket.Collector
 	attestationNotifier       operation.Notifier
+	gossipNotifier            gossipfeed.Notifier
 	seenBlockLock             sync.RWMutex
 	seenBlockCache            *lru.Cache
 	seenAttestationLock       sync.RWMutex
@@ -113,6 +116,7 @@ func NewRegularSync(cfg *Config) *Service {
 		chain:                cfg.Chain,
 		initialSync:          cfg.InitialSync,
 		attestationNotifier:  cfg.AttestationNotifier,
+		gossipNotifier:       cfg.GossipNotifier,
 		slotToPendingBlocks:  make(map[uint64]*ethpb.SignedBeaconBlock),
 		seenPendingBlocks:    make(map[[32]byte]bool),
 		blkRootToPendingAtts: make(map[[32]byte][]*ethpb.SignedAggregateAttestationAndProof),
@@ -201,10 +205,23 @@ func (r *Service) initCaches() error {
 	return nil
 }
 
+// Sync modes reported by a Checker.
+const (
+	// SyncModeSynced is reported once the node follows the chain head through gossip.
+	SyncModeSynced = "synced"
+	// SyncModeInitial is reported while the node range syncs up to its peers' finalized checkpoint.
+	SyncModeInitial = "initial-sync"
+	// SyncModeHead is reported while the node range syncs the unfinalized portion of the chain
+	// up to its peers' head, which happens when finality has stalled.
+	SyncModeHead = "head-sync"
+)
+
 // Checker defines a struct which can verify whether a node is currently
 // synchronizing a chain with the rest of peers in the network.
 type Checker interface {
 	Syncing() bool
 	Status() error
 	Resync() error
+	ResyncToHead() error
+	SyncMode() string
 }
@@ -20,6 +20,7 @@ import (
 	"github.com/prysmaticlabs/prysm/shared/p2putils"
 	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/prysmaticlabs/prysm/shared/roughtime"
+	"github.com/prysmaticlabs/prysm/shared/sliceutil"
 	"github.com/prysmaticlabs/prysm/shared/slotutil"
 	"github.com/prysmaticlabs/prysm/shared/traceutil"
 	"go.opencensus.io/trace"
@@ -238,8 +239,8 @@ func (r *Service) subscribeDynamicWithSubnets(
 				if r.chainStarted && r.initialSync.Syncing() {
 					continue
 				}
-				// Update desired topic indices for aggregator
-				wantedSubs := r.aggregatorCommitteeIndices(currentSlot)
+				// Update desired topic indices for aggregator and the node's long lived subnets.
+				wantedSubs := sliceutil.UnionUint64(r.aggregatorCommitteeIndices(currentSlot), r.persistentCommitteeIndices())
 				// Resize as appropriate.
 				r.reValidateSubscriptions(subscriptions, wantedSubs, topicFormat)
 
@@ -253,6 +254,10 @@ func (r *Service) subscribeDynamicWithSubnets(
 				for _, idx := range attesterSubs {
 					r.lookupAttesterSubnets(digest, idx)
 				}
+				// maintain a minimum number of peers in our long lived subnets.
+				for _, idx := range r.persistentCommitteeIndices() {
+					r.maintainSubnetPeers(idx)
+				}
 			}
 		}
 	}()
@@ -375,6 +380,18 @@ func (r *Service) lookupAttesterSubnets(digest [4]byte, idx uint64) {
 	}
 }
 
+// search for more peers if we are below the minimum peer count of a subnet.
+func (r *Service) maintainSubnetPeers(idx uint64) {
+	if uint64(len(r.p2p.Peers().SubscribedToSubnet(idx))) >= params.BeaconNetworkConfig().MinimumPeersInSubnet {
+		return
+	}
+	go func(idx uint64) {
+		if _, err := r.p2p.FindPeersWithSubnet(idx); err != nil {
+			log.Errorf("Could not search for peers: %v", err)
+		}
+	}(idx)
+}
+
 // find if we have peers who are subscribed to the same subnet
 func (r *Service) validPeersExist(subnetTopic string, idx uint64) bool {
 	numOfPeers := r.p2p.PubSub().ListPeers(subnetTopic + r.p2p.Encoding().ProtocolSuffix())
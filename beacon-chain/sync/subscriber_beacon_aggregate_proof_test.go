@@ -8,6 +8,9 @@ import (
 	lru "github.com/hashicorp/golang-lru"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/prysmaticlabs/go-bitfield"
+	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed/operation"
 	"github.com/prysmaticlabs/prysm/beacon-chain/operations/attestations"
 )
 
@@ -19,6 +22,7 @@ func TestBeaconAggregateProofSubscriber_CanSave(t *testing.T) {
 	r := &Service{
 		attPool:              attestations.NewPool(),
 		seenAttestationCache: c,
+		attestationNotifier:  (&mock.ChainService{}).OperationNotifier(),
 	}
 
 	a := &ethpb.SignedAggregateAttestationAndProof{Message: &ethpb.AggregateAttestationAndProof{Aggregate: &ethpb.Attestation{Data: &ethpb.AttestationData{}, AggregationBits: bitfield.Bitlist{0x07}}, AggregatorIndex: 100}}
@@ -30,3 +34,35 @@ func TestBeaconAggregateProofSubscriber_CanSave(t *testing.T) {
 		t.Error("Did not save aggregated attestation")
 	}
 }
+
+func TestBeaconAggregateProofSubscriber_NotifiesAggregateReceived(t *testing.T) {
+	c, err := lru.New(10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := &Service{
+		attPool:              attestations.NewPool(),
+		seenAttestationCache: c,
+		attestationNotifier:  (&mock.ChainService{}).OperationNotifier(),
+	}
+	events := make(chan *feed.Event, 1)
+	sub := r.attestationNotifier.OperationFeed().Subscribe(events)
+	defer sub.Unsubscribe()
+
+	a := &ethpb.SignedAggregateAttestationAndProof{Message: &ethpb.AggregateAttestationAndProof{Aggregate: &ethpb.Attestation{Data: &ethpb.AttestationData{}, AggregationBits: bitfield.Bitlist{0x07}}, AggregatorIndex: 100}}
+	if err := r.beaconAggregateProofSubscriber(context.Background(), a); err != nil {
+		t.Fatal(err)
+	}
+
+	event := <-events
+	if event.Type != operation.AggregatedAttReceived {
+		t.Fatalf("Wanted event type %d, received %d", operation.AggregatedAttReceived, event.Type)
+	}
+	data, ok := event.Data.(*operation.AggregatedAttReceivedData)
+	if !ok {
+		t.Fatalf("Unexpected event data type %T", event.Data)
+	}
+	if !reflect.DeepEqual(data.Attestation, a.Message) {
+		t.Error("Did not send received aggregate and proof")
+	}
+}
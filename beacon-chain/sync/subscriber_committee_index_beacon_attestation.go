@@ -73,3 +73,7 @@ func (r *Service) attesterCommitteeIndices(currentSlot uint64) []uint64 {
 	}
 	return sliceutil.SetUint64(commIds)
 }
+
+func (r *Service) persistentCommitteeIndices() []uint64 {
+	return cache.CommitteeIDs.GetPersistentCommitteeIDs()
+}
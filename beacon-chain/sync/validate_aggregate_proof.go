@@ -9,6 +9,8 @@ import (
 	"github.com/pkg/errors"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
+	gossipfeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/gossip"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/state"
 	stateTrie "github.com/prysmaticlabs/prysm/beacon-chain/state"
@@ -52,6 +54,14 @@ func (r *Service) validateAggregateAndProof(ctx context.Context, pid peer.ID, ms
 	if m.Message == nil || m.Message.Aggregate == nil || m.Message.Aggregate.Data == nil {
 		return false
 	}
+	// Broadcast the aggregated attestation on the gossip feed before validating it against the chain,
+	// so that services in the beacon node watching gossip also see the aggregates rejected below.
+	r.gossipNotifier.GossipFeed().Send(&feed.Event{
+		Type: gossipfeed.AttReceived,
+		Data: &gossipfeed.AttReceivedData{
+			Attestation: m.Message.Aggregate,
+		},
+	})
 	// Verify this is the first aggregate received from the aggregator with index and slot.
 	if r.hasSeenAggregatorIndexSlot(m.Message.Aggregate.Data.Slot, m.Message.AggregatorIndex) {
 		return false
@@ -140,6 +140,7 @@ func TestValidateAggregateAndProof_NoBlock(t *testing.T) {
 		t.Fatal(err)
 	}
 	r := &Service{
+		gossipNotifier:       (&mock.ChainService{}).GossipNotifier(),
 		p2p:                  p,
 		db:                   db,
 		initialSync:          &mockSync.Sync{IsSyncing: false},
@@ -215,9 +216,10 @@ func TestValidateAggregateAndProof_NotWithinSlotRange(t *testing.T) {
 		t.Fatal(err)
 	}
 	r := &Service{
-		p2p:         p,
-		db:          db,
-		initialSync: &mockSync.Sync{IsSyncing: false},
+		gossipNotifier: (&mock.ChainService{}).GossipNotifier(),
+		p2p:            p,
+		db:             db,
+		initialSync:    &mockSync.Sync{IsSyncing: false},
 		chain: &mock.ChainService{Genesis: time.Now(),
 			State: beaconState},
 		attPool:              attestations.NewPool(),
@@ -305,10 +307,11 @@ func TestValidateAggregateAndProof_ExistedInPool(t *testing.T) {
 		t.Fatal(err)
 	}
 	r := &Service{
-		attPool:     attestations.NewPool(),
-		p2p:         p,
-		db:          db,
-		initialSync: &mockSync.Sync{IsSyncing: false},
+		gossipNotifier: (&mock.ChainService{}).GossipNotifier(),
+		attPool:        attestations.NewPool(),
+		p2p:            p,
+		db:             db,
+		initialSync:    &mockSync.Sync{IsSyncing: false},
 		chain: &mock.ChainService{Genesis: time.Now(),
 			State: beaconState},
 		seenAttestationCache: c,
@@ -433,9 +436,10 @@ func TestValidateAggregateAndProofWithNewStateMgmt_CanValidate(t *testing.T) {
 		t.Fatal(err)
 	}
 	r := &Service{
-		p2p:         p,
-		db:          db,
-		initialSync: &mockSync.Sync{IsSyncing: false},
+		gossipNotifier: (&mock.ChainService{}).GossipNotifier(),
+		p2p:            p,
+		db:             db,
+		initialSync:    &mockSync.Sync{IsSyncing: false},
 		chain: &mock.ChainService{Genesis: time.Now(),
 			State:            beaconState,
 			ValidAttestation: true,
@@ -559,9 +563,10 @@ func TestVerifyIndexInCommittee_SeenAggregatorSlot(t *testing.T) {
 		t.Fatal(err)
 	}
 	r := &Service{
-		p2p:         p,
-		db:          db,
-		initialSync: &mockSync.Sync{IsSyncing: false},
+		gossipNotifier: (&mock.ChainService{}).GossipNotifier(),
+		p2p:            p,
+		db:             db,
+		initialSync:    &mockSync.Sync{IsSyncing: false},
 		chain: &mock.ChainService{Genesis: time.Now(),
 			ValidatorsRoot:   [32]byte{'A'},
 			State:            beaconState,
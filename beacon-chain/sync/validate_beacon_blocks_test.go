@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/gogo/protobuf/proto"
 	lru "github.com/hashicorp/golang-lru"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	pubsubpb "github.com/libp2p/go-libp2p-pubsub/pb"
@@ -14,6 +15,8 @@ import (
 	"github.com/prysmaticlabs/go-ssz"
 	mock "github.com/prysmaticlabs/prysm/beacon-chain/blockchain/testing"
 	"github.com/prysmaticlabs/prysm/beacon-chain/cache"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/feed"
+	gossipfeed "github.com/prysmaticlabs/prysm/beacon-chain/core/feed/gossip"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
 	dbtest "github.com/prysmaticlabs/prysm/beacon-chain/db/testing"
 	"github.com/prysmaticlabs/prysm/beacon-chain/operations/attestations"
@@ -52,9 +55,10 @@ func TestValidateBeaconBlockPubSub_InvalidSignature(t *testing.T) {
 		t.Fatal(err)
 	}
 	r := &Service{
-		db:          db,
-		p2p:         p,
-		initialSync: &mockSync.Sync{IsSyncing: false},
+		gossipNotifier: (&mock.ChainService{}).GossipNotifier(),
+		db:             db,
+		p2p:            p,
+		initialSync:    &mockSync.Sync{IsSyncing: false},
 		chain: &mock.ChainService{Genesis: time.Now(),
 			FinalizedCheckPoint: &ethpb.Checkpoint{
 				Epoch: 0,
@@ -81,6 +85,71 @@ func TestValidateBeaconBlockPubSub_InvalidSignature(t *testing.T) {
 	}
 }
 
+func TestValidateBeaconBlockPubSub_NotifiesRejectedGossipBlock(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.SetupDB(t)
+	defer dbtest.TeardownDB(t, db)
+	msg := &ethpb.SignedBeaconBlock{
+		Block: &ethpb.BeaconBlock{
+			Slot:          1,
+			ProposerIndex: 7,
+			ParentRoot:    testutil.Random32Bytes(t),
+		},
+		Signature: bytesutil.PadTo([]byte("second"), 96),
+	}
+
+	p := p2ptest.NewTestP2P(t)
+
+	c, err := lru.New(10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := &Service{
+		gossipNotifier: (&mock.ChainService{}).GossipNotifier(),
+		db:             db,
+		p2p:            p,
+		initialSync:    &mockSync.Sync{IsSyncing: false},
+		chain: &mock.ChainService{Genesis: time.Now(),
+			FinalizedCheckPoint: &ethpb.Checkpoint{
+				Epoch: 0,
+			}},
+		seenBlockCache: c,
+	}
+	// A block of the proposer for the slot was already received.
+	r.setSeenBlockIndexSlot(msg.Block.Slot, msg.Block.ProposerIndex)
+	events := make(chan *feed.Event, 1)
+	sub := r.gossipNotifier.GossipFeed().Subscribe(events)
+	defer sub.Unsubscribe()
+
+	buf := new(bytes.Buffer)
+	if _, err := p.Encoding().Encode(buf, msg); err != nil {
+		t.Fatal(err)
+	}
+	m := &pubsub.Message{
+		Message: &pubsubpb.Message{
+			Data: buf.Bytes(),
+			TopicIDs: []string{
+				p2p.GossipTypeMapping[reflect.TypeOf(msg)],
+			},
+		},
+	}
+	if r.validateBeaconBlockPubSub(ctx, "", m) {
+		t.Error("Expected false result, got true")
+	}
+
+	event := <-events
+	if event.Type != gossipfeed.BlockReceived {
+		t.Fatalf("Wanted event type %d, received %d", gossipfeed.BlockReceived, event.Type)
+	}
+	data, ok := event.Data.(*gossipfeed.BlockReceivedData)
+	if !ok {
+		t.Fatalf("Unexpected event data type %T", event.Data)
+	}
+	if !proto.Equal(data.SignedBlock, msg) {
+		t.Error("Did not send the rejected gossip block")
+	}
+}
+
 func TestValidateBeaconBlockPubSub_BlockAlreadyPresentInDB(t *testing.T) {
 	db := dbtest.SetupDB(t)
 	defer dbtest.TeardownDB(t, db)
@@ -102,6 +171,7 @@ func TestValidateBeaconBlockPubSub_BlockAlreadyPresentInDB(t *testing.T) {
 		t.Fatal(err)
 	}
 	r := &Service{
+		gossipNotifier:    (&mock.ChainService{}).GossipNotifier(),
 		db:                db,
 		p2p:               p,
 		initialSync:       &mockSync.Sync{IsSyncing: false},
@@ -187,9 +257,10 @@ func TestValidateBeaconBlockPubSub_ValidProposerSignature(t *testing.T) {
 	stateSummaryCache := cache.NewStateSummaryCache()
 	stateGen := stategen.New(db, stateSummaryCache)
 	r := &Service{
-		db:          db,
-		p2p:         p,
-		initialSync: &mockSync.Sync{IsSyncing: false},
+		gossipNotifier: (&mock.ChainService{}).GossipNotifier(),
+		db:             db,
+		p2p:            p,
+		initialSync:    &mockSync.Sync{IsSyncing: false},
 		chain: &mock.ChainService{Genesis: time.Unix(time.Now().Unix()-int64(params.BeaconConfig().SecondsPerSlot), 0),
 			State: beaconState,
 			FinalizedCheckPoint: &ethpb.Checkpoint{
@@ -243,9 +314,10 @@ func TestValidateBeaconBlockPubSub_Syncing(t *testing.T) {
 	}
 
 	r := &Service{
-		db:          db,
-		p2p:         p,
-		initialSync: &mockSync.Sync{IsSyncing: true},
+		gossipNotifier: (&mock.ChainService{}).GossipNotifier(),
+		db:             db,
+		p2p:            p,
+		initialSync:    &mockSync.Sync{IsSyncing: true},
 		chain: &mock.ChainService{
 			Genesis: time.Now(),
 			FinalizedCheckPoint: &ethpb.Checkpoint{
@@ -295,6 +367,7 @@ func TestValidateBeaconBlockPubSub_RejectBlocksFromFuture(t *testing.T) {
 		t.Fatal(err)
 	}
 	r := &Service{
+		gossipNotifier:      (&mock.ChainService{}).GossipNotifier(),
 		p2p:                 p,
 		db:                  db,
 		initialSync:         &mockSync.Sync{IsSyncing: false},
@@ -347,9 +420,10 @@ func TestValidateBeaconBlockPubSub_RejectBlocksFromThePast(t *testing.T) {
 		t.Fatal(err)
 	}
 	r := &Service{
-		db:          db,
-		p2p:         p,
-		initialSync: &mockSync.Sync{IsSyncing: false},
+		gossipNotifier: (&mock.ChainService{}).GossipNotifier(),
+		db:             db,
+		p2p:            p,
+		initialSync:    &mockSync.Sync{IsSyncing: false},
 		chain: &mock.ChainService{
 			Genesis: time.Unix(genesisTime.Unix()-1000, 0),
 			FinalizedCheckPoint: &ethpb.Checkpoint{
@@ -428,9 +502,10 @@ func TestValidateBeaconBlockPubSub_SeenProposerSlot(t *testing.T) {
 		t.Fatal(err)
 	}
 	r := &Service{
-		db:          db,
-		p2p:         p,
-		initialSync: &mockSync.Sync{IsSyncing: false},
+		gossipNotifier: (&mock.ChainService{}).GossipNotifier(),
+		db:             db,
+		p2p:            p,
+		initialSync:    &mockSync.Sync{IsSyncing: false},
 		chain: &mock.ChainService{Genesis: time.Unix(time.Now().Unix()-int64(params.BeaconConfig().SecondsPerSlot), 0),
 			State: beaconState,
 			FinalizedCheckPoint: &ethpb.Checkpoint{
@@ -489,6 +564,7 @@ func TestValidateBeaconBlockPubSub_FilterByFinalizedEpoch(t *testing.T) {
 		p2p:            p,
 		chain:          chain,
 		blockNotifier:  chain.BlockNotifier(),
+		gossipNotifier: chain.GossipNotifier(),
 		attPool:        attestations.NewPool(),
 		seenBlockCache: c,
 		initialSync:    &mockSync.Sync{IsSyncing: false},
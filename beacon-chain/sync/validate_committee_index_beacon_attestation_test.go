@@ -39,6 +39,7 @@ func TestService_validateCommitteeIndexBeaconAttestation(t *testing.T) {
 		t.Fatal(err)
 	}
 	s := &Service{
+		gossipNotifier:       (&mockChain.ChainService{}).GossipNotifier(),
 		initialSync:          &mockSync.Sync{IsSyncing: false},
 		p2p:                  p,
 		db:                   db,
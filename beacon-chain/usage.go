@@ -93,6 +93,7 @@ var appHelpFlagGroups = []flagGroup{
 			flags.UnsafeSync,
 			flags.SlotsPerArchivedPoint,
 			flags.DisableDiscv5,
+			flags.WeakSubjectivityCheckpt,
 		},
 	},
 	{
@@ -105,9 +106,14 @@ var appHelpFlagGroups = []flagGroup{
 			cmd.P2PPrivKey,
 			cmd.P2PMetadata,
 			cmd.P2PWhitelist,
+			cmd.P2PMaxPeersPerIP,
+			cmd.P2PMaxPeersPerSubnet,
+			cmd.P2POutboundPeerRatio,
+			cmd.P2PDenyList,
 			cmd.StaticPeers,
 			cmd.EnableUPnPFlag,
 			cmd.P2PEncoding,
+			cmd.P2PRPCEncodings,
 			cmd.P2PPubsub,
 			flags.MinSyncPeers,
 		},
@@ -0,0 +1,97 @@
+package bls
+
+import (
+	"fmt"
+
+	bls12 "github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/prysmaticlabs/prysm/shared/featureconfig"
+)
+
+// SignatureSet refers to the defined set of signatures and its respective public keys and
+// messages required to verify it.
+type SignatureSet struct {
+	Signatures []*Signature
+	PublicKeys []*PublicKey
+	Messages   [][32]byte
+}
+
+// NewSet constructs an empty signature set object.
+func NewSet() *SignatureSet {
+	return &SignatureSet{
+		Signatures: []*Signature{},
+		PublicKeys: []*PublicKey{},
+		Messages:   [][32]byte{},
+	}
+}
+
+// Join merges the provided signature set to our current one.
+func (s *SignatureSet) Join(set *SignatureSet) *SignatureSet {
+	if set == nil {
+		return s
+	}
+	s.Signatures = append(s.Signatures, set.Signatures...)
+	s.PublicKeys = append(s.PublicKeys, set.PublicKeys...)
+	s.Messages = append(s.Messages, set.Messages...)
+	return s
+}
+
+// Verify the current signature set using the batch verification algorithm.
+func (s *SignatureSet) Verify() (bool, error) {
+	return VerifyMultipleSignatures(s.Signatures, s.Messages, s.PublicKeys)
+}
+
+// VerifyMultipleSignatures verifies a non-singular set of signatures and its respective pubkeys and messages.
+// Each signature and public key pair is scaled by a randomly chosen scalar before all of them are
+// combined, so that a single aggregate verification covers the whole set. This random linear combination
+// prevents an invalid signature from being cancelled out by a crafted signature elsewhere in the set.
+func VerifyMultipleSignatures(sigs []*Signature, msgs [][32]byte, pubKeys []*PublicKey) (bool, error) {
+	if featureconfig.Get().SkipBLSVerify {
+		return true, nil
+	}
+	if len(sigs) == 0 || len(pubKeys) == 0 {
+		return false, nil
+	}
+	if len(sigs) != len(msgs) || len(msgs) != len(pubKeys) {
+		return false, fmt.Errorf(
+			"provided signatures, pubkeys and messages have differing lengths. S: %d, P: %d, M: %d",
+			len(sigs),
+			len(pubKeys),
+			len(msgs),
+		)
+	}
+
+	aggSig := new(bls12.G2)
+	aggSig.Clear()
+	// Scaled public keys sharing the same message are added together, as herumi's aggregate
+	// verification requires all of its messages to be distinct.
+	msgIndices := make(map[[32]byte]int, len(msgs))
+	scaledKeys := make([]bls12.G1, 0, len(msgs))
+	distinctMsgs := make([]byte, 0, len(msgs)*32)
+	for i := 0; i < len(sigs); i++ {
+		if sigs[i] == nil || pubKeys[i] == nil {
+			return false, fmt.Errorf("nil signature or public key at index %d", i)
+		}
+		var r bls12.Fr
+		r.SetByCSPRNG()
+
+		var scaledSig bls12.G2
+		bls12.G2Mul(&scaledSig, bls12.CastFromSign(sigs[i].s), &r)
+		bls12.G2Add(aggSig, aggSig, &scaledSig)
+
+		var scaledKey bls12.G1
+		bls12.G1Mul(&scaledKey, bls12.CastFromPublicKey(pubKeys[i].p), &r)
+		if idx, ok := msgIndices[msgs[i]]; ok {
+			bls12.G1Add(&scaledKeys[idx], &scaledKeys[idx], &scaledKey)
+			continue
+		}
+		msgIndices[msgs[i]] = len(scaledKeys)
+		scaledKeys = append(scaledKeys, scaledKey)
+		distinctMsgs = append(distinctMsgs, msgs[i][:]...)
+	}
+
+	rawKeys := make([]bls12.PublicKey, len(scaledKeys))
+	for i := 0; i < len(scaledKeys); i++ {
+		rawKeys[i] = *bls12.CastToPublicKey(&scaledKeys[i])
+	}
+	return bls12.CastToSign(aggSig).AggregateVerify(rawKeys, distinctMsgs), nil
+}
@@ -0,0 +1,86 @@
+package bls_test
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/shared/bls"
+)
+
+func TestVerifyMultipleSignatures(t *testing.T) {
+	set := bls.NewSet()
+	for i := 0; i < 10; i++ {
+		msg := [32]byte{'h', 'e', 'l', 'l', 'o', byte(i)}
+		priv := bls.RandKey()
+		set.Join(&bls.SignatureSet{
+			Signatures: []*bls.Signature{priv.Sign(msg[:])},
+			PublicKeys: []*bls.PublicKey{priv.PublicKey()},
+			Messages:   [][32]byte{msg},
+		})
+	}
+	verified, err := set.Verify()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !verified {
+		t.Error("Signature set did not verify")
+	}
+}
+
+func TestVerifyMultipleSignatures_SharedMessages(t *testing.T) {
+	var sigs []*bls.Signature
+	var pubkeys []*bls.PublicKey
+	var msgs [][32]byte
+	for i := 0; i < 10; i++ {
+		msg := [32]byte{'h', 'e', 'l', 'l', 'o', byte(i % 3)}
+		priv := bls.RandKey()
+		sigs = append(sigs, priv.Sign(msg[:]))
+		pubkeys = append(pubkeys, priv.PublicKey())
+		msgs = append(msgs, msg)
+	}
+	verified, err := bls.VerifyMultipleSignatures(sigs, msgs, pubkeys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !verified {
+		t.Error("Signatures did not verify")
+	}
+}
+
+func TestVerifyMultipleSignatures_InvalidSignature(t *testing.T) {
+	var sigs []*bls.Signature
+	var pubkeys []*bls.PublicKey
+	var msgs [][32]byte
+	for i := 0; i < 10; i++ {
+		msg := [32]byte{'h', 'e', 'l', 'l', 'o', byte(i)}
+		priv := bls.RandKey()
+		sigs = append(sigs, priv.Sign(msg[:]))
+		pubkeys = append(pubkeys, priv.PublicKey())
+		msgs = append(msgs, msg)
+	}
+	// Swap two signatures, each one is still valid on its own but not for its paired message.
+	sigs[2], sigs[7] = sigs[7], sigs[2]
+	verified, err := bls.VerifyMultipleSignatures(sigs, msgs, pubkeys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if verified {
+		t.Error("Expected swapped signatures to fail verification")
+	}
+}
+
+func TestVerifyMultipleSignatures_DifferingLengths(t *testing.T) {
+	priv := bls.RandKey()
+	msg := [32]byte{'h', 'e', 'l', 'l', 'o'}
+	sigs := []*bls.Signature{priv.Sign(msg[:])}
+	pubkeys := []*bls.PublicKey{priv.PublicKey(), priv.PublicKey()}
+	if _, err := bls.VerifyMultipleSignatures(sigs, [][32]byte{msg}, pubkeys); err == nil {
+		t.Error("Expected error with differing lengths")
+	}
+	verified, err := bls.VerifyMultipleSignatures(nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if verified {
+		t.Error("Expected empty set to not verify")
+	}
+}
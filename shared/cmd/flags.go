@@ -125,12 +125,44 @@ var (
 			"would whitelist connections to peers on your local network only. The default " +
 			"is to accept all connections.",
 	}
+	// P2PMaxPeersPerIP defines a flag to limit the number of peers sharing the same ip address.
+	P2PMaxPeersPerIP = &cli.Uint64Flag{
+		Name:  "p2p-max-peers-per-ip",
+		Usage: "The max number of p2p peers allowed from the same ip address. A value of 0 disables the limit.",
+		Value: 3,
+	}
+	// P2PMaxPeersPerSubnet defines a flag to limit the number of peers in the same /24 network.
+	P2PMaxPeersPerSubnet = &cli.Uint64Flag{
+		Name:  "p2p-max-peers-per-subnet",
+		Usage: "The max number of p2p peers allowed from the same /24 (ipv4) or /64 (ipv6) network. A value of 0 disables the limit.",
+		Value: 10,
+	}
+	// P2POutboundPeerRatio defines a flag for the share of peer slots reserved for outbound connections.
+	P2POutboundPeerRatio = &cli.Float64Flag{
+		Name:  "p2p-outbound-peer-ratio",
+		Usage: "The share of p2p-max-peers reserved for peers dialed by this node, in the range [0, 1].",
+		Value: 0.2,
+	}
+	// P2PDenyList defines a list of CIDR subnets to refuse connections from.
+	P2PDenyList = &cli.StringSliceFlag{
+		Name: "p2p-denylist",
+		Usage: "The CIDR subnets for denying peer connections. Example: 192.168.0.0/16 " +
+			"would refuse connections with peers on your local network. Multiple subnets may be " +
+			"provided by repeating the flag.",
+	}
 	// P2PEncoding defines the encoding format for p2p messages.
 	P2PEncoding = &cli.StringFlag{
 		Name:  "p2p-encoding",
 		Usage: "The encoding format of messages sent over the wire. The default is 0, which represents ssz",
 		Value: "ssz-snappy",
 	}
+	// P2PRPCEncodings defines the encodings accepted on p2p req/resp streams.
+	P2PRPCEncodings = &cli.StringSliceFlag{
+		Name: "p2p-rpc-encodings",
+		Usage: "The encodings accepted on req/resp streams in addition to the one set by --p2p-encoding, " +
+			"negotiated with each peer per stream. Supported values are: ssz, ssz-snappy",
+		Value: cli.NewStringSlice("ssz-snappy", "ssz"),
+	}
 	// P2PPubsub defines the pubsub router to use for p2p messages.
 	P2PPubsub = &cli.StringFlag{
 		Name:  "p2p-pubsub",
@@ -53,6 +53,8 @@ type Flags struct {
 	NoInitSyncBatchSaveBlocks                  bool // NoInitSyncBatchSaveBlocks disables batch save blocks mode during initial syncing.
 	EnableStateRefCopy                         bool // EnableStateRefCopy copies the references to objects instead of the objects themselves when copying state fields.
 	WaitForSynced                              bool // WaitForSynced uses WaitForSynced in validator startup to ensure it can communicate with the beacon node as soon as possible.
+	EnableProposerBoost                        bool // EnableProposerBoost boosts the fork choice weight of timely blocks of the current slot.
+	BatchBlockVerify                           bool // BatchBlockVerify verifies the signatures of initial sync blocks in batches.
 	// DisableForkChoice disables using LMD-GHOST fork choice to update
 	// the head of the chain based on attestations and instead accepts any valid received block
 	// as the chain head. UNSAFE, use with caution.
@@ -243,6 +245,14 @@ func ConfigureBeaconChain(ctx *cli.Context) {
 		log.Warn("Enabling broadcast slashing to p2p network")
 		cfg.BroadcastSlashings = true
 	}
+	if ctx.Bool(enableProposerBoostFlag.Name) {
+		log.Warn("Enabling proposer boost in fork choice")
+		cfg.EnableProposerBoost = true
+	}
+	if ctx.Bool(batchBlockVerifyFlag.Name) {
+		log.Warn("Enabling batch verification of initial sync block signatures")
+		cfg.BatchBlockVerify = true
+	}
 	Init(cfg)
 }
 
@@ -146,6 +146,16 @@ var (
 		Name:  "enable-state-ref-copy",
 		Usage: "Enables the usage of a new copying method for our state fields.",
 	}
+	enableProposerBoostFlag = &cli.BoolFlag{
+		Name: "enable-proposer-boost",
+		Usage: "Boosts the fork choice weight of a block received on time during its slot, which " +
+			"mitigates balancing and ex ante reorg attacks.",
+	}
+	batchBlockVerifyFlag = &cli.BoolFlag{
+		Name: "batch-block-verify",
+		Usage: "Verifies the signatures of blocks received during initial sync as batches, falling back to " +
+			"verifying blocks one by one if a batch is invalid.",
+	}
 	waitForSyncedFlag = &cli.BoolFlag{
 		Name:  "wait-for-synced",
 		Usage: "Uses WaitForSynced for validator startup, to ensure a validator is able to communicate with the beacon node as quick as possible",
@@ -398,6 +408,8 @@ var BeaconChainFlags = append(deprecatedFlags, []cli.Flag{
 	disableInitSyncBatchSaveBlocks,
 	enableStateRefCopy,
 	waitForSyncedFlag,
+	enableProposerBoostFlag,
+	batchBlockVerifyFlag,
 }...)
 
 // E2EBeaconChainFlags contains a list of the beacon chain feature flags to be tested in E2E.
@@ -53,6 +53,8 @@ type BeaconChainConfig struct {
 	MinEpochsToInactivityPenalty     uint64 `yaml:"MIN_EPOCHS_TO_INACTIVITY_PENALTY"`    // MinEpochsToInactivityPenalty defines the minimum amount of epochs since finality to begin penalizing inactivity.
 	Eth1FollowDistance               uint64 // Eth1FollowDistance is the number of eth1.0 blocks to wait before considering a new deposit for voting. This only applies after the chain as been started.
 	SafeSlotsToUpdateJustified       uint64 // SafeSlotsToUpdateJustified is the minimal slots needed to update justified check point.
+	ProposerScoreBoost               uint64 // ProposerScoreBoost is the percentage of a committee's weight added to a timely block of the current slot in fork choice.
+	IntervalsPerSlot                 uint64 // IntervalsPerSlot is the number of intervals a slot is split into, a block is timely if it arrives within the first one.
 	SecondsPerETH1Block              uint64 `yaml:"SECONDS_PER_ETH1_BLOCK"` // SecondsPerETH1Block is the approximate time for a single eth1 block to be produced.
 	// State list lengths
 	EpochsPerHistoricalVector uint64 `yaml:"EPOCHS_PER_HISTORICAL_VECTOR"` // EpochsPerHistoricalVector defines max length in epoch to store old historical stats in beacon state.
@@ -154,6 +156,8 @@ var defaultBeaconConfig = &BeaconChainConfig{
 	MinEpochsToInactivityPenalty:     4,
 	Eth1FollowDistance:               1024,
 	SafeSlotsToUpdateJustified:       8,
+	ProposerScoreBoost:               70,
+	IntervalsPerSlot:                 3,
 	SecondsPerETH1Block:              14,
 
 	// State list length constants.
@@ -4,13 +4,16 @@ import "time"
 
 // NetworkConfig defines the spec based network parameters.
 type NetworkConfig struct {
-	GossipMaxSize                   uint64        `yaml:"GOSSIP_MAX_SIZE"`                    // GossipMaxSize is the maximum allowed size of uncompressed gossip messages.
-	MaxChunkSize                    uint64        `yaml:"MAX_CHUNK_SIZE"`                     // MaxChunkSize is the the maximum allowed size of uncompressed req/resp chunked responses.
-	AttestationSubnetCount          uint64        `yaml:"ATTESTATION_SUBNET_COUNT"`           // AttestationSubnetCount is the number of attestation subnets used in the gossipsub protocol.
-	AttestationPropagationSlotRange uint64        `yaml:"ATTESTATION_PROPAGATION_SLOT_RANGE"` // AttestationPropagationSlotRange is the maximum number of slots during which an attestation can be propagated.
-	TtfbTimeout                     time.Duration `yaml:"TTFB_TIMEOUT"`                       // TtfbTimeout is the maximum time to wait for first byte of request response (time-to-first-byte).
-	RespTimeout                     time.Duration `yaml:"RESP_TIMEOUT"`                       // RespTimeout is the maximum time for complete response transfer.
-	MaximumGossipClockDisparity     time.Duration `yaml:"MAXIMUM_GOSSIP_CLOCK_DISPARITY"`     // MaximumGossipClockDisparity is the maximum milliseconds of clock disparity assumed between honest nodes.
+	GossipMaxSize                     uint64        `yaml:"GOSSIP_MAX_SIZE"`                       // GossipMaxSize is the maximum allowed size of uncompressed gossip messages.
+	MaxChunkSize                      uint64        `yaml:"MAX_CHUNK_SIZE"`                        // MaxChunkSize is the the maximum allowed size of uncompressed req/resp chunked responses.
+	AttestationSubnetCount            uint64        `yaml:"ATTESTATION_SUBNET_COUNT"`              // AttestationSubnetCount is the number of attestation subnets used in the gossipsub protocol.
+	AttestationPropagationSlotRange   uint64        `yaml:"ATTESTATION_PROPAGATION_SLOT_RANGE"`    // AttestationPropagationSlotRange is the maximum number of slots during which an attestation can be propagated.
+	TtfbTimeout                       time.Duration `yaml:"TTFB_TIMEOUT"`                          // TtfbTimeout is the maximum time to wait for first byte of request response (time-to-first-byte).
+	RespTimeout                       time.Duration `yaml:"RESP_TIMEOUT"`                          // RespTimeout is the maximum time for complete response transfer.
+	MaximumGossipClockDisparity       time.Duration `yaml:"MAXIMUM_GOSSIP_CLOCK_DISPARITY"`        // MaximumGossipClockDisparity is the maximum milliseconds of clock disparity assumed between honest nodes.
+	RandomSubnetsPerNode              uint64        `yaml:"RANDOM_SUBNETS_PER_NODE"`               // RandomSubnetsPerNode is the number of long lived attestation subnets a node subscribes to.
+	EpochsPerRandomSubnetSubscription uint64        `yaml:"EPOCHS_PER_RANDOM_SUBNET_SUBSCRIPTION"` // EpochsPerRandomSubnetSubscription is the minimum number of epochs before a long lived subnet subscription is rotated.
+	MinimumPeersInSubnet              uint64        `yaml:"MINIMUM_PEERS_IN_SUBNET"`               // MinimumPeersInSubnet is the number of peers the node tries to maintain for each subscribed subnet.
 
 	// DiscoveryV5 Config
 	ETH2Key      string // ETH2Key is the ENR key of the eth2 object in an enr.
@@ -18,15 +21,18 @@ type NetworkConfig struct {
 }
 
 var defaultNetworkConfig = &NetworkConfig{
-	GossipMaxSize:                   1 << 20, // 1 MiB
-	MaxChunkSize:                    1 << 20, // 1 MiB
-	AttestationSubnetCount:          64,
-	AttestationPropagationSlotRange: 32,
-	TtfbTimeout:                     5 * time.Second,
-	RespTimeout:                     10 * time.Second,
-	MaximumGossipClockDisparity:     500 * time.Millisecond,
-	ETH2Key:                         "eth2",
-	AttSubnetKey:                    "attnets",
+	GossipMaxSize:                     1 << 20, // 1 MiB
+	MaxChunkSize:                      1 << 20, // 1 MiB
+	AttestationSubnetCount:            64,
+	AttestationPropagationSlotRange:   32,
+	TtfbTimeout:                       5 * time.Second,
+	RespTimeout:                       10 * time.Second,
+	MaximumGossipClockDisparity:       500 * time.Millisecond,
+	RandomSubnetsPerNode:              2,
+	EpochsPerRandomSubnetSubscription: 256,
+	MinimumPeersInSubnet:              2,
+	ETH2Key:                           "eth2",
+	AttSubnetKey:                      "attnets",
 }
 
 // BeaconNetworkConfig returns the current network config for
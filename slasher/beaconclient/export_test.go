@@ -0,0 +1,26 @@
+package beaconclient
+
+import (
+	"context"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+)
+
+// Hooks into the service for tests of the beaconclient_test package, which import
+// slasher packages depending on beaconclient.
+
+func (bs *Service) SetBeaconClient(client ethpb.BeaconChainClient) {
+	bs.beaconClient = client
+}
+
+func (bs *Service) SubscribeDetectedAttesterSlashings(ctx context.Context, ch chan *ethpb.AttesterSlashing) {
+	bs.subscribeDetectedAttesterSlashings(ctx, ch)
+}
+
+func (bs *Service) SubscribeDetectedProposerSlashings(ctx context.Context, ch chan *ethpb.ProposerSlashing) {
+	bs.subscribeDetectedProposerSlashings(ctx, ch)
+}
+
+func (bs *Service) ResubmitPendingSlashings(ctx context.Context, headEpoch uint64) error {
+	return bs.resubmitPendingSlashings(ctx, headEpoch)
+}
@@ -0,0 +1,395 @@
+package beaconclient
+
+import (
+	"context"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/shared/hashutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/sliceutil"
+	"github.com/prysmaticlabs/prysm/slasher/db/types"
+	"github.com/sirupsen/logrus"
+	"go.opencensus.io/trace"
+)
+
+// defaultSlashingResubmitEpochs is the number of epochs after which slashings which were
+// not included on chain are submitted again, if no number is configured.
+const defaultSlashingResubmitEpochs = 4
+
+// headInclusion is a detected slashing included in a block streamed from a beacon
+// node, which may still be reverted until the block is finalized.
+type headInclusion struct {
+	slot             uint64
+	attesterSlashing *ethpb.AttesterSlashing
+	proposerSlashing *ethpb.ProposerSlashing
+}
+
+// trackSlashingInclusion checks the chain head of the beacon node once per epoch,
+// updating the status of detected slashings included in newly finalized blocks and
+// re-submitting the slashings which are still pending.
+func (bs *Service) trackSlashingInclusion(ctx context.Context) {
+	secondsPerEpoch := params.BeaconConfig().SecondsPerSlot * params.BeaconConfig().SlotsPerEpoch
+	ticker := time.NewTicker(time.Duration(secondsPerEpoch) * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := bs.checkSlashingInclusion(ctx); err != nil {
+				log.WithError(err).Error("Could not check inclusion of detected slashings")
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// checkSlashingInclusion marks detected slashings included in blocks finalized since the
+// last check as included, and slashings only included in blocks which were not finalized
+// as reverted. Pending slashings are re-submitted every resubmitEpochs epochs.
+func (bs *Service) checkSlashingInclusion(ctx context.Context) error {
+	ctx, span := trace.StartSpan(ctx, "beaconclient.checkSlashingInclusion")
+	defer span.End()
+	head, err := bs.ChainHead(ctx)
+	if err != nil {
+		return err
+	}
+	if head.FinalizedEpoch > 0 {
+		if bs.lastFinalizedSlot == 0 {
+			// On start, blocks are checked as far back as slashings are re-submitted.
+			lookback := bs.resubmitEpochs
+			if lookback > head.FinalizedEpoch {
+				lookback = head.FinalizedEpoch
+			}
+			bs.lastFinalizedSlot = helpers.StartSlot(head.FinalizedEpoch - lookback)
+		}
+		if head.FinalizedSlot > bs.lastFinalizedSlot {
+			if err := bs.processFinalizedBlocks(ctx, head.FinalizedBlockRoot, head.FinalizedSlot); err != nil {
+				return errors.Wrap(err, "could not process finalized blocks")
+			}
+			bs.lastFinalizedSlot = head.FinalizedSlot
+		}
+	}
+	if err := bs.resubmitPendingSlashings(ctx, head.HeadEpoch); err != nil {
+		return errors.Wrap(err, "could not re-submit pending slashings")
+	}
+	return bs.updateSlashingStatusMetrics(ctx)
+}
+
+// processFinalizedBlocks walks the canonical chain back from the finalized block root to the
+// last processed finalized slot and marks the detected slashings included in its blocks, as
+// well as the pending slashings of validators slashed by other slashings in its blocks.
+func (bs *Service) processFinalizedBlocks(ctx context.Context, finalizedRoot []byte, finalizedSlot uint64) error {
+	included := make(map[[32]byte]bool)
+	slashed := make(map[uint64]bool)
+	root := finalizedRoot
+	for len(root) > 0 {
+		res, err := bs.beaconClient.ListBlocks(ctx, &ethpb.ListBlocksRequest{
+			QueryFilter: &ethpb.ListBlocksRequest_Root{Root: root},
+		})
+		if err != nil {
+			return errors.Wrapf(err, "could not retrieve block %#x", root)
+		}
+		if len(res.BlockContainers) == 0 {
+			break
+		}
+		blk := res.BlockContainers[0].Block
+		if blk == nil || blk.Block == nil || blk.Block.Slot <= bs.lastFinalizedSlot {
+			break
+		}
+		for _, slashing := range blk.Block.Body.AttesterSlashings {
+			r, err := bs.markAttesterSlashingIncluded(ctx, slashing, blk.Block.Slot)
+			if err != nil {
+				return err
+			}
+			included[r] = true
+			for _, idx := range attesterSlashingIndices(slashing) {
+				slashed[idx] = true
+			}
+		}
+		for _, slashing := range blk.Block.Body.ProposerSlashings {
+			r, err := bs.markProposerSlashingIncluded(ctx, slashing, blk.Block.Slot)
+			if err != nil {
+				return err
+			}
+			included[r] = true
+			slashed[slashing.Header_1.Header.ProposerIndex] = true
+		}
+		root = blk.Block.ParentRoot
+	}
+	if err := bs.markSupersededSlashings(ctx, slashed, included); err != nil {
+		return errors.Wrap(err, "could not mark superseded slashings")
+	}
+	return bs.revertHeadInclusions(ctx, included, finalizedSlot)
+}
+
+// markSupersededSlashings marks pending slashings as included if every validator they slash
+// was slashed by the slashings of finalized blocks, which may have been submitted by another
+// source or for another offense. Such slashings can no longer be included, as a validator is
+// only slashed once. The roots of the marked slashings are added to the included roots.
+func (bs *Service) markSupersededSlashings(ctx context.Context, slashed map[uint64]bool, included map[[32]byte]bool) error {
+	if len(slashed) == 0 {
+		return nil
+	}
+	allSlashed := func(indices []uint64) bool {
+		for _, idx := range indices {
+			if !slashed[idx] {
+				return false
+			}
+		}
+		return len(indices) > 0
+	}
+	for _, status := range []types.SlashingStatus{types.Active, types.Reverted} {
+		attesterSlashings, err := bs.slasherDB.AttesterSlashings(ctx, status)
+		if err != nil {
+			return err
+		}
+		for _, slashing := range attesterSlashings {
+			if !allSlashed(attesterSlashingIndices(slashing)) {
+				continue
+			}
+			root, err := bs.markAttesterSlashingSuperseded(ctx, slashing)
+			if err != nil {
+				return err
+			}
+			included[root] = true
+		}
+		proposerSlashings, err := bs.slasherDB.ProposalSlashingsByStatus(ctx, status)
+		if err != nil {
+			return err
+		}
+		for _, slashing := range proposerSlashings {
+			if !allSlashed([]uint64{slashing.Header_1.Header.ProposerIndex}) {
+				continue
+			}
+			root, err := bs.markProposerSlashingSuperseded(ctx, slashing)
+			if err != nil {
+				return err
+			}
+			included[root] = true
+		}
+	}
+	return nil
+}
+
+// markAttesterSlashingSuperseded marks a pending attester slashing of validators which are
+// already slashed as included, so it is no longer submitted.
+func (bs *Service) markAttesterSlashingSuperseded(ctx context.Context, slashing *ethpb.AttesterSlashing) ([32]byte, error) {
+	root, err := hashutil.HashProto(slashing)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	if err := bs.slasherDB.SaveAttesterSlashing(ctx, types.Included, slashing); err != nil {
+		return [32]byte{}, errors.Wrap(err, "could not update attester slashing status")
+	}
+	bs.untrackSubmission(root)
+	slashingsIncluded.WithLabelValues(attesterSlashingType).Inc()
+	log.WithField("indices", attesterSlashingIndices(slashing)).Info("Validators of attester slashing were already slashed")
+	return root, nil
+}
+
+// markProposerSlashingSuperseded marks a pending proposer slashing of a validator which is
+// already slashed as included, so it is no longer submitted.
+func (bs *Service) markProposerSlashingSuperseded(ctx context.Context, slashing *ethpb.ProposerSlashing) ([32]byte, error) {
+	root, err := hashutil.HashProto(slashing)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	if err := bs.slasherDB.SaveProposerSlashing(ctx, types.Included, slashing); err != nil {
+		return [32]byte{}, errors.Wrap(err, "could not update proposer slashing status")
+	}
+	bs.untrackSubmission(root)
+	slashingsIncluded.WithLabelValues(proposerSlashingType).Inc()
+	log.WithField("proposerIndex", slashing.Header_1.Header.ProposerIndex).Info("Validator of proposer slashing was already slashed")
+	return root, nil
+}
+
+// validatorsSlashed returns true if all the given validators are slashed in the head state
+// of the beacon node.
+func (bs *Service) validatorsSlashed(ctx context.Context, indices []uint64) (bool, error) {
+	if len(indices) == 0 {
+		return false, nil
+	}
+	res, err := bs.beaconClient.ListValidators(ctx, &ethpb.ListValidatorsRequest{Indices: indices})
+	if err != nil {
+		return false, errors.Wrapf(err, "could not request validators %v", indices)
+	}
+	if len(res.ValidatorList) != len(indices) {
+		return false, nil
+	}
+	for _, v := range res.ValidatorList {
+		if v.Validator == nil || !v.Validator.Slashed {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// attesterSlashingIndices returns the indices of the validators slashed by an attester slashing.
+func attesterSlashingIndices(slashing *ethpb.AttesterSlashing) []uint64 {
+	if slashing.Attestation_1 == nil || slashing.Attestation_2 == nil {
+		return nil
+	}
+	return sliceutil.IntersectionUint64(slashing.Attestation_1.AttestingIndices, slashing.Attestation_2.AttestingIndices)
+}
+
+// revertHeadInclusions marks detected slashings as reverted, if the blocks they were included
+// in are at or before the finalized slot but not part of the finalized chain, and submits
+// them again.
+func (bs *Service) revertHeadInclusions(ctx context.Context, included map[[32]byte]bool, finalizedSlot uint64) error {
+	bs.headInclusionsLock.Lock()
+	var reverted []*headInclusion
+	for root, inclusion := range bs.headInclusions {
+		if inclusion.slot > finalizedSlot {
+			continue
+		}
+		if !included[root] {
+			reverted = append(reverted, inclusion)
+		}
+		delete(bs.headInclusions, root)
+	}
+	bs.headInclusionsLock.Unlock()
+
+	for _, inclusion := range reverted {
+		if inclusion.attesterSlashing != nil {
+			if err := bs.slasherDB.SaveAttesterSlashing(ctx, types.Reverted, inclusion.attesterSlashing); err != nil {
+				return errors.Wrap(err, "could not update attester slashing status")
+			}
+			slashingsReverted.WithLabelValues(attesterSlashingType).Inc()
+			log.WithField("slot", inclusion.slot).Warn("Attester slashing was reverted, submitting it again")
+			bs.submitAttesterSlashing(ctx, inclusion.attesterSlashing)
+		}
+		if inclusion.proposerSlashing != nil {
+			if err := bs.slasherDB.SaveProposerSlashing(ctx, types.Reverted, inclusion.proposerSlashing); err != nil {
+				return errors.Wrap(err, "could not update proposer slashing status")
+			}
+			slashingsReverted.WithLabelValues(proposerSlashingType).Inc()
+			log.WithField("slot", inclusion.slot).Warn("Proposer slashing was reverted, submitting it again")
+			bs.submitProposerSlashing(ctx, inclusion.proposerSlashing)
+		}
+	}
+	return nil
+}
+
+// markAttesterSlashingIncluded marks an attester slashing included in a finalized block as
+// included, if it was detected by the slasher. It returns the root the slashing is stored by.
+func (bs *Service) markAttesterSlashingIncluded(ctx context.Context, slashing *ethpb.AttesterSlashing, slot uint64) ([32]byte, error) {
+	root, err := hashutil.HashProto(slashing)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	found, status, err := bs.slasherDB.HasAttesterSlashing(ctx, slashing)
+	if err != nil {
+		return [32]byte{}, errors.Wrap(err, "could not retrieve attester slashing")
+	}
+	if !found || status == types.Included {
+		return root, nil
+	}
+	if err := bs.slasherDB.SaveAttesterSlashing(ctx, types.Included, slashing); err != nil {
+		return [32]byte{}, errors.Wrap(err, "could not update attester slashing status")
+	}
+	bs.untrackSubmission(root)
+	slashingsIncluded.WithLabelValues(attesterSlashingType).Inc()
+	log.WithFields(logrus.Fields{
+		"slot":    slot,
+		"indices": slashing.Attestation_1.AttestingIndices,
+	}).Info("Attester slashing was included in a finalized block")
+	return root, nil
+}
+
+// markProposerSlashingIncluded marks a proposer slashing included in a finalized block as
+// included, if it was detected by the slasher. It returns the root the slashing is stored by.
+func (bs *Service) markProposerSlashingIncluded(ctx context.Context, slashing *ethpb.ProposerSlashing, slot uint64) ([32]byte, error) {
+	root, err := hashutil.HashProto(slashing)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	found, status, err := bs.slasherDB.HasProposerSlashing(ctx, slashing)
+	if err != nil {
+		return [32]byte{}, errors.Wrap(err, "could not retrieve proposer slashing")
+	}
+	if !found || status == types.Included {
+		return root, nil
+	}
+	if err := bs.slasherDB.SaveProposerSlashing(ctx, types.Included, slashing); err != nil {
+		return [32]byte{}, errors.Wrap(err, "could not update proposer slashing status")
+	}
+	bs.untrackSubmission(root)
+	slashingsIncluded.WithLabelValues(proposerSlashingType).Inc()
+	log.WithFields(logrus.Fields{
+		"slot":          slot,
+		"proposerIndex": slashing.Header_1.Header.ProposerIndex,
+	}).Info("Proposer slashing was included in a finalized block")
+	return root, nil
+}
+
+// recordHeadInclusions records the pending detected slashings included in a block received
+// from a beacon node, which are not re-submitted while waiting for the block to be finalized.
+func (bs *Service) recordHeadInclusions(ctx context.Context, blk *ethpb.SignedBeaconBlock) {
+	body := blk.Block.Body
+	if body == nil || (len(body.AttesterSlashings) == 0 && len(body.ProposerSlashings) == 0) {
+		return
+	}
+	for _, slashing := range body.AttesterSlashings {
+		found, status, err := bs.slasherDB.HasAttesterSlashing(ctx, slashing)
+		if err != nil {
+			log.WithError(err).Error("Could not retrieve attester slashing")
+			continue
+		}
+		if !found || status == types.Included {
+			continue
+		}
+		bs.recordHeadInclusion(slashing, &headInclusion{slot: blk.Block.Slot, attesterSlashing: slashing})
+	}
+	for _, slashing := range body.ProposerSlashings {
+		found, status, err := bs.slasherDB.HasProposerSlashing(ctx, slashing)
+		if err != nil {
+			log.WithError(err).Error("Could not retrieve proposer slashing")
+			continue
+		}
+		if !found || status == types.Included {
+			continue
+		}
+		bs.recordHeadInclusion(slashing, &headInclusion{slot: blk.Block.Slot, proposerSlashing: slashing})
+	}
+}
+
+func (bs *Service) recordHeadInclusion(slashing proto.Message, inclusion *headInclusion) {
+	root, err := hashutil.HashProto(slashing)
+	if err != nil {
+		log.WithError(err).Error("Could not hash slashing")
+		return
+	}
+	bs.headInclusionsLock.Lock()
+	defer bs.headInclusionsLock.Unlock()
+	bs.headInclusions[root] = inclusion
+}
+
+// includedInHeadBlock returns true if the slashing with the given root was included in a
+// block received from a beacon node which is not finalized yet.
+func (bs *Service) includedInHeadBlock(root [32]byte) bool {
+	bs.headInclusionsLock.RLock()
+	defer bs.headInclusionsLock.RUnlock()
+	_, ok := bs.headInclusions[root]
+	return ok
+}
+
+// updateSlashingStatusMetrics sets the number of detected slashings by type and status.
+func (bs *Service) updateSlashingStatusMetrics(ctx context.Context) error {
+	for _, status := range []types.SlashingStatus{types.Active, types.Included, types.Reverted} {
+		attesterSlashings, err := bs.slasherDB.AttesterSlashings(ctx, status)
+		if err != nil {
+			return err
+		}
+		slashingsByStatus.WithLabelValues(attesterSlashingType, status.String()).Set(float64(len(attesterSlashings)))
+		proposerSlashings, err := bs.slasherDB.ProposalSlashingsByStatus(ctx, status)
+		if err != nil {
+			return err
+		}
+		slashingsByStatus.WithLabelValues(proposerSlashingType, status.String()).Set(float64(len(proposerSlashings)))
+	}
+	return nil
+}
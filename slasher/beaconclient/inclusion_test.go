@@ -0,0 +1,233 @@
+package beaconclient
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/hashutil"
+	"github.com/prysmaticlabs/prysm/shared/mock"
+	testDB "github.com/prysmaticlabs/prysm/slasher/db/testing"
+	"github.com/prysmaticlabs/prysm/slasher/db/types"
+)
+
+func attesterSlashingForTest(index uint64) *ethpb.AttesterSlashing {
+	att := func(sig byte) *ethpb.IndexedAttestation {
+		return &ethpb.IndexedAttestation{
+			AttestingIndices: []uint64{index},
+			Data: &ethpb.AttestationData{
+				Source: &ethpb.Checkpoint{Epoch: 1},
+				Target: &ethpb.Checkpoint{Epoch: 2},
+			},
+			Signature: []byte{sig},
+		}
+	}
+	return &ethpb.AttesterSlashing{Attestation_1: att(1), Attestation_2: att(2)}
+}
+
+func TestService_ProcessFinalizedBlocks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client := mock.NewMockBeaconChainClient(ctrl)
+	db := testDB.SetupSlasherDB(t, false)
+	defer testDB.TeardownSlasherDB(t, db)
+	ctx := context.Background()
+
+	included := attesterSlashingForTest(1)
+	reverted := attesterSlashingForTest(2)
+	if err := db.SaveAttesterSlashings(ctx, types.Active, []*ethpb.AttesterSlashing{included, reverted}); err != nil {
+		t.Fatal(err)
+	}
+	revertedRoot, err := hashutil.HashProto(reverted)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bs := &Service{
+		beaconClient:      client,
+		slasherDB:         db,
+		lastFinalizedSlot: 2,
+		headInclusions: map[[32]byte]*headInclusion{
+			revertedRoot: {slot: 5, attesterSlashing: reverted},
+		},
+	}
+
+	finalizedRoot := []byte("finalized")
+	parentRoot := []byte("parent")
+	client.EXPECT().ListBlocks(gomock.Any(), &ethpb.ListBlocksRequest{
+		QueryFilter: &ethpb.ListBlocksRequest_Root{Root: finalizedRoot},
+	}).Return(&ethpb.ListBlocksResponse{
+		BlockContainers: []*ethpb.BeaconBlockContainer{{
+			Block: &ethpb.SignedBeaconBlock{Block: &ethpb.BeaconBlock{
+				Slot:       10,
+				ParentRoot: parentRoot,
+				Body:       &ethpb.BeaconBlockBody{AttesterSlashings: []*ethpb.AttesterSlashing{included}},
+			}},
+		}},
+	}, nil)
+	client.EXPECT().ListBlocks(gomock.Any(), &ethpb.ListBlocksRequest{
+		QueryFilter: &ethpb.ListBlocksRequest_Root{Root: parentRoot},
+	}).Return(&ethpb.ListBlocksResponse{
+		BlockContainers: []*ethpb.BeaconBlockContainer{{
+			Block: &ethpb.SignedBeaconBlock{Block: &ethpb.BeaconBlock{Slot: 2, Body: &ethpb.BeaconBlockBody{}}},
+		}},
+	}, nil)
+	client.EXPECT().SubmitAttesterSlashing(gomock.Any(), reverted)
+
+	if err := bs.processFinalizedBlocks(ctx, finalizedRoot, 10); err != nil {
+		t.Fatal(err)
+	}
+	if _, status, err := db.HasAttesterSlashing(ctx, included); err != nil || status != types.Included {
+		t.Errorf("Expected status %s, received %s: %v", types.SlashingStatus(types.Included), status, err)
+	}
+	if _, status, err := db.HasAttesterSlashing(ctx, reverted); err != nil || status != types.Reverted {
+		t.Errorf("Expected status %s, received %s: %v", types.SlashingStatus(types.Reverted), status, err)
+	}
+	if len(bs.headInclusions) != 0 {
+		t.Errorf("Expected finalized head inclusions to be removed, %d left", len(bs.headInclusions))
+	}
+}
+
+func TestService_ResubmitPendingSlashings(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client := mock.NewMockBeaconChainClient(ctrl)
+	db := testDB.SetupSlasherDB(t, false)
+	defer testDB.TeardownSlasherDB(t, db)
+	ctx := context.Background()
+
+	pending := attesterSlashingForTest(1)
+	inHeadBlock := attesterSlashingForTest(2)
+	// Saved by a slashable check of a validator, never submitted by the service.
+	notSubmitted := attesterSlashingForTest(4)
+	if err := db.SaveAttesterSlashings(ctx, types.Active, []*ethpb.AttesterSlashing{pending, inHeadBlock, notSubmitted}); err != nil {
+		t.Fatal(err)
+	}
+	pendingRoot, err := hashutil.HashProto(pending)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveAttesterSlashing(ctx, types.Included, attesterSlashingForTest(3)); err != nil {
+		t.Fatal(err)
+	}
+	inHeadBlockRoot, err := hashutil.HashProto(inHeadBlock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bs := &Service{
+		beaconClient:    client,
+		slasherDB:       db,
+		resubmitEpochs:  2,
+		submittedEpochs: make(map[[32]byte]uint64),
+		submittedSlashings: map[[32]byte]bool{
+			pendingRoot:     true,
+			inHeadBlockRoot: true,
+		},
+		headInclusions: map[[32]byte]*headInclusion{
+			inHeadBlockRoot: {slot: 5, attesterSlashing: inHeadBlock},
+		},
+	}
+
+	client.EXPECT().ListValidators(gomock.Any(), &ethpb.ListValidatorsRequest{Indices: []uint64{1}}).Return(&ethpb.Validators{
+		ValidatorList: []*ethpb.Validators_ValidatorContainer{{Index: 1, Validator: &ethpb.Validator{}}},
+	}, nil)
+	// Only the pending slashing submitted by the service is submitted again, once resubmitEpochs
+	// passed since it was first seen.
+	client.EXPECT().SubmitAttesterSlashing(gomock.Any(), pending).Times(1)
+	for epoch := uint64(10); epoch <= 13; epoch++ {
+		if err := bs.resubmitPendingSlashings(ctx, epoch); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(bs.submittedEpochs) != 1 {
+		t.Errorf("Expected 1 tracked slashing, received %d", len(bs.submittedEpochs))
+	}
+}
+
+func TestService_ProcessFinalizedBlocks_MarksSupersededSlashings(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client := mock.NewMockBeaconChainClient(ctrl)
+	db := testDB.SetupSlasherDB(t, false)
+	defer testDB.TeardownSlasherDB(t, db)
+	ctx := context.Background()
+
+	superseded := attesterSlashingForTest(1)
+	pending := attesterSlashingForTest(2)
+	if err := db.SaveAttesterSlashings(ctx, types.Active, []*ethpb.AttesterSlashing{superseded, pending}); err != nil {
+		t.Fatal(err)
+	}
+	// An equivalent slashing of validator 1 from another source is included instead of ours.
+	other := attesterSlashingForTest(1)
+	other.Attestation_1.Signature = []byte{3}
+	bs := &Service{
+		beaconClient:      client,
+		slasherDB:         db,
+		lastFinalizedSlot: 2,
+		headInclusions:    make(map[[32]byte]*headInclusion),
+	}
+
+	finalizedRoot := []byte("finalized")
+	client.EXPECT().ListBlocks(gomock.Any(), &ethpb.ListBlocksRequest{
+		QueryFilter: &ethpb.ListBlocksRequest_Root{Root: finalizedRoot},
+	}).Return(&ethpb.ListBlocksResponse{
+		BlockContainers: []*ethpb.BeaconBlockContainer{{
+			Block: &ethpb.SignedBeaconBlock{Block: &ethpb.BeaconBlock{
+				Slot: 10,
+				Body: &ethpb.BeaconBlockBody{AttesterSlashings: []*ethpb.AttesterSlashing{other}},
+			}},
+		}},
+	}, nil)
+
+	if err := bs.processFinalizedBlocks(ctx, finalizedRoot, 10); err != nil {
+		t.Fatal(err)
+	}
+	if _, status, err := db.HasAttesterSlashing(ctx, superseded); err != nil || status != types.Included {
+		t.Errorf("Expected status %s, received %s: %v", types.SlashingStatus(types.Included), status, err)
+	}
+	if _, status, err := db.HasAttesterSlashing(ctx, pending); err != nil || status != types.Active {
+		t.Errorf("Expected status %s, received %s: %v", types.SlashingStatus(types.Active), status, err)
+	}
+}
+
+func TestService_ResubmitPendingSlashings_ValidatorAlreadySlashed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client := mock.NewMockBeaconChainClient(ctrl)
+	db := testDB.SetupSlasherDB(t, false)
+	defer testDB.TeardownSlasherDB(t, db)
+	ctx := context.Background()
+
+	slashing := attesterSlashingForTest(1)
+	if err := db.SaveAttesterSlashing(ctx, types.Active, slashing); err != nil {
+		t.Fatal(err)
+	}
+	root, err := hashutil.HashProto(slashing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bs := &Service{
+		beaconClient:       client,
+		slasherDB:          db,
+		resubmitEpochs:     1,
+		submittedEpochs:    make(map[[32]byte]uint64),
+		submittedSlashings: map[[32]byte]bool{root: true},
+		headInclusions:     make(map[[32]byte]*headInclusion),
+	}
+
+	// The validator was slashed by a slashing from another source before it was finalized.
+	client.EXPECT().ListValidators(gomock.Any(), &ethpb.ListValidatorsRequest{Indices: []uint64{1}}).Return(&ethpb.Validators{
+		ValidatorList: []*ethpb.Validators_ValidatorContainer{{Index: 1, Validator: &ethpb.Validator{Slashed: true}}},
+	}, nil)
+	for epoch := uint64(10); epoch <= 13; epoch++ {
+		if err := bs.resubmitPendingSlashings(ctx, epoch); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, status, err := db.HasAttesterSlashing(ctx, slashing); err != nil || status != types.Included {
+		t.Errorf("Expected status %s, received %s: %v", types.SlashingStatus(types.Included), status, err)
+	}
+	if len(bs.submittedEpochs) != 0 {
+		t.Errorf("Expected no tracked slashings, received %d", len(bs.submittedEpochs))
+	}
+}
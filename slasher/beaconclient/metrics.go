@@ -5,9 +5,39 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promauto"
 )
 
+// Slashing type label values of the slashing status metrics.
+const (
+	attesterSlashingType = "attester"
+	proposerSlashingType = "proposer"
+)
+
 var (
 	slasherNumAttestationsReceived = promauto.NewCounter(prometheus.CounterOpts{
 		Name: "slasher_attestations_received_total",
 		Help: "The # of attestations received by slasher",
 	})
+	slasherNumDuplicateAttestationsReceived = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "slasher_duplicate_attestations_received_total",
+		Help: "The # of attestations dropped by slasher as they were already received",
+	})
+	slasherNumDuplicateBlocksReceived = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "slasher_duplicate_blocks_received_total",
+		Help: "The # of blocks dropped by slasher as they were already received",
+	})
+	slashingsByStatus = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "slasher_slashings",
+		Help: "The # of slashings detected by slasher by type and status",
+	}, []string{"type", "status"})
+	slashingsIncluded = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "slasher_slashings_included_total",
+		Help: "The # of detected slashings included in finalized blocks",
+	}, []string{"type"})
+	slashingsReverted = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "slasher_slashings_reverted_total",
+		Help: "The # of detected slashings included in blocks which were not finalized",
+	}, []string{"type"})
+	slashingsResubmitted = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "slasher_slashings_resubmitted_total",
+		Help: "The # of detected slashings submitted again as they were not included on chain",
+	}, []string{"type"})
 )
@@ -8,6 +8,7 @@ import (
 
 	ptypes "github.com/gogo/protobuf/types"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	pbrpc "github.com/prysmaticlabs/prysm/proto/beacon/rpc/v1"
 	"github.com/sirupsen/logrus"
 	"go.opencensus.io/trace"
 	"google.golang.org/grpc/codes"
@@ -19,12 +20,13 @@ import (
 var reconnectPeriod = 5 * time.Second
 
 // receiveBlocks starts a gRPC client stream listener to obtain
-// blocks from the beacon node. Upon receiving a block, the service
+// blocks from a beacon node. Upon receiving a block, the service
 // broadcasts it to a feed for other services in slasher to subscribe to.
-func (bs *Service) receiveBlocks(ctx context.Context) {
+// Blocks already received from another beacon node are dropped.
+func (bs *Service) receiveBlocks(ctx context.Context, client ethpb.BeaconChainClient) {
 	ctx, span := trace.StartSpan(ctx, "beaconclient.receiveBlocks")
 	defer span.End()
-	stream, err := bs.beaconClient.StreamBlocks(ctx, &ptypes.Empty{})
+	stream, err := client.StreamBlocks(ctx, &ptypes.Empty{})
 	if err != nil {
 		log.WithError(err).Error("Failed to retrieve blocks stream")
 		return
@@ -44,7 +46,7 @@ func (bs *Service) receiveBlocks(ctx context.Context) {
 			if e, ok := status.FromError(err); ok {
 				switch e.Code() {
 				case codes.Canceled:
-					stream, err = bs.restartBlockStream(ctx)
+					stream, err = bs.restartBlockStream(ctx, client)
 					if err != nil {
 						log.WithError(err).Error("Could not restart stream")
 						return
@@ -62,25 +64,88 @@ func (bs *Service) receiveBlocks(ctx context.Context) {
 		if res == nil {
 			continue
 		}
-		log.WithField("slot", res.Block.Slot).Info("Received block from beacon node")
-		// We send the received block over the block feed.
-		bs.blockFeed.Send(res)
+		// Inclusions are recorded for every block processed by the beacon node, including
+		// blocks which were already received over gossip.
+		bs.recordHeadInclusions(ctx, res)
+		bs.sendBlock(res)
 	}
 }
 
+// receiveGossipBlocks starts a gRPC client stream listener to obtain the blocks
+// a beacon node received over gossip, including blocks it did not process such as
+// the second block of a proposer for a slot. Upon receiving a block, the service
+// broadcasts it to the block feed, unless it was already received.
+func (bs *Service) receiveGossipBlocks(ctx context.Context, client pbrpc.GossipClient) {
+	ctx, span := trace.StartSpan(ctx, "beaconclient.receiveGossipBlocks")
+	defer span.End()
+	stream, err := client.StreamGossipBlocks(ctx, &ptypes.Empty{})
+	if err != nil {
+		log.WithError(err).Error("Failed to retrieve gossip blocks stream")
+		return
+	}
+	for {
+		res, err := stream.Recv()
+		// If the stream is closed, we stop the loop.
+		if err == io.EOF {
+			break
+		}
+		// If context is canceled we stop the loop.
+		if ctx.Err() == context.Canceled {
+			log.WithError(ctx.Err()).Error("Context canceled - shutting down gossip blocks receiver")
+			return
+		}
+		if err != nil {
+			if e, ok := status.FromError(err); ok {
+				switch e.Code() {
+				case codes.Canceled:
+					stream, err = bs.restartGossipBlockStream(ctx, client)
+					if err != nil {
+						log.WithError(err).Error("Could not restart stream")
+						return
+					}
+					break
+				default:
+					log.WithError(err).Errorf("Could not receive gossip block from beacon node. rpc status: %v", e.Code())
+					return
+				}
+			} else {
+				log.WithError(err).Error("Could not receive gossip blocks from beacon node")
+				return
+			}
+		}
+		if res == nil || res.Block == nil {
+			continue
+		}
+		bs.sendBlock(res)
+	}
+}
+
+// sendBlock broadcasts a received block over the block feed, unless it was
+// already received from the same or from another stream.
+func (bs *Service) sendBlock(blk *ethpb.SignedBeaconBlock) {
+	if bs.seenBlocks != nil {
+		if seen, _ := bs.seenBlocks.ContainsOrAdd(string(blk.Signature), true); seen {
+			slasherNumDuplicateBlocksReceived.Inc()
+			return
+		}
+	}
+	log.WithField("slot", blk.Block.Slot).Info("Received block from beacon node")
+	bs.blockFeed.Send(blk)
+}
+
 // receiveAttestations starts a gRPC client stream listener to obtain
-// attestations from the beacon node. Upon receiving an attestation, the service
-// broadcasts it to a feed for other services in slasher to subscribe to.
-func (bs *Service) receiveAttestations(ctx context.Context) {
+// attestations from a beacon node. Received attestations are collected by
+// collectReceivedAttestations, which broadcasts them to a feed for other
+// services in slasher to subscribe to.
+func (bs *Service) receiveAttestations(ctx context.Context, client ethpb.BeaconChainClient) {
 	ctx, span := trace.StartSpan(ctx, "beaconclient.receiveAttestations")
 	defer span.End()
-	stream, err := bs.beaconClient.StreamIndexedAttestations(ctx, &ptypes.Empty{})
+	stream, err := client.StreamIndexedAttestations(ctx, &ptypes.Empty{})
 	if err != nil {
 		log.WithError(err).Error("Failed to retrieve attestations stream")
 		return
 	}
 
-	go bs.collectReceivedAttestations(ctx)
 	for {
 		res, err := stream.Recv()
 		// If the stream is closed, we stop the loop.
@@ -96,7 +161,7 @@ func (bs *Service) receiveAttestations(ctx context.Context) {
 			if e, ok := status.FromError(err); ok {
 				switch e.Code() {
 				case codes.Canceled:
-					stream, err = bs.restartIndexedAttestationStream(ctx)
+					stream, err = bs.restartIndexedAttestationStream(ctx, client)
 					if err != nil {
 						log.WithError(err).Error("Could not restart stream")
 						return
@@ -118,6 +183,59 @@ func (bs *Service) receiveAttestations(ctx context.Context) {
 	}
 }
 
+// receiveGossipAttestations starts a gRPC client stream listener to obtain the
+// attestations a beacon node received over gossip, aggregated or not, including
+// attestations it did not process such as votes for unknown blocks. Received
+// attestations are collected along with the attestations of receiveAttestations.
+func (bs *Service) receiveGossipAttestations(ctx context.Context, client pbrpc.GossipClient) {
+	ctx, span := trace.StartSpan(ctx, "beaconclient.receiveGossipAttestations")
+	defer span.End()
+	stream, err := client.StreamGossipIndexedAttestations(ctx, &ptypes.Empty{})
+	if err != nil {
+		log.WithError(err).Error("Failed to retrieve gossip attestations stream")
+		return
+	}
+
+	for {
+		res, err := stream.Recv()
+		// If the stream is closed, we stop the loop.
+		if err == io.EOF {
+			break
+		}
+		// If context is canceled we stop the loop.
+		if ctx.Err() == context.Canceled {
+			log.WithError(ctx.Err()).Error("Context canceled - shutting down gossip attestations receiver")
+			return
+		}
+		if err != nil {
+			if e, ok := status.FromError(err); ok {
+				switch e.Code() {
+				case codes.Canceled:
+					stream, err = bs.restartGossipAttestationStream(ctx, client)
+					if err != nil {
+						log.WithError(err).Error("Could not restart stream")
+						return
+					}
+					break
+				default:
+					log.WithError(err).Errorf("Could not receive gossip attestations from beacon node. rpc status: %v", e.Code())
+					return
+				}
+			} else {
+				log.WithError(err).Error("Could not receive gossip attestations from beacon node")
+				return
+			}
+		}
+		if res == nil || res.Data == nil || res.Data.Target == nil {
+			continue
+		}
+		bs.receivedAttestationsBuffer <- res
+	}
+}
+
+// collectReceivedAttestations batches the attestations received from all beacon nodes,
+// saves them to the slasher DB and broadcasts them to the attestation feed. Attestations
+// which were already received, from the same or from another beacon node, are dropped.
 func (bs *Service) collectReceivedAttestations(ctx context.Context) {
 	ctx, span := trace.StartSpan(ctx, "beaconclient.collectReceivedAttestations")
 	defer span.End()
@@ -134,6 +252,14 @@ func (bs *Service) collectReceivedAttestations(ctx context.Context) {
 		case att := <-bs.receivedAttestationsBuffer:
 			atts = append(atts, att)
 		case collectedAtts := <-bs.collectedAttestationsBuffer:
+			collectedAtts, err := bs.dropDuplicateAttestations(ctx, collectedAtts)
+			if err != nil {
+				log.WithError(err).Error("Could not check for duplicate attestations")
+				continue
+			}
+			if len(collectedAtts) == 0 {
+				continue
+			}
 			if err := bs.slasherDB.SaveIndexedAttestations(ctx, collectedAtts); err != nil {
 				log.WithError(err).Error("Could not save indexed attestation")
 				continue
@@ -158,13 +284,43 @@ func (bs *Service) collectReceivedAttestations(ctx context.Context) {
 	}
 }
 
-func (bs *Service) restartIndexedAttestationStream(ctx context.Context) (ethpb.BeaconChain_StreamIndexedAttestationsClient, error) {
+// dropDuplicateAttestations removes attestations which occur more than once in the given
+// batch or which are already stored in the slasher DB. Attestations are identified by their
+// target epoch and signature, as they are in the slasher DB.
+func (bs *Service) dropDuplicateAttestations(ctx context.Context, atts []*ethpb.IndexedAttestation) ([]*ethpb.IndexedAttestation, error) {
+	type attKey struct {
+		targetEpoch uint64
+		signature   string
+	}
+	seen := make(map[attKey]bool, len(atts))
+	unique := make([]*ethpb.IndexedAttestation, 0, len(atts))
+	for _, att := range atts {
+		key := attKey{targetEpoch: att.Data.Target.Epoch, signature: string(att.Signature)}
+		if seen[key] {
+			slasherNumDuplicateAttestationsReceived.Inc()
+			continue
+		}
+		seen[key] = true
+		exists, err := bs.slasherDB.HasIndexedAttestation(ctx, att)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			slasherNumDuplicateAttestationsReceived.Inc()
+			continue
+		}
+		unique = append(unique, att)
+	}
+	return unique, nil
+}
+
+func (bs *Service) restartIndexedAttestationStream(ctx context.Context, client ethpb.BeaconChainClient) (ethpb.BeaconChain_StreamIndexedAttestationsClient, error) {
 	ticker := time.NewTicker(reconnectPeriod)
 	for {
 		select {
 		case <-ticker.C:
 			log.Info("Context closed, attempting to restart attestation stream")
-			stream, err := bs.beaconClient.StreamIndexedAttestations(ctx, &ptypes.Empty{})
+			stream, err := client.StreamIndexedAttestations(ctx, &ptypes.Empty{})
 			if err != nil {
 				continue
 			}
@@ -178,13 +334,13 @@ func (bs *Service) restartIndexedAttestationStream(ctx context.Context) (ethpb.B
 
 }
 
-func (bs *Service) restartBlockStream(ctx context.Context) (ethpb.BeaconChain_StreamBlocksClient, error) {
+func (bs *Service) restartBlockStream(ctx context.Context, client ethpb.BeaconChainClient) (ethpb.BeaconChain_StreamBlocksClient, error) {
 	ticker := time.NewTicker(reconnectPeriod)
 	for {
 		select {
 		case <-ticker.C:
 			log.Info("Context closed, attempting to restart block stream")
-			stream, err := bs.beaconClient.StreamBlocks(ctx, &ptypes.Empty{})
+			stream, err := client.StreamBlocks(ctx, &ptypes.Empty{})
 			if err != nil {
 				continue
 			}
@@ -197,3 +353,41 @@ func (bs *Service) restartBlockStream(ctx context.Context) (ethpb.BeaconChain_St
 	}
 
 }
+
+func (bs *Service) restartGossipBlockStream(ctx context.Context, client pbrpc.GossipClient) (pbrpc.Gossip_StreamGossipBlocksClient, error) {
+	ticker := time.NewTicker(reconnectPeriod)
+	for {
+		select {
+		case <-ticker.C:
+			log.Info("Context closed, attempting to restart gossip block stream")
+			stream, err := client.StreamGossipBlocks(ctx, &ptypes.Empty{})
+			if err != nil {
+				continue
+			}
+			log.Info("Gossip block stream restarted...")
+			return stream, nil
+		case <-ctx.Done():
+			log.Debug("Context closed, exiting reconnect routine")
+			return nil, errors.New("context closed, no longer attempting to restart stream")
+		}
+	}
+}
+
+func (bs *Service) restartGossipAttestationStream(ctx context.Context, client pbrpc.GossipClient) (pbrpc.Gossip_StreamGossipIndexedAttestationsClient, error) {
+	ticker := time.NewTicker(reconnectPeriod)
+	for {
+		select {
+		case <-ticker.C:
+			log.Info("Context closed, attempting to restart gossip attestation stream")
+			stream, err := client.StreamGossipIndexedAttestations(ctx, &ptypes.Empty{})
+			if err != nil {
+				continue
+			}
+			log.Info("Gossip attestation stream restarted...")
+			return stream, nil
+		case <-ctx.Done():
+			log.Debug("Context closed, exiting reconnect routine")
+			return nil, errors.New("context closed, no longer attempting to restart stream")
+		}
+	}
+}
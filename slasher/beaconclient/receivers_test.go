@@ -2,15 +2,19 @@ package beaconclient
 
 import (
 	"context"
+	"reflect"
 	"testing"
 	"time"
 
 	ptypes "github.com/gogo/protobuf/types"
 	"github.com/golang/mock/gomock"
+	lru "github.com/hashicorp/golang-lru"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	pbrpc "github.com/prysmaticlabs/prysm/proto/beacon/rpc/v1"
 	"github.com/prysmaticlabs/prysm/shared/event"
 	"github.com/prysmaticlabs/prysm/shared/mock"
 	testDB "github.com/prysmaticlabs/prysm/slasher/db/testing"
+	"google.golang.org/grpc"
 )
 
 func TestService_ReceiveBlocks(t *testing.T) {
@@ -35,7 +39,7 @@ func TestService_ReceiveBlocks(t *testing.T) {
 	).Do(func() {
 		cancel()
 	})
-	bs.receiveBlocks(ctx)
+	bs.receiveBlocks(ctx, client)
 }
 
 func TestService_ReceiveAttestations(t *testing.T) {
@@ -67,7 +71,7 @@ func TestService_ReceiveAttestations(t *testing.T) {
 	).Do(func() {
 		cancel()
 	})
-	bs.receiveAttestations(ctx)
+	bs.receiveAttestations(ctx, client)
 }
 
 func TestService_ReceiveAttestations_Batched(t *testing.T) {
@@ -107,7 +111,8 @@ func TestService_ReceiveAttestations_Batched(t *testing.T) {
 		cancel()
 	})
 
-	go bs.receiveAttestations(ctx)
+	go bs.collectReceivedAttestations(ctx)
+	go bs.receiveAttestations(ctx, client)
 	bs.receivedAttestationsBuffer <- att
 	att.Data.Target.Epoch = 6
 	bs.receivedAttestationsBuffer <- att
@@ -118,3 +123,160 @@ func TestService_ReceiveAttestations_Batched(t *testing.T) {
 		t.Fatalf("Expected %d received attestations to be batched", len(atts))
 	}
 }
+
+func TestService_ReceiveBlocks_DropsDuplicates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client := mock.NewMockBeaconChainClient(ctrl)
+	seenBlocks, err := lru.New(seenBlocksSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bs := Service{
+		blockFeed:  new(event.Feed),
+		seenBlocks: seenBlocks,
+	}
+	blocksChannel := make(chan *ethpb.SignedBeaconBlock, 2)
+	sub := bs.blockFeed.Subscribe(blocksChannel)
+	defer sub.Unsubscribe()
+	blk := &ethpb.SignedBeaconBlock{Block: &ethpb.BeaconBlock{Slot: 1}, Signature: []byte{1, 2}}
+	for i := 0; i < 2; i++ {
+		stream := mock.NewMockBeaconChain_StreamBlocksClient(ctrl)
+		ctx, cancel := context.WithCancel(context.Background())
+		client.EXPECT().StreamBlocks(
+			gomock.Any(),
+			&ptypes.Empty{},
+		).Return(stream, nil)
+		stream.EXPECT().Context().Return(ctx).AnyTimes()
+		stream.EXPECT().Recv().Return(
+			blk,
+			nil,
+		)
+		stream.EXPECT().Recv().Return(
+			nil,
+			nil,
+		).Do(func() {
+			cancel()
+		})
+		bs.receiveBlocks(ctx, client)
+	}
+	if len(blocksChannel) != 1 {
+		t.Errorf("Expected 1 block to be sent over the feed, received %d", len(blocksChannel))
+	}
+}
+
+type fakeGossipClient struct {
+	pbrpc.GossipClient
+	blocks []*ethpb.SignedBeaconBlock
+	cancel context.CancelFunc
+}
+
+func (f *fakeGossipClient) StreamGossipBlocks(_ context.Context, _ *ptypes.Empty, _ ...grpc.CallOption) (pbrpc.Gossip_StreamGossipBlocksClient, error) {
+	return &fakeGossipBlocksStream{client: f}, nil
+}
+
+type fakeGossipBlocksStream struct {
+	grpc.ClientStream
+	client *fakeGossipClient
+}
+
+func (f *fakeGossipBlocksStream) Recv() (*ethpb.SignedBeaconBlock, error) {
+	if len(f.client.blocks) == 0 {
+		f.client.cancel()
+		return nil, nil
+	}
+	blk := f.client.blocks[0]
+	f.client.blocks = f.client.blocks[1:]
+	return blk, nil
+}
+
+func TestService_ReceiveGossipBlocks_DropsBlocksOfBlockStream(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	seenBlocks, err := lru.New(seenBlocksSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bs := Service{
+		blockFeed:  new(event.Feed),
+		seenBlocks: seenBlocks,
+	}
+	blocksChannel := make(chan *ethpb.SignedBeaconBlock, 3)
+	sub := bs.blockFeed.Subscribe(blocksChannel)
+	defer sub.Unsubscribe()
+	blk := &ethpb.SignedBeaconBlock{Block: &ethpb.BeaconBlock{Slot: 1}, Signature: []byte{1, 2}}
+	// A second block of the proposer for the slot is only received over gossip.
+	doubleProposal := &ethpb.SignedBeaconBlock{Block: &ethpb.BeaconBlock{Slot: 1, StateRoot: []byte{3}}, Signature: []byte{3, 4}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	bs.receiveGossipBlocks(ctx, &fakeGossipClient{blocks: []*ethpb.SignedBeaconBlock{blk, doubleProposal}, cancel: cancel})
+
+	client := mock.NewMockBeaconChainClient(ctrl)
+	stream := mock.NewMockBeaconChain_StreamBlocksClient(ctrl)
+	ctx, cancel = context.WithCancel(context.Background())
+	client.EXPECT().StreamBlocks(
+		gomock.Any(),
+		&ptypes.Empty{},
+	).Return(stream, nil)
+	stream.EXPECT().Context().Return(ctx).AnyTimes()
+	stream.EXPECT().Recv().Return(
+		blk,
+		nil,
+	)
+	stream.EXPECT().Recv().Return(
+		nil,
+		nil,
+	).Do(func() {
+		cancel()
+	})
+	bs.receiveBlocks(ctx, client)
+
+	if len(blocksChannel) != 2 {
+		t.Fatalf("Expected 2 blocks to be sent over the feed, received %d", len(blocksChannel))
+	}
+	if b := <-blocksChannel; b != blk {
+		t.Errorf("Expected block %v, received %v", blk, b)
+	}
+	if b := <-blocksChannel; b != doubleProposal {
+		t.Errorf("Expected block %v, received %v", doubleProposal, b)
+	}
+}
+
+func TestService_DropDuplicateAttestations(t *testing.T) {
+	db := testDB.SetupSlasherDB(t, false)
+	defer testDB.TeardownSlasherDB(t, db)
+	ctx := context.Background()
+	bs := Service{
+		slasherDB: db,
+	}
+	newAtt := func(target uint64, sig byte) *ethpb.IndexedAttestation {
+		return &ethpb.IndexedAttestation{
+			AttestingIndices: []uint64{1},
+			Data: &ethpb.AttestationData{
+				Source: &ethpb.Checkpoint{Epoch: target - 1},
+				Target: &ethpb.Checkpoint{Epoch: target},
+			},
+			Signature: []byte{sig, 2},
+		}
+	}
+	if err := db.SaveIndexedAttestations(ctx, []*ethpb.IndexedAttestation{newAtt(3, 1)}); err != nil {
+		t.Fatal(err)
+	}
+
+	atts := []*ethpb.IndexedAttestation{
+		newAtt(3, 1),
+		newAtt(3, 2),
+		newAtt(4, 2),
+		newAtt(3, 2),
+	}
+	unique, err := bs.dropDuplicateAttestations(ctx, atts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*ethpb.IndexedAttestation{newAtt(3, 2), newAtt(4, 2)}
+	if !reflect.DeepEqual(unique, want) {
+		t.Errorf("Wanted %v, received %v", want, unique)
+	}
+}
@@ -7,12 +7,15 @@ package beaconclient
 
 import (
 	"context"
+	"sync"
 
 	middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	grpc_opentracing "github.com/grpc-ecosystem/go-grpc-middleware/tracing/opentracing"
 	grpc_prometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
+	lru "github.com/hashicorp/golang-lru"
 	"github.com/pkg/errors"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	pbrpc "github.com/prysmaticlabs/prysm/proto/beacon/rpc/v1"
 	"github.com/prysmaticlabs/prysm/shared/event"
 	"github.com/prysmaticlabs/prysm/slasher/cache"
 	"github.com/prysmaticlabs/prysm/slasher/db"
@@ -45,6 +48,10 @@ type Service struct {
 	cert                        string
 	conn                        *grpc.ClientConn
 	provider                    string
+	additionalProviders         []string
+	additionalConns             []*grpc.ClientConn
+	gossipStreams               bool
+	seenBlocks                  *lru.Cache
 	beaconClient                ethpb.BeaconChainClient
 	slasherDB                   db.Database
 	nodeClient                  ethpb.NodeClient
@@ -58,17 +65,33 @@ type Service struct {
 	receivedAttestationsBuffer  chan *ethpb.IndexedAttestation
 	collectedAttestationsBuffer chan []*ethpb.IndexedAttestation
 	publicKeyCache              *cache.PublicKeyCache
+	resubmitEpochs              uint64
+	submittedEpochs             map[[32]byte]uint64
+	submittedSlashings          map[[32]byte]bool
+	submittedLock               sync.Mutex
+	lastFinalizedSlot           uint64
+	headInclusions              map[[32]byte]*headInclusion
+	headInclusionsLock          sync.RWMutex
 }
 
 // Config options for the beaconclient service.
 type Config struct {
-	BeaconProvider        string
-	BeaconCert            string
-	SlasherDB             db.Database
-	ProposerSlashingsFeed *event.Feed
-	AttesterSlashingsFeed *event.Feed
+	BeaconProvider            string
+	AdditionalBeaconProviders []string
+	GossipStreams             bool
+	BeaconCert                string
+	SlasherDB                 db.Database
+	ProposerSlashingsFeed     *event.Feed
+	AttesterSlashingsFeed     *event.Feed
+	// SlashingResubmitEpochs is the number of epochs after which detected
+	// slashings which were not included on chain are submitted again.
+	SlashingResubmitEpochs uint64
 }
 
+// seenBlocksSize is the number of recently received block signatures kept to
+// drop blocks received from more than one beacon node.
+const seenBlocksSize = 1024
+
 // NewBeaconClientService instantiation.
 func NewBeaconClientService(ctx context.Context, cfg *Config) (*Service, error) {
 	ctx, cancel := context.WithCancel(ctx)
@@ -76,12 +99,24 @@ func NewBeaconClientService(ctx context.Context, cfg *Config) (*Service, error)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not create new cache")
 	}
+	seenBlocks, err := lru.New(seenBlocksSize)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create seen blocks cache")
+	}
+
+	resubmitEpochs := cfg.SlashingResubmitEpochs
+	if resubmitEpochs == 0 {
+		resubmitEpochs = defaultSlashingResubmitEpochs
+	}
 
 	return &Service{
 		cert:                        cfg.BeaconCert,
 		ctx:                         ctx,
 		cancel:                      cancel,
 		provider:                    cfg.BeaconProvider,
+		additionalProviders:         cfg.AdditionalBeaconProviders,
+		gossipStreams:               cfg.GossipStreams,
+		seenBlocks:                  seenBlocks,
 		blockFeed:                   new(event.Feed),
 		clientFeed:                  new(event.Feed),
 		attestationFeed:             new(event.Feed),
@@ -93,6 +128,10 @@ func NewBeaconClientService(ctx context.Context, cfg *Config) (*Service, error)
 		receivedAttestationsBuffer:  make(chan *ethpb.IndexedAttestation, 1),
 		collectedAttestationsBuffer: make(chan []*ethpb.IndexedAttestation, 1),
 		publicKeyCache:              publicKeyCache,
+		resubmitEpochs:              resubmitEpochs,
+		submittedEpochs:             make(map[[32]byte]uint64),
+		submittedSlashings:          make(map[[32]byte]bool),
+		headInclusions:              make(map[[32]byte]*headInclusion),
 	}, nil
 }
 
@@ -114,10 +153,15 @@ func (bs *Service) ClientReadyFeed() *event.Feed {
 	return bs.clientFeed
 }
 
-// Stop the beacon client service by closing the gRPC connection.
+// Stop the beacon client service by closing the gRPC connections.
 func (bs *Service) Stop() error {
 	bs.cancel()
 	log.Info("Stopping service")
+	for _, conn := range bs.additionalConns {
+		if err := conn.Close(); err != nil {
+			log.WithError(err).Error("Could not close connection to additional beacon node")
+		}
+	}
 	if bs.conn != nil {
 		return bs.conn.Close()
 	}
@@ -136,7 +180,9 @@ func (bs *Service) Status() error {
 // Start the main runtime of the beaconclient service, initializing
 // a gRPC client connection with a beacon node, listening for
 // streamed blocks/attestations, and submitting slashing operations
-// after they are detected by other services in the slasher.
+// after they are detected by other services in the slasher. Blocks and
+// attestations are streamed from the additional beacon nodes as well,
+// while chain data is only requested from the main beacon node.
 func (bs *Service) Start() {
 	var dialOpt grpc.DialOption
 	if bs.cert != "" {
@@ -171,6 +217,17 @@ func (bs *Service) Start() {
 	bs.conn = conn
 	bs.beaconClient = ethpb.NewBeaconChainClient(bs.conn)
 	bs.nodeClient = ethpb.NewNodeClient(bs.conn)
+	streamConns := []*grpc.ClientConn{bs.conn}
+	for _, provider := range bs.additionalProviders {
+		conn, err := grpc.DialContext(bs.ctx, provider, beaconOpts...)
+		if err != nil {
+			log.WithError(err).Errorf("Could not dial additional endpoint: %s", provider)
+			continue
+		}
+		log.WithField("endpoint", provider).Info("Successfully started gRPC connection to additional beacon node")
+		bs.additionalConns = append(bs.additionalConns, conn)
+		streamConns = append(streamConns, conn)
+	}
 
 	// We poll for the sync status of the beacon node until it is fully synced.
 	bs.querySyncStatus(bs.ctx)
@@ -184,8 +241,35 @@ func (bs *Service) Start() {
 	// as they are found.
 	go bs.subscribeDetectedProposerSlashings(bs.ctx, bs.proposerSlashingsChan)
 	go bs.subscribeDetectedAttesterSlashings(bs.ctx, bs.attesterSlashingsChan)
+	// We track whether detected slashings are included on chain, submitting
+	// them again if they are not, including the slashings still pending
+	// since before the slasher restarted.
+	if err := bs.loadPendingSlashings(bs.ctx); err != nil {
+		log.WithError(err).Error("Could not load pending slashings")
+	}
+	go bs.trackSlashingInclusion(bs.ctx)
+
+	// We listen to a stream of blocks and attestations from every beacon node,
+	// and to the blocks and attestations it received over gossip if enabled.
+	go bs.collectReceivedAttestations(bs.ctx)
+	for _, conn := range streamConns {
+		client := ethpb.NewBeaconChainClient(conn)
+		go bs.receiveBlocks(bs.ctx, client)
+		go bs.receiveAttestations(bs.ctx, client)
+		if bs.gossipStreams {
+			gossipClient := pbrpc.NewGossipClient(conn)
+			go bs.receiveGossipBlocks(bs.ctx, gossipClient)
+			go bs.receiveGossipAttestations(bs.ctx, gossipClient)
+		}
+	}
+}
 
-	// We listen to a stream of blocks and attestations from the beacon node.
-	go bs.receiveBlocks(bs.ctx)
-	go bs.receiveAttestations(bs.ctx)
+// ReceiveChainData listens to the blocks and attestations streamed by a beacon chain
+// client, without dialing a beacon node. The streamed chain data goes through the same
+// path as the chain data of the beacon nodes: duplicates are dropped and attestations
+// are saved to the slasher DB before they are broadcast.
+func (bs *Service) ReceiveChainData(client ethpb.BeaconChainClient) {
+	go bs.collectReceivedAttestations(bs.ctx)
+	go bs.receiveBlocks(bs.ctx, client)
+	go bs.receiveAttestations(bs.ctx, client)
 }
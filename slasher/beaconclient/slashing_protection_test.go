@@ -0,0 +1,148 @@
+package beaconclient_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	slashpb "github.com/prysmaticlabs/prysm/proto/slashing"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/event"
+	"github.com/prysmaticlabs/prysm/shared/mock"
+	"github.com/prysmaticlabs/prysm/slasher/beaconclient"
+	testDB "github.com/prysmaticlabs/prysm/slasher/db/testing"
+	"github.com/prysmaticlabs/prysm/slasher/db/types"
+	"github.com/prysmaticlabs/prysm/slasher/detection"
+	"github.com/prysmaticlabs/prysm/slasher/rpc"
+	"google.golang.org/grpc"
+)
+
+// Validators check their attestations and blocks with the slasher before broadcasting them,
+// and reject the slashable ones. The slashings found by those checks must never be submitted
+// to the beacon node, as they would slash the validator for an offense it did not commit.
+func TestSlashableChecks_NeverSubmitted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	// Any call to SubmitAttesterSlashing or SubmitProposerSlashing fails the test.
+	client := mock.NewMockBeaconChainClient(ctrl)
+	db := testDB.SetupSlasherDB(t, false)
+	defer testDB.TeardownSlasherDB(t, db)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	attesterSlashingsFeed := new(event.Feed)
+	proposerSlashingsFeed := new(event.Feed)
+	bs, err := beaconclient.NewBeaconClientService(ctx, &beaconclient.Config{
+		SlasherDB:              db,
+		AttesterSlashingsFeed:  attesterSlashingsFeed,
+		ProposerSlashingsFeed:  proposerSlashingsFeed,
+		SlashingResubmitEpochs: 1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	bs.SetBeaconClient(client)
+	go bs.SubscribeDetectedAttesterSlashings(ctx, make(chan *ethpb.AttesterSlashing, 1))
+	go bs.SubscribeDetectedProposerSlashings(ctx, make(chan *ethpb.ProposerSlashing, 1))
+
+	ds := detection.NewDetectionService(ctx, &detection.Config{
+		SlasherDB:             db,
+		AttesterSlashingsFeed: attesterSlashingsFeed,
+		ProposerSlashingsFeed: proposerSlashingsFeed,
+	})
+	rpcService := rpc.NewService(ctx, &rpc.Config{
+		Port:                  "7351",
+		Detector:              ds,
+		SlasherDB:             db,
+		AttesterSlashingsFeed: attesterSlashingsFeed,
+		ProposerSlashingsFeed: proposerSlashingsFeed,
+	})
+	rpcService.Start()
+	defer func() {
+		if err := rpcService.Stop(); err != nil {
+			t.Error(err)
+		}
+	}()
+	conn, err := grpc.Dial("127.0.0.1:7351", grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			t.Error(err)
+		}
+	}()
+	slasher := slashpb.NewSlasherClient(conn)
+
+	att := func(source uint64, sig byte) *ethpb.IndexedAttestation {
+		return &ethpb.IndexedAttestation{
+			AttestingIndices: []uint64{3},
+			Data: &ethpb.AttestationData{
+				BeaconBlockRoot: make([]byte, 32),
+				Source:          &ethpb.Checkpoint{Epoch: source, Root: make([]byte, 32)},
+				Target:          &ethpb.Checkpoint{Epoch: 4, Root: make([]byte, 32)},
+			},
+			Signature: bytesutil.PadTo([]byte{sig}, 96),
+		}
+	}
+	res, err := slasher.IsSlashableAttestation(ctx, att(3, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.AttesterSlashing) != 0 {
+		t.Fatalf("Expected first attestation not to be slashable, received %v", res.AttesterSlashing)
+	}
+	res, err = slasher.IsSlashableAttestation(ctx, att(2, 2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.AttesterSlashing) != 1 {
+		t.Fatalf("Expected double vote to be slashable, received %d slashings", len(res.AttesterSlashing))
+	}
+
+	header := func(sig byte) *ethpb.SignedBeaconBlockHeader {
+		return &ethpb.SignedBeaconBlockHeader{
+			Header: &ethpb.BeaconBlockHeader{
+				Slot:          5,
+				ProposerIndex: 3,
+				BodyRoot:      bytesutil.PadTo([]byte{sig}, 32),
+			},
+			Signature: bytesutil.PadTo([]byte{sig}, 96),
+		}
+	}
+	blkRes, err := slasher.IsSlashableBlock(ctx, header(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(blkRes.ProposerSlashing) != 0 {
+		t.Fatalf("Expected first block not to be slashable, received %v", blkRes.ProposerSlashing)
+	}
+	blkRes, err = slasher.IsSlashableBlock(ctx, header(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(blkRes.ProposerSlashing) != 1 {
+		t.Fatalf("Expected double proposal to be slashable, received %d slashings", len(blkRes.ProposerSlashing))
+	}
+
+	for _, status := range []types.SlashingStatus{types.Active, types.Reverted} {
+		attesterSlashings, err := db.AttesterSlashings(ctx, status)
+		if err != nil {
+			t.Fatal(err)
+		}
+		proposerSlashings, err := db.ProposalSlashingsByStatus(ctx, status)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(attesterSlashings)+len(proposerSlashings) != 0 {
+			t.Errorf("Expected no %s slashings to be saved, received %d", status, len(attesterSlashings)+len(proposerSlashings))
+		}
+	}
+	// Pending slashings would be re-submitted within these epochs.
+	for epoch := uint64(1); epoch <= 4; epoch++ {
+		if err := bs.ResubmitPendingSlashings(ctx, epoch); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
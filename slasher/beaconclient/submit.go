@@ -3,7 +3,10 @@ package beaconclient
 import (
 	"context"
 
+	"github.com/gogo/protobuf/proto"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/hashutil"
+	"github.com/prysmaticlabs/prysm/slasher/db/types"
 	"go.opencensus.io/trace"
 )
 
@@ -19,9 +22,7 @@ func (bs *Service) subscribeDetectedProposerSlashings(ctx context.Context, ch ch
 	for {
 		select {
 		case slashing := <-ch:
-			if _, err := bs.beaconClient.SubmitProposerSlashing(ctx, slashing); err != nil {
-				log.Error(err)
-			}
+			bs.submitProposerSlashing(ctx, slashing)
 		case <-sub.Err():
 			log.Error("Subscriber closed, exiting goroutine")
 			return
@@ -44,9 +45,7 @@ func (bs *Service) subscribeDetectedAttesterSlashings(ctx context.Context, ch ch
 	for {
 		select {
 		case slashing := <-ch:
-			if _, err := bs.beaconClient.SubmitAttesterSlashing(ctx, slashing); err != nil {
-				log.Error(err)
-			}
+			bs.submitAttesterSlashing(ctx, slashing)
 		case <-sub.Err():
 			log.Error("Subscriber closed, exiting goroutine")
 			return
@@ -56,3 +55,162 @@ func (bs *Service) subscribeDetectedAttesterSlashings(ctx context.Context, ch ch
 		}
 	}
 }
+
+func (bs *Service) submitProposerSlashing(ctx context.Context, slashing *ethpb.ProposerSlashing) {
+	bs.trackSubmission(slashing)
+	if _, err := bs.beaconClient.SubmitProposerSlashing(ctx, slashing); err != nil {
+		log.Error(err)
+	}
+}
+
+func (bs *Service) submitAttesterSlashing(ctx context.Context, slashing *ethpb.AttesterSlashing) {
+	bs.trackSubmission(slashing)
+	if _, err := bs.beaconClient.SubmitAttesterSlashing(ctx, slashing); err != nil {
+		log.Error(err)
+	}
+}
+
+// trackSubmission records the root of a slashing submitted by this service, as only
+// those are re-submitted while they remain pending.
+func (bs *Service) trackSubmission(slashing proto.Message) {
+	root, err := hashutil.HashProto(slashing)
+	if err != nil {
+		log.WithError(err).Error("Could not hash slashing")
+		return
+	}
+	bs.submittedLock.Lock()
+	defer bs.submittedLock.Unlock()
+	if bs.submittedSlashings == nil {
+		bs.submittedSlashings = make(map[[32]byte]bool)
+	}
+	bs.submittedSlashings[root] = true
+}
+
+// untrackSubmission stops tracking a slashing which is no longer pending.
+func (bs *Service) untrackSubmission(root [32]byte) {
+	bs.submittedLock.Lock()
+	defer bs.submittedLock.Unlock()
+	delete(bs.submittedSlashings, root)
+}
+
+// submittedByService returns true if the slashing with the given root was submitted by this service.
+func (bs *Service) submittedByService(root [32]byte) bool {
+	bs.submittedLock.Lock()
+	defer bs.submittedLock.Unlock()
+	return bs.submittedSlashings[root]
+}
+
+// loadPendingSlashings tracks the active and reverted slashings saved to the slasher DB
+// before the slasher restarted as submitted, so that they are re-submitted while they
+// remain pending.
+func (bs *Service) loadPendingSlashings(ctx context.Context) error {
+	ctx, span := trace.StartSpan(ctx, "beaconclient.loadPendingSlashings")
+	defer span.End()
+	for _, status := range []types.SlashingStatus{types.Active, types.Reverted} {
+		attesterSlashings, err := bs.slasherDB.AttesterSlashings(ctx, status)
+		if err != nil {
+			return err
+		}
+		for _, slashing := range attesterSlashings {
+			bs.trackSubmission(slashing)
+		}
+		proposerSlashings, err := bs.slasherDB.ProposalSlashingsByStatus(ctx, status)
+		if err != nil {
+			return err
+		}
+		for _, slashing := range proposerSlashings {
+			bs.trackSubmission(slashing)
+		}
+	}
+	return nil
+}
+
+// resubmitPendingSlashings submits active and reverted slashings to the connected beacon
+// node again, once every resubmitEpochs epochs they remain pending, unless the validators
+// they slash are already slashed. Only slashings this service submitted, or loaded as
+// pending on start, are re-submitted, so slashings saved by other means while the service
+// runs are not. Slashings included in a block which is not finalized yet are not re-submitted.
+func (bs *Service) resubmitPendingSlashings(ctx context.Context, headEpoch uint64) error {
+	ctx, span := trace.StartSpan(ctx, "beaconclient.resubmitPendingSlashings")
+	defer span.End()
+	pending := make(map[[32]byte]bool)
+	// due returns true if the slashing with the given root should be submitted again.
+	due := func(root [32]byte) bool {
+		pending[root] = true
+		if !bs.submittedByService(root) || bs.includedInHeadBlock(root) {
+			return false
+		}
+		submitted, ok := bs.submittedEpochs[root]
+		if !ok {
+			// Pending slashings were submitted on detection.
+			bs.submittedEpochs[root] = headEpoch
+			return false
+		}
+		if headEpoch < submitted+bs.resubmitEpochs {
+			return false
+		}
+		bs.submittedEpochs[root] = headEpoch
+		return true
+	}
+	for _, status := range []types.SlashingStatus{types.Active, types.Reverted} {
+		attesterSlashings, err := bs.slasherDB.AttesterSlashings(ctx, status)
+		if err != nil {
+			return err
+		}
+		for _, slashing := range attesterSlashings {
+			root, err := hashutil.HashProto(slashing)
+			if err != nil {
+				return err
+			}
+			if !due(root) {
+				continue
+			}
+			slashed, err := bs.validatorsSlashed(ctx, attesterSlashingIndices(slashing))
+			if err != nil {
+				return err
+			}
+			if slashed {
+				if _, err := bs.markAttesterSlashingSuperseded(ctx, slashing); err != nil {
+					return err
+				}
+				continue
+			}
+			bs.submitAttesterSlashing(ctx, slashing)
+			slashingsResubmitted.WithLabelValues(attesterSlashingType).Inc()
+		}
+		proposerSlashings, err := bs.slasherDB.ProposalSlashingsByStatus(ctx, status)
+		if err != nil {
+			return err
+		}
+		for _, slashing := range proposerSlashings {
+			root, err := hashutil.HashProto(slashing)
+			if err != nil {
+				return err
+			}
+			if !due(root) {
+				continue
+			}
+			slashed, err := bs.validatorsSlashed(ctx, []uint64{slashing.Header_1.Header.ProposerIndex})
+			if err != nil {
+				return err
+			}
+			if slashed {
+				if _, err := bs.markProposerSlashingSuperseded(ctx, slashing); err != nil {
+					return err
+				}
+				continue
+			}
+			bs.submitProposerSlashing(ctx, slashing)
+			slashingsResubmitted.WithLabelValues(proposerSlashingType).Inc()
+		}
+	}
+	// Slashings which are no longer pending are no longer tracked.
+	// Slashings submitted since they were read are not pending yet, and remain tracked.
+	for root := range bs.submittedEpochs {
+		if !pending[root] {
+			delete(bs.submittedEpochs, root)
+			bs.untrackSubmission(root)
+		}
+	}
+	return nil
+}
@@ -7,8 +7,11 @@ import (
 	"github.com/golang/mock/gomock"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/prysmaticlabs/prysm/shared/event"
+	"github.com/prysmaticlabs/prysm/shared/hashutil"
 	"github.com/prysmaticlabs/prysm/shared/mock"
 	"github.com/prysmaticlabs/prysm/shared/testutil"
+	testDB "github.com/prysmaticlabs/prysm/slasher/db/testing"
+	"github.com/prysmaticlabs/prysm/slasher/db/types"
 	logTest "github.com/sirupsen/logrus/hooks/test"
 )
 
@@ -89,3 +92,45 @@ func TestService_SubscribeDetectedAttesterSlashings(t *testing.T) {
 	exitRoutine <- true
 	testutil.AssertLogsContain(t, hook, "Context canceled")
 }
+
+func TestService_LoadPendingSlashings(t *testing.T) {
+	db := testDB.SetupSlasherDB(t, false)
+	defer testDB.TeardownSlasherDB(t, db)
+	ctx := context.Background()
+
+	active := attesterSlashingForTest(1)
+	reverted := attesterSlashingForTest(2)
+	included := attesterSlashingForTest(3)
+	for status, slashing := range map[types.SlashingStatus]*ethpb.AttesterSlashing{
+		types.Active:   active,
+		types.Reverted: reverted,
+		types.Included: included,
+	} {
+		if err := db.SaveAttesterSlashing(ctx, status, slashing); err != nil {
+			t.Fatal(err)
+		}
+	}
+	bs := &Service{
+		slasherDB:          db,
+		submittedSlashings: make(map[[32]byte]bool),
+	}
+	if err := bs.loadPendingSlashings(ctx); err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range []struct {
+		slashing *ethpb.AttesterSlashing
+		tracked  bool
+	}{
+		{slashing: active, tracked: true},
+		{slashing: reverted, tracked: true},
+		{slashing: included, tracked: false},
+	} {
+		root, err := hashutil.HashProto(tt.slashing)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if bs.submittedByService(root) != tt.tracked {
+			t.Errorf("Expected slashing of validator %d to be tracked %v", tt.slashing.Attestation_1.AttestingIndices[0], tt.tracked)
+		}
+	}
+}
@@ -66,6 +66,18 @@ func (c *EpochSpansCache) Has(epoch uint64) bool {
 	return c.cache.Contains(epoch)
 }
 
+// Keys returns the epochs held in the cache, from oldest to newest.
+func (c *EpochSpansCache) Keys() []uint64 {
+	keys := c.cache.Keys()
+	epochs := make([]uint64, 0, len(keys))
+	for _, k := range keys {
+		if epoch, ok := k.(uint64); ok {
+			epochs = append(epochs, epoch)
+		}
+	}
+	return epochs
+}
+
 // Clear removes all keys from the SpanCache.
 func (c *EpochSpansCache) Clear() {
 	c.cache.Purge()
@@ -26,12 +26,19 @@ type ReadOnlyDatabase interface {
 	IndexedAttestationsForTarget(ctx context.Context, targetEpoch uint64) ([]*ethpb.IndexedAttestation, error)
 	IndexedAttestationsWithPrefix(ctx context.Context, targetEpoch uint64, sigBytes []byte) ([]*ethpb.IndexedAttestation, error)
 	LatestIndexedAttestationsTargetEpoch(ctx context.Context) (uint64, error)
+	ValidatorAttestationDataRoot(ctx context.Context, validatorIdx uint64, targetEpoch uint64) ([32]byte, bool, error)
+	ValidatorAttestation(ctx context.Context, validatorIdx uint64, targetEpoch uint64) (*ethpb.IndexedAttestation, error)
+	AttestationRootsIndexed(ctx context.Context) (bool, error)
 
 	// MinMaxSpan related methods.
 	EpochSpansMap(ctx context.Context, epoch uint64) (map[uint64]detectionTypes.Span, bool, error)
 	EpochSpanByValidatorIndex(ctx context.Context, validatorIdx uint64, epoch uint64) (detectionTypes.Span, error)
 	EpochsSpanByValidatorsIndices(ctx context.Context, validatorIndices []uint64, maxEpoch uint64) (map[uint64]map[uint64]detectionTypes.Span, error)
 
+	// SpanChunk related methods.
+	SpanChunks(ctx context.Context, keys []detectionTypes.ChunkKey) (map[detectionTypes.ChunkKey][]byte, error)
+	SpanChunksMigrated(ctx context.Context) (bool, error)
+
 	// ProposerSlashing related methods.
 	ProposalSlashingsByStatus(ctx context.Context, status types.SlashingStatus) ([]*ethpb.ProposerSlashing, error)
 	HasProposerSlashing(ctx context.Context, slashing *ethpb.ProposerSlashing) (bool, types.SlashingStatus, error)
@@ -41,6 +48,9 @@ type ReadOnlyDatabase interface {
 
 	// Chain data related methods.
 	ChainHead(ctx context.Context) (*ethpb.ChainHead, error)
+
+	// Historical detection related methods.
+	DetectedEpochRanges(ctx context.Context) ([]types.EpochRange, error)
 }
 
 // WriteAccessDatabase represents a write access database with only functions that can modify the DB.
@@ -60,6 +70,7 @@ type WriteAccessDatabase interface {
 	SaveIndexedAttestations(ctx context.Context, idxAttestations []*ethpb.IndexedAttestation) error
 	DeleteIndexedAttestation(ctx context.Context, idxAttestation *ethpb.IndexedAttestation) error
 	PruneAttHistory(ctx context.Context, currentEpoch uint64, pruningEpochAge uint64) error
+	MigrateAttestationRoots(ctx context.Context) error
 
 	// MinMaxSpan related methods.
 	SaveEpochSpansMap(ctx context.Context, epoch uint64, spanMap map[uint64]detectionTypes.Span) error
@@ -69,6 +80,11 @@ type WriteAccessDatabase interface {
 	DeleteEpochSpans(ctx context.Context, validatorIdx uint64) error
 	DeleteValidatorSpanByEpoch(ctx context.Context, validatorIdx uint64, epoch uint64) error
 
+	// SpanChunk related methods.
+	SaveSpanChunks(ctx context.Context, chunks map[detectionTypes.ChunkKey][]byte) error
+	MigrateEpochSpansToChunks(ctx context.Context, params *detectionTypes.ChunkParams) error
+	DeleteSpanChunks(ctx context.Context) error
+
 	// ProposerSlashing related methods.
 	DeleteProposerSlashing(ctx context.Context, slashing *ethpb.ProposerSlashing) error
 	SaveProposerSlashing(ctx context.Context, status types.SlashingStatus, slashing *ethpb.ProposerSlashing) error
@@ -80,6 +96,15 @@ type WriteAccessDatabase interface {
 
 	// Chain data related methods.
 	SaveChainHead(ctx context.Context, head *ethpb.ChainHead) error
+
+	// Pruning related methods.
+	PruneEpochSpans(ctx context.Context, currentEpoch uint64, pruningEpochAge uint64) error
+	PruneSpanChunks(ctx context.Context, params *detectionTypes.ChunkParams, currentEpoch uint64, pruningEpochAge uint64) error
+	PruneSlashings(ctx context.Context, currentEpoch uint64, pruningEpochAge uint64) error
+
+	// Historical detection related methods.
+	SaveDetectedEpochRange(ctx context.Context, epochRange types.EpochRange) error
+	DeleteDetectedEpochRange(ctx context.Context, epochRange types.EpochRange) error
 }
 
 // FullAccessDatabase represents a full access database with only DB interaction functions.
@@ -95,4 +120,6 @@ type Database interface {
 
 	DatabasePath() string
 	ClearDB() error
+	Compact(ctx context.Context) (int64, error)
+	FreeSpace() int64
 }
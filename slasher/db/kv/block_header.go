@@ -7,7 +7,6 @@ import (
 	"github.com/gogo/protobuf/proto"
 	"github.com/pkg/errors"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
-	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
 	"github.com/prysmaticlabs/prysm/shared/params"
 	"github.com/sirupsen/logrus"
@@ -72,14 +71,13 @@ func (db *Store) HasBlockHeader(ctx context.Context, slot uint64, validatorID ui
 func (db *Store) SaveBlockHeader(ctx context.Context, blockHeader *ethpb.SignedBeaconBlockHeader) error {
 	ctx, span := trace.StartSpan(ctx, "slasherDB.SaveBlockHeader")
 	defer span.End()
-	epoch := helpers.SlotToEpoch(blockHeader.Header.Slot)
 	key := encodeSlotValidatorIDSig(blockHeader.Header.Slot, blockHeader.Header.ProposerIndex, blockHeader.Signature)
 	enc, err := proto.Marshal(blockHeader)
 	if err != nil {
 		return errors.Wrap(err, "failed to encode block")
 	}
 
-	err = db.update(func(tx *bolt.Tx) error {
+	return db.update(func(tx *bolt.Tx) error {
 		bucket := tx.Bucket(historicBlockHeadersBucket)
 		if err := bucket.Put(key, enc); err != nil {
 			return errors.Wrap(err, "failed to include block header in the historical bucket")
@@ -87,15 +85,6 @@ func (db *Store) SaveBlockHeader(ctx context.Context, blockHeader *ethpb.SignedB
 
 		return err
 	})
-	if err != nil {
-		return err
-	}
-
-	// Prune block header history every 10th epoch.
-	if epoch%params.BeaconConfig().PruneSlasherStoragePeriod == 0 {
-		return db.PruneBlockHistory(ctx, epoch, params.BeaconConfig().WeakSubjectivityPeriod)
-	}
-	return nil
 }
 
 // DeleteBlockHeader deletes a block header using the slot and validator id.
@@ -120,15 +109,25 @@ func (db *Store) PruneBlockHistory(ctx context.Context, currentEpoch uint64, pru
 	if pruneTill <= 0 {
 		return nil
 	}
-	pruneTillSlot := uint64(pruneTill) * params.BeaconConfig().SlotsPerEpoch
 	return db.update(func(tx *bolt.Tx) error {
 		bucket := tx.Bucket(historicBlockHeadersBucket)
-		c := tx.Bucket(historicBlockHeadersBucket).Cursor()
-		for k, _ := c.First(); k != nil && bytesutil.FromBytes8(k[:8]) <= pruneTillSlot; k, _ = c.Next() {
+		// Slots are encoded little endian, so keys are not ordered by slot.
+		var keys [][]byte
+		if err := bucket.ForEach(func(k, _ []byte) error {
+			// Headers of the whole pruning epoch are removed, as are attestations targeting it.
+			if bytesutil.FromBytes8(k[:8])/params.BeaconConfig().SlotsPerEpoch <= uint64(pruneTill) {
+				keys = append(keys, k)
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+		for _, k := range keys {
 			if err := bucket.Delete(k); err != nil {
 				return errors.Wrap(err, "failed to delete the block header from historical bucket")
 			}
 		}
+		prunedRecords.WithLabelValues("block_headers").Add(float64(len(keys)))
 		return nil
 	})
 }
@@ -233,7 +233,7 @@ func TestPruneHistoryBlkHdr(t *testing.T) {
 		if err != nil {
 			t.Fatalf("failed to get block header: %v", err)
 		}
-		if helpers.SlotToEpoch(tt.bh.Header.Slot) >= currentEpoch-historyToKeep {
+		if helpers.SlotToEpoch(tt.bh.Header.Slot) > currentEpoch-historyToKeep {
 			if bha == nil || !reflect.DeepEqual(bha[0], tt.bh) {
 				t.Fatalf("get should return bh: %v", bha)
 			}
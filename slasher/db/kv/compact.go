@@ -0,0 +1,155 @@
+package kv
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// compactTxMaxSize is the number of bytes copied in a single transaction during compaction.
+const compactTxMaxSize = 64 * 1024 * 1024
+
+// Compact rewrites the database into a new file and replaces the current file with it,
+// returning the size in bytes freed on disk. Bolt never shrinks its file on its own, so
+// space of pruned records is only released to the file system by compacting.
+// Database access is blocked while compacting.
+func (db *Store) Compact(ctx context.Context) (int64, error) {
+	ctx, span := trace.StartSpan(ctx, "slasherDB.Compact")
+	defer span.End()
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	before, err := os.Stat(db.databasePath)
+	if err != nil {
+		return 0, err
+	}
+	compactPath := db.databasePath + ".compact"
+	if err := os.RemoveAll(compactPath); err != nil {
+		return 0, err
+	}
+	dst, err := bolt.Open(compactPath, 0600, &bolt.Options{Timeout: 1 * time.Second})
+	if err != nil {
+		return 0, errors.Wrap(err, "could not open compacted database")
+	}
+	if err := copyDB(ctx, dst, db.db); err != nil {
+		_ = dst.Close()
+		_ = os.Remove(compactPath)
+		return 0, errors.Wrap(err, "could not copy database")
+	}
+	if err := dst.Close(); err != nil {
+		_ = os.Remove(compactPath)
+		return 0, err
+	}
+
+	if err := db.db.Close(); err != nil {
+		_ = os.Remove(compactPath)
+		return 0, err
+	}
+	renameErr := os.Rename(compactPath, db.databasePath)
+	// The database is reopened even if the compacted file could not replace it.
+	boltDB, err := bolt.Open(db.databasePath, 0600, &bolt.Options{Timeout: 1 * time.Second})
+	if err != nil {
+		return 0, errors.Wrap(err, "could not reopen database")
+	}
+	db.db = boltDB
+	if renameErr != nil {
+		_ = os.Remove(compactPath)
+		return 0, errors.Wrap(renameErr, "could not replace database with compacted database")
+	}
+
+	after, err := os.Stat(db.databasePath)
+	if err != nil {
+		return 0, err
+	}
+	return before.Size() - after.Size(), nil
+}
+
+// FreeSpace returns the size in bytes of the free pages of the database file, which hold
+// pruned records and are reused by bolt, but only released to the file system by compacting.
+func (db *Store) FreeSpace() int64 {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+	stats := db.db.Stats()
+	return int64(stats.FreePageN+stats.PendingPageN) * int64(db.db.Info().PageSize)
+}
+
+// copyDB copies all buckets, nested buckets and their key values from src into the empty dst
+// database, committing every compactTxMaxSize bytes.
+func copyDB(ctx context.Context, dst *bolt.DB, src *bolt.DB) error {
+	tx, err := dst.Begin(true)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+	var size int64
+	if err := src.View(func(srcTx *bolt.Tx) error {
+		return srcTx.ForEach(func(name []byte, b *bolt.Bucket) error {
+			return walkBucket(b, nil, name, nil, b.Sequence(), func(keys [][]byte, k []byte, v []byte, seq uint64) error {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				if size += int64(len(k) + len(v)); size > compactTxMaxSize {
+					if err := tx.Commit(); err != nil {
+						return err
+					}
+					if tx, err = dst.Begin(true); err != nil {
+						return err
+					}
+					size = int64(len(k) + len(v))
+				}
+				// Top level buckets are created on the transaction.
+				if len(keys) == 0 {
+					bkt, err := tx.CreateBucket(k)
+					if err != nil {
+						return err
+					}
+					return bkt.SetSequence(seq)
+				}
+				b := tx.Bucket(keys[0])
+				for _, key := range keys[1:] {
+					b = b.Bucket(key)
+				}
+				// Keys are copied in order, so pages are filled completely.
+				b.FillPercent = 1.0
+				if v == nil {
+					bkt, err := b.CreateBucket(k)
+					if err != nil {
+						return err
+					}
+					return bkt.SetSequence(seq)
+				}
+				return b.Put(k, v)
+			})
+		})
+	}); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// walkBucket calls fn for the bucket or key value k, found under the given path of bucket
+// keys, and recursively for all key values of k if it is a bucket, which has a nil value.
+func walkBucket(b *bolt.Bucket, keys [][]byte, k []byte, v []byte, seq uint64, fn func([][]byte, []byte, []byte, uint64) error) error {
+	if err := fn(keys, k, v, seq); err != nil {
+		return err
+	}
+	if v != nil {
+		return nil
+	}
+	path := make([][]byte, len(keys), len(keys)+1)
+	copy(path, keys)
+	path = append(path, k)
+	return b.ForEach(func(k, v []byte) error {
+		if v == nil {
+			nested := b.Bucket(k)
+			return walkBucket(nested, path, k, nil, nested.Sequence(), fn)
+		}
+		return walkBucket(b, path, k, v, b.Sequence(), fn)
+	})
+}
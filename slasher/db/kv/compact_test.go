@@ -0,0 +1,69 @@
+package kv
+
+import (
+	"context"
+	"flag"
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	detectionTypes "github.com/prysmaticlabs/prysm/slasher/detection/attestations/types"
+	"gopkg.in/urfave/cli.v2"
+)
+
+func TestStore_Compact(t *testing.T) {
+	app := cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	db := setupDB(t, cli.NewContext(&app, set, nil))
+	defer teardownDB(t, db)
+	ctx := context.Background()
+	db.EnableSpanCache(false)
+
+	var atts []*ethpb.IndexedAttestation
+	for epoch := uint64(1); epoch <= 1000; epoch++ {
+		atts = append(atts, &ethpb.IndexedAttestation{
+			AttestingIndices: []uint64{epoch},
+			Data: &ethpb.AttestationData{
+				Source: &ethpb.Checkpoint{Epoch: epoch - 1},
+				Target: &ethpb.Checkpoint{Epoch: epoch},
+			},
+			Signature: make([]byte, 96),
+		})
+	}
+	if err := db.SaveIndexedAttestations(ctx, atts); err != nil {
+		t.Fatal(err)
+	}
+	spanMap := map[uint64]detectionTypes.Span{1: {MinSpan: 1, MaxSpan: 2}}
+	if err := db.SaveEpochSpansMap(ctx, 1001, spanMap); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.PruneAttHistory(ctx, 1001, 2); err != nil {
+		t.Fatal(err)
+	}
+	if free := db.FreeSpace(); free <= 0 {
+		t.Errorf("Expected pruning to free pages, free space is %d bytes", free)
+	}
+
+	freed, err := db.Compact(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if freed <= 0 {
+		t.Errorf("Expected compaction to free space, freed %d bytes", freed)
+	}
+	for _, att := range atts[len(atts)-1:] {
+		exists, err := db.HasIndexedAttestation(ctx, att)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !exists {
+			t.Errorf("Expected attestation with target epoch %d to be kept", att.Data.Target.Epoch)
+		}
+	}
+	persisted, _, err := db.EpochSpansMap(ctx, 1001)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if persisted[1] != spanMap[1] {
+		t.Errorf("Expected nested span map %v to be kept, received %v", spanMap, persisted)
+	}
+}
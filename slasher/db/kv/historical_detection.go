@@ -0,0 +1,79 @@
+package kv
+
+import (
+	"context"
+	"sort"
+
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/slasher/db/types"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// DetectedEpochRanges returns the epoch ranges historical slashing detection completed on,
+// sorted by their first epoch.
+func (db *Store) DetectedEpochRanges(ctx context.Context) ([]types.EpochRange, error) {
+	ctx, span := trace.StartSpan(ctx, "slasherDB.DetectedEpochRanges")
+	defer span.End()
+	var ranges []types.EpochRange
+	err := db.view(func(tx *bolt.Tx) error {
+		return tx.Bucket(historicalDetectionBucket).ForEach(func(k, v []byte) error {
+			ranges = append(ranges, types.EpochRange{
+				Start: bytesutil.FromBytes8(k),
+				End:   bytesutil.FromBytes8(v),
+			})
+			return nil
+		})
+	})
+	// Keys are little endian, so they are not iterated in order.
+	sort.Slice(ranges, func(i, j int) bool {
+		return ranges[i].Start < ranges[j].Start
+	})
+	return ranges, err
+}
+
+// SaveDetectedEpochRange marks historical slashing detection as completed on an epoch range.
+func (db *Store) SaveDetectedEpochRange(ctx context.Context, epochRange types.EpochRange) error {
+	ctx, span := trace.StartSpan(ctx, "slasherDB.SaveDetectedEpochRange")
+	defer span.End()
+	return db.update(func(tx *bolt.Tx) error {
+		return tx.Bucket(historicalDetectionBucket).Put(bytesutil.Bytes8(epochRange.Start), bytesutil.Bytes8(epochRange.End))
+	})
+}
+
+// DeleteDetectedEpochRange unmarks the epochs of the given range as detected, so historical
+// slashing detection runs on them again. Detected ranges partially overlapping the given range
+// are trimmed to the epochs outside of it.
+func (db *Store) DeleteDetectedEpochRange(ctx context.Context, epochRange types.EpochRange) error {
+	ctx, span := trace.StartSpan(ctx, "slasherDB.DeleteDetectedEpochRange")
+	defer span.End()
+	return db.update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(historicalDetectionBucket)
+		var overlapping []types.EpochRange
+		if err := b.ForEach(func(k, v []byte) error {
+			r := types.EpochRange{Start: bytesutil.FromBytes8(k), End: bytesutil.FromBytes8(v)}
+			if r.Start <= epochRange.End && r.End >= epochRange.Start {
+				overlapping = append(overlapping, r)
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+		for _, r := range overlapping {
+			if err := b.Delete(bytesutil.Bytes8(r.Start)); err != nil {
+				return err
+			}
+			if r.Start < epochRange.Start {
+				if err := b.Put(bytesutil.Bytes8(r.Start), bytesutil.Bytes8(epochRange.Start-1)); err != nil {
+					return err
+				}
+			}
+			if r.End > epochRange.End {
+				if err := b.Put(bytesutil.Bytes8(epochRange.End+1), bytesutil.Bytes8(r.End)); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,71 @@
+package kv
+
+import (
+	"context"
+	"flag"
+	"reflect"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/slasher/db/types"
+	"gopkg.in/urfave/cli.v2"
+)
+
+func TestStore_DetectedEpochRanges(t *testing.T) {
+	app := cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	db := setupDB(t, cli.NewContext(&app, set, nil))
+	defer teardownDB(t, db)
+	ctx := context.Background()
+
+	saved := []types.EpochRange{
+		{Start: 256, End: 511},
+		{Start: 0, End: 15},
+		{Start: 16, End: 31},
+	}
+	for _, r := range saved {
+		if err := db.SaveDetectedEpochRange(ctx, r); err != nil {
+			t.Fatalf("Failed to save detected epoch range: %v", err)
+		}
+	}
+	ranges, err := db.DetectedEpochRanges(ctx)
+	if err != nil {
+		t.Fatalf("Failed to get detected epoch ranges: %v", err)
+	}
+	want := []types.EpochRange{
+		{Start: 0, End: 15},
+		{Start: 16, End: 31},
+		{Start: 256, End: 511},
+	}
+	if !reflect.DeepEqual(ranges, want) {
+		t.Errorf("Wanted %v, received %v", want, ranges)
+	}
+}
+
+func TestStore_DeleteDetectedEpochRange(t *testing.T) {
+	app := cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	db := setupDB(t, cli.NewContext(&app, set, nil))
+	defer teardownDB(t, db)
+	ctx := context.Background()
+
+	for _, r := range []types.EpochRange{{Start: 0, End: 15}, {Start: 16, End: 31}, {Start: 32, End: 47}} {
+		if err := db.SaveDetectedEpochRange(ctx, r); err != nil {
+			t.Fatalf("Failed to save detected epoch range: %v", err)
+		}
+	}
+	if err := db.DeleteDetectedEpochRange(ctx, types.EpochRange{Start: 10, End: 20}); err != nil {
+		t.Fatalf("Failed to delete detected epoch range: %v", err)
+	}
+	ranges, err := db.DetectedEpochRanges(ctx)
+	if err != nil {
+		t.Fatalf("Failed to get detected epoch ranges: %v", err)
+	}
+	want := []types.EpochRange{
+		{Start: 0, End: 9},
+		{Start: 21, End: 31},
+		{Start: 32, End: 47},
+	}
+	if !reflect.DeepEqual(ranges, want) {
+		t.Errorf("Wanted %v, received %v", want, ranges)
+	}
+}
@@ -8,10 +8,15 @@ import (
 	"github.com/pkg/errors"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/hashutil"
 	bolt "go.etcd.io/bbolt"
 	"go.opencensus.io/trace"
 )
 
+// attRootsMigrationBatchSize is the number of attestations indexed per transaction
+// when indexing the attestations stored before the attestation roots index existed.
+const attRootsMigrationBatchSize = 10000
+
 func unmarshalIndexedAttestation(ctx context.Context, enc []byte) (*ethpb.IndexedAttestation, error) {
 	ctx, span := trace.StartSpan(ctx, "slasherDB.unmarshalIndexedAttestation")
 	defer span.End()
@@ -86,6 +91,47 @@ func (db *Store) HasIndexedAttestation(ctx context.Context, att *ethpb.IndexedAt
 	return hasAttestation, err
 }
 
+// ValidatorAttestationDataRoot returns the data root of the first saved attestation of a validator
+// for the given target epoch, and false if the validator has no attestation saved for the epoch.
+func (db *Store) ValidatorAttestationDataRoot(ctx context.Context, validatorIdx uint64, targetEpoch uint64) ([32]byte, bool, error) {
+	ctx, span := trace.StartSpan(ctx, "slasherDB.ValidatorAttestationDataRoot")
+	defer span.End()
+	var root [32]byte
+	var found bool
+	err := db.view(func(tx *bolt.Tx) error {
+		val := tx.Bucket(attestationRootsByValidatorTargetBucket).Get(encodeEpochValidatorID(targetEpoch, validatorIdx))
+		if val == nil {
+			return nil
+		}
+		copy(root[:], val[:32])
+		found = true
+		return nil
+	})
+	return root, found, err
+}
+
+// ValidatorAttestation returns the first saved attestation of a validator for the given target epoch.
+// Returns nil if the validator has no attestation saved for the epoch.
+func (db *Store) ValidatorAttestation(ctx context.Context, validatorIdx uint64, targetEpoch uint64) (*ethpb.IndexedAttestation, error) {
+	ctx, span := trace.StartSpan(ctx, "slasherDB.ValidatorAttestation")
+	defer span.End()
+	var idxAtt *ethpb.IndexedAttestation
+	err := db.view(func(tx *bolt.Tx) error {
+		val := tx.Bucket(attestationRootsByValidatorTargetBucket).Get(encodeEpochValidatorID(targetEpoch, validatorIdx))
+		if val == nil {
+			return nil
+		}
+		enc := tx.Bucket(historicIndexedAttestationsBucket).Get(encodeEpochSig(targetEpoch, val[32:]))
+		if enc == nil {
+			return nil
+		}
+		var err error
+		idxAtt, err = unmarshalIndexedAttestation(ctx, enc)
+		return err
+	})
+	return idxAtt, err
+}
+
 // SaveIndexedAttestation accepts an indexed attestation and writes it to the DB.
 func (db *Store) SaveIndexedAttestation(ctx context.Context, idxAttestation *ethpb.IndexedAttestation) error {
 	ctx, span := trace.StartSpan(ctx, "slasherDB.SaveIndexedAttestation")
@@ -95,6 +141,10 @@ func (db *Store) SaveIndexedAttestation(ctx context.Context, idxAttestation *eth
 	if err != nil {
 		return errors.Wrap(err, "failed to marshal")
 	}
+	root, err := hashutil.HashProto(idxAttestation.Data)
+	if err != nil {
+		return errors.Wrap(err, "failed to hash attestation data")
+	}
 	err = db.update(func(tx *bolt.Tx) error {
 		bucket := tx.Bucket(historicIndexedAttestationsBucket)
 		//if data is in db skip put and index functions
@@ -105,6 +155,9 @@ func (db *Store) SaveIndexedAttestation(ctx context.Context, idxAttestation *eth
 		if err := bucket.Put(key, enc); err != nil {
 			return errors.Wrap(err, "failed to save indexed attestation into historical bucket")
 		}
+		if err := indexAttestationRoot(tx.Bucket(attestationRootsByValidatorTargetBucket), idxAttestation, root); err != nil {
+			return errors.Wrap(err, "failed to index attestation data root")
+		}
 
 		return err
 	})
@@ -117,17 +170,24 @@ func (db *Store) SaveIndexedAttestations(ctx context.Context, idxAttestations []
 	defer span.End()
 	keys := make([][]byte, len(idxAttestations))
 	marshaledAtts := make([][]byte, len(idxAttestations))
+	roots := make([][32]byte, len(idxAttestations))
 	for i, att := range idxAttestations {
 		enc, err := proto.Marshal(att)
 		if err != nil {
 			return errors.Wrap(err, "failed to marshal")
 		}
+		root, err := hashutil.HashProto(att.Data)
+		if err != nil {
+			return errors.Wrap(err, "failed to hash attestation data")
+		}
 		keys[i] = encodeEpochSig(att.Data.Target.Epoch, att.Signature)
 		marshaledAtts[i] = enc
+		roots[i] = root
 	}
 
 	err := db.update(func(tx *bolt.Tx) error {
 		bucket := tx.Bucket(historicIndexedAttestationsBucket)
+		rootsBucket := tx.Bucket(attestationRootsByValidatorTargetBucket)
 		for i, key := range keys {
 			//if data is in db skip put and index functions
 			val := bucket.Get(key)
@@ -137,6 +197,9 @@ func (db *Store) SaveIndexedAttestations(ctx context.Context, idxAttestations []
 			if err := bucket.Put(key, marshaledAtts[i]); err != nil {
 				return errors.Wrap(err, "failed to save indexed attestation into historical bucket")
 			}
+			if err := indexAttestationRoot(rootsBucket, idxAttestations[i], roots[i]); err != nil {
+				return errors.Wrap(err, "failed to index attestation data root")
+			}
 		}
 		return nil
 	})
@@ -157,6 +220,17 @@ func (db *Store) DeleteIndexedAttestation(ctx context.Context, idxAttestation *e
 		if err := bucket.Delete(key); err != nil {
 			return errors.Wrap(err, "failed to delete indexed attestation from historical bucket")
 		}
+		rootsBucket := tx.Bucket(attestationRootsByValidatorTargetBucket)
+		for _, idx := range idxAttestation.AttestingIndices {
+			rootKey := encodeEpochValidatorID(idxAttestation.Data.Target.Epoch, idx)
+			val := rootsBucket.Get(rootKey)
+			if val == nil || !bytes.Equal(val[32:], idxAttestation.Signature) {
+				continue
+			}
+			if err := rootsBucket.Delete(rootKey); err != nil {
+				return errors.Wrap(err, "failed to delete attestation data root from index")
+			}
+		}
 		return nil
 	})
 }
@@ -172,13 +246,38 @@ func (db *Store) PruneAttHistory(ctx context.Context, currentEpoch uint64, pruni
 
 	return db.update(func(tx *bolt.Tx) error {
 		attBucket := tx.Bucket(historicIndexedAttestationsBucket)
-		c := tx.Bucket(historicIndexedAttestationsBucket).Cursor()
-		max := bytesutil.Bytes8(uint64(pruneFromEpoch))
-		for k, _ := c.First(); k != nil && bytes.Compare(k[:8], max) <= 0; k, _ = c.Next() {
+		// Target epochs are encoded little endian, so keys are not ordered by epoch.
+		var keys [][]byte
+		if err := attBucket.ForEach(func(k, _ []byte) error {
+			if bytesutil.FromBytes8(k[:8]) <= uint64(pruneFromEpoch) {
+				keys = append(keys, k)
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+		for _, k := range keys {
 			if err := attBucket.Delete(k); err != nil {
 				return errors.Wrap(err, "failed to delete indexed attestation from historical bucket")
 			}
 		}
+		prunedRecords.WithLabelValues("attestations").Add(float64(len(keys)))
+
+		rootsBucket := tx.Bucket(attestationRootsByValidatorTargetBucket)
+		var rootKeys [][]byte
+		if err := rootsBucket.ForEach(func(k, _ []byte) error {
+			if bytesutil.FromBytes8(k[:8]) <= uint64(pruneFromEpoch) {
+				rootKeys = append(rootKeys, k)
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+		for _, k := range rootKeys {
+			if err := rootsBucket.Delete(k); err != nil {
+				return errors.Wrap(err, "failed to delete attestation data root from index")
+			}
+		}
 		return nil
 	})
 }
@@ -200,3 +299,88 @@ func (db *Store) LatestIndexedAttestationsTargetEpoch(ctx context.Context) (uint
 	})
 	return lt, err
 }
+
+// indexAttestationRoot saves the data root and signature of an attestation for every attesting
+// validator, unless an attestation of the validator for the same target epoch is indexed already.
+func indexAttestationRoot(bucket *bolt.Bucket, idxAttestation *ethpb.IndexedAttestation, root [32]byte) error {
+	val := append(root[:], idxAttestation.Signature...)
+	for _, idx := range idxAttestation.AttestingIndices {
+		key := encodeEpochValidatorID(idxAttestation.Data.Target.Epoch, idx)
+		if bucket.Get(key) != nil {
+			continue
+		}
+		if err := bucket.Put(key, val); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AttestationRootsIndexed returns true if the attestations saved before the attestation data
+// root index existed have already been indexed.
+func (db *Store) AttestationRootsIndexed(ctx context.Context) (bool, error) {
+	ctx, span := trace.StartSpan(ctx, "slasherDB.AttestationRootsIndexed")
+	defer span.End()
+	var indexed bool
+	err := db.view(func(tx *bolt.Tx) error {
+		indexed = tx.Bucket(chainDataBucket).Get([]byte(attRootsIndexedKey)) != nil
+		return nil
+	})
+	return indexed, err
+}
+
+// MigrateAttestationRoots indexes the data roots of the attestations saved before the attestation
+// data root index existed, attRootsMigrationBatchSize attestations per transaction.
+// The migration is done only once.
+func (db *Store) MigrateAttestationRoots(ctx context.Context) error {
+	ctx, span := trace.StartSpan(ctx, "slasherDB.MigrateAttestationRoots")
+	defer span.End()
+	indexed, err := db.AttestationRootsIndexed(ctx)
+	if err != nil {
+		return err
+	}
+	if indexed {
+		return nil
+	}
+
+	var lastKey []byte
+	for done := false; !done; {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err := db.update(func(tx *bolt.Tx) error {
+			rootsBucket := tx.Bucket(attestationRootsByValidatorTargetBucket)
+			c := tx.Bucket(historicIndexedAttestationsBucket).Cursor()
+			k, enc := c.First()
+			if lastKey != nil {
+				k, enc = c.Seek(lastKey)
+				if k != nil && bytes.Equal(k, lastKey) {
+					k, enc = c.Next()
+				}
+			}
+			for n := 0; k != nil && n < attRootsMigrationBatchSize; n++ {
+				idxAtt, err := unmarshalIndexedAttestation(ctx, enc)
+				if err != nil {
+					return err
+				}
+				root, err := hashutil.HashProto(idxAtt.Data)
+				if err != nil {
+					return errors.Wrap(err, "failed to hash attestation data")
+				}
+				if err := indexAttestationRoot(rootsBucket, idxAtt, root); err != nil {
+					return errors.Wrap(err, "failed to index attestation data root")
+				}
+				lastKey = append(lastKey[:0], k...)
+				k, enc = c.Next()
+			}
+			done = k == nil
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+
+	return db.update(func(tx *bolt.Tx) error {
+		return tx.Bucket(chainDataBucket).Put([]byte(attRootsIndexedKey), []byte{1})
+	})
+}
@@ -6,7 +6,10 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/gogo/protobuf/proto"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/hashutil"
+	bolt "go.etcd.io/bbolt"
 	"gopkg.in/urfave/cli.v2"
 )
 
@@ -803,3 +806,130 @@ func TestPruneHistoryIndexedAttestation(t *testing.T) {
 		}
 	}
 }
+
+func TestValidatorAttestation(t *testing.T) {
+	app := cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	db := setupDB(t, cli.NewContext(&app, set, nil))
+	defer teardownDB(t, db)
+	ctx := context.Background()
+
+	first := &ethpb.IndexedAttestation{
+		AttestingIndices: []uint64{1, 2},
+		Data: &ethpb.AttestationData{
+			Source: &ethpb.Checkpoint{Epoch: 2},
+			Target: &ethpb.Checkpoint{Epoch: 3},
+		},
+		Signature: []byte{1, 2},
+	}
+	second := &ethpb.IndexedAttestation{
+		AttestingIndices: []uint64{2, 3},
+		Data: &ethpb.AttestationData{
+			Source: &ethpb.Checkpoint{Epoch: 1},
+			Target: &ethpb.Checkpoint{Epoch: 3},
+		},
+		Signature: []byte{1, 3},
+	}
+	if err := db.SaveIndexedAttestations(ctx, []*ethpb.IndexedAttestation{first, second}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The first attestation of validator 2 for the target epoch is kept.
+	want := map[uint64]*ethpb.IndexedAttestation{1: first, 2: first, 3: second, 4: nil}
+	for idx, wantAtt := range want {
+		root, found, err := db.ValidatorAttestationDataRoot(ctx, idx, 3)
+		if err != nil {
+			t.Fatal(err)
+		}
+		att, err := db.ValidatorAttestation(ctx, idx, 3)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if wantAtt == nil {
+			if found || att != nil {
+				t.Errorf("Expected no attestation of validator %d, received %v", idx, att)
+			}
+			continue
+		}
+		wantRoot, err := hashutil.HashProto(wantAtt.Data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !found || root != wantRoot {
+			t.Errorf("Expected data root %#x for validator %d, received %#x", wantRoot, idx, root)
+		}
+		if !proto.Equal(att, wantAtt) {
+			t.Errorf("Expected attestation %v for validator %d, received %v", wantAtt, idx, att)
+		}
+	}
+
+	if err := db.DeleteIndexedAttestation(ctx, first); err != nil {
+		t.Fatal(err)
+	}
+	for _, idx := range []uint64{1, 2} {
+		if _, found, err := db.ValidatorAttestationDataRoot(ctx, idx, 3); err != nil || found {
+			t.Errorf("Expected deleted attestation of validator %d to be removed from the index: %v", idx, err)
+		}
+	}
+	if err := db.PruneAttHistory(ctx, 4, 1); err != nil {
+		t.Fatal(err)
+	}
+	if _, found, err := db.ValidatorAttestationDataRoot(ctx, 3, 3); err != nil || found {
+		t.Errorf("Expected pruned attestation to be removed from the index: %v", err)
+	}
+}
+
+func TestMigrateAttestationRoots(t *testing.T) {
+	app := cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	db := setupDB(t, cli.NewContext(&app, set, nil))
+	defer teardownDB(t, db)
+	ctx := context.Background()
+
+	// Attestations saved before the index existed are only in the historic attestations bucket.
+	var atts []*ethpb.IndexedAttestation
+	for epoch := uint64(1); epoch <= 3; epoch++ {
+		atts = append(atts, &ethpb.IndexedAttestation{
+			AttestingIndices: []uint64{epoch},
+			Data: &ethpb.AttestationData{
+				Source: &ethpb.Checkpoint{Epoch: epoch - 1},
+				Target: &ethpb.Checkpoint{Epoch: epoch},
+			},
+			Signature: []byte{byte(epoch)},
+		})
+	}
+	if err := db.update(func(tx *bolt.Tx) error {
+		for _, att := range atts {
+			enc, err := proto.Marshal(att)
+			if err != nil {
+				return err
+			}
+			if err := tx.Bucket(historicIndexedAttestationsBucket).Put(encodeEpochSig(att.Data.Target.Epoch, att.Signature), enc); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := db.MigrateAttestationRoots(ctx); err != nil {
+		t.Fatal(err)
+	}
+	indexed, err := db.AttestationRootsIndexed(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !indexed {
+		t.Error("Expected attestation roots to be indexed")
+	}
+	for _, att := range atts {
+		received, err := db.ValidatorAttestation(ctx, att.AttestingIndices[0], att.Data.Target.Epoch)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !proto.Equal(received, att) {
+			t.Errorf("Expected attestation %v, received %v", att, received)
+		}
+	}
+}
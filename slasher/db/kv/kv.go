@@ -3,6 +3,7 @@ package kv
 import (
 	"os"
 	"path"
+	"sync"
 	"time"
 
 	"github.com/pkg/errors"
@@ -15,6 +16,8 @@ var databaseFileName = "slasher.db"
 // Store defines an implementation of the slasher Database interface
 // using BoltDB as the underlying persistent kv-store for eth2.
 type Store struct {
+	// lock guards the bolt db, which is reopened on compaction.
+	lock             sync.RWMutex
 	db               *bolt.DB
 	databasePath     string
 	spanCache        *cache.EpochSpansCache
@@ -29,6 +32,8 @@ type Config struct {
 
 // Close closes the underlying boltdb database.
 func (db *Store) Close() error {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
 	return db.db.Close()
 }
 
@@ -38,12 +43,18 @@ func (db *Store) ClearSpanCache() {
 }
 
 func (db *Store) update(fn func(*bolt.Tx) error) error {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
 	return db.db.Update(fn)
 }
 func (db *Store) batch(fn func(*bolt.Tx) error) error {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
 	return db.db.Batch(fn)
 }
 func (db *Store) view(fn func(*bolt.Tx) error) error {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
 	return db.db.View(fn)
 }
 
@@ -98,10 +109,13 @@ func NewKVStore(dirPath string, cfg *Config) (*Store, error) {
 			indexedAttestationsBucket,
 			indexedAttestationsRootsByTargetBucket,
 			historicIndexedAttestationsBucket,
+			attestationRootsByValidatorTargetBucket,
 			historicBlockHeadersBucket,
 			compressedIdxAttsBucket,
 			validatorsPublicKeysBucket,
 			validatorsMinMaxSpanBucket,
+			validatorsSpanChunksBucket,
+			historicalDetectionBucket,
 			slashingBucket,
 			chainDataBucket,
 		)
@@ -115,7 +129,7 @@ func NewKVStore(dirPath string, cfg *Config) (*Store, error) {
 // Size returns the db size in bytes.
 func (db *Store) Size() (int64, error) {
 	var size int64
-	err := db.db.View(func(tx *bolt.Tx) error {
+	err := db.view(func(tx *bolt.Tx) error {
 		size = tx.Size()
 		return nil
 	})
@@ -0,0 +1,157 @@
+package kv
+
+import (
+	"context"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/slasher/db/types"
+	detectionTypes "github.com/prysmaticlabs/prysm/slasher/detection/attestations/types"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+var prunedRecords = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "slasher_pruned_records_total",
+	Help: "The number of records pruned from the slasher database by kind",
+}, []string{"kind"})
+
+// PruneEpochSpans removes the span maps of all epochs older than the pruning epoch age,
+// both from the span cache and the db.
+func (db *Store) PruneEpochSpans(ctx context.Context, currentEpoch uint64, pruningEpochAge uint64) error {
+	ctx, span := trace.StartSpan(ctx, "slasherDB.PruneEpochSpans")
+	defer span.End()
+	pruneTill := int64(currentEpoch) - int64(pruningEpochAge)
+	if pruneTill <= 0 {
+		return nil
+	}
+	// Removing an epoch from the cache persists its span map, so the cache is
+	// pruned first and the persisted span maps are removed along with the rest.
+	for _, epoch := range db.spanCache.Keys() {
+		if epoch <= uint64(pruneTill) {
+			_ = db.spanCache.Delete(epoch)
+		}
+	}
+	return db.update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(validatorsMinMaxSpanBucket)
+		// Epoch bucket keys are little endian, so they are not iterated in order.
+		var keys [][]byte
+		if err := bucket.ForEach(func(k, v []byte) error {
+			// Epoch span maps are stored as nested buckets, which have nil values.
+			if v == nil && bytesutil.FromBytes8(k) <= uint64(pruneTill) {
+				keys = append(keys, k)
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+		for _, k := range keys {
+			if err := bucket.DeleteBucket(k); err != nil {
+				return errors.Wrap(err, "failed to delete epoch span map")
+			}
+		}
+		prunedRecords.WithLabelValues("epoch_spans").Add(float64(len(keys)))
+		return nil
+	})
+}
+
+// PruneSpanChunks removes all span chunks of the given dimensions which only hold
+// epochs older than the pruning epoch age.
+func (db *Store) PruneSpanChunks(ctx context.Context, params *detectionTypes.ChunkParams, currentEpoch uint64, pruningEpochAge uint64) error {
+	ctx, span := trace.StartSpan(ctx, "slasherDB.PruneSpanChunks")
+	defer span.End()
+	pruneTill := int64(currentEpoch) - int64(pruningEpochAge)
+	if pruneTill <= 0 {
+		return nil
+	}
+	return db.update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(validatorsSpanChunksBucket)
+		var keys [][]byte
+		if err := bucket.ForEach(func(k, _ []byte) error {
+			key, err := detectionTypes.UnmarshalChunkKey(k)
+			if err != nil {
+				return err
+			}
+			lastEpoch := (key.EpochChunk+1)*params.EpochChunkSize - 1
+			if lastEpoch <= uint64(pruneTill) {
+				keys = append(keys, k)
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+		for _, k := range keys {
+			if err := bucket.Delete(k); err != nil {
+				return errors.Wrap(err, "failed to delete span chunk")
+			}
+		}
+		prunedRecords.WithLabelValues("span_chunks").Add(float64(len(keys)))
+		return nil
+	})
+}
+
+// PruneSlashings removes all slashings which have been included on chain and are
+// older than the pruning epoch age. Active and reverted slashings are kept.
+func (db *Store) PruneSlashings(ctx context.Context, currentEpoch uint64, pruningEpochAge uint64) error {
+	ctx, span := trace.StartSpan(ctx, "slasherDB.PruneSlashings")
+	defer span.End()
+	pruneTill := int64(currentEpoch) - int64(pruningEpochAge)
+	if pruneTill <= 0 {
+		return nil
+	}
+	return db.update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(slashingBucket)
+		var keys [][]byte
+		if err := bucket.ForEach(func(k, v []byte) error {
+			if len(v) == 0 || v[0] != byte(types.Included) {
+				return nil
+			}
+			epoch, err := slashingEpoch(types.SlashingType(k[0]), v[1:])
+			if err != nil {
+				return err
+			}
+			if epoch <= uint64(pruneTill) {
+				keys = append(keys, k)
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+		for _, k := range keys {
+			if err := bucket.Delete(k); err != nil {
+				return errors.Wrap(err, "failed to delete slashing")
+			}
+		}
+		prunedRecords.WithLabelValues("slashings").Add(float64(len(keys)))
+		return nil
+	})
+}
+
+// slashingEpoch returns the epoch of the latest message of an encoded slashing.
+func slashingEpoch(slashingType types.SlashingType, enc []byte) (uint64, error) {
+	switch slashingType {
+	case types.Attestation:
+		slashing, err := unmarshalAttSlashing(enc)
+		if err != nil {
+			return 0, err
+		}
+		epoch := slashing.Attestation_1.GetData().GetTarget().GetEpoch()
+		if e := slashing.Attestation_2.GetData().GetTarget().GetEpoch(); e > epoch {
+			epoch = e
+		}
+		return epoch, nil
+	case types.Proposal:
+		slashing := &ethpb.ProposerSlashing{}
+		if err := proto.Unmarshal(enc, slashing); err != nil {
+			return 0, errors.Wrap(err, "failed to unmarshal encoded proposer slashing")
+		}
+		return slashing.Header_1.GetHeader().GetSlot() / params.BeaconConfig().SlotsPerEpoch, nil
+	default:
+		return 0, errors.Errorf("unknown slashing type %d", slashingType)
+	}
+}
@@ -0,0 +1,214 @@
+package kv
+
+import (
+	"context"
+	"flag"
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/slasher/db/types"
+	detectionTypes "github.com/prysmaticlabs/prysm/slasher/detection/attestations/types"
+	"gopkg.in/urfave/cli.v2"
+)
+
+func TestStore_PruneAttHistory_UnorderedEpochs(t *testing.T) {
+	app := cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	db := setupDB(t, cli.NewContext(&app, set, nil))
+	defer teardownDB(t, db)
+	ctx := context.Background()
+
+	// Little endian keys of epochs 255 and 256 are not ordered by epoch.
+	var atts []*ethpb.IndexedAttestation
+	for _, epoch := range []uint64{255, 256, 300} {
+		att := &ethpb.IndexedAttestation{
+			AttestingIndices: []uint64{1},
+			Data: &ethpb.AttestationData{
+				Source: &ethpb.Checkpoint{},
+				Target: &ethpb.Checkpoint{Epoch: epoch},
+			},
+			Signature: []byte{1},
+		}
+		if err := db.SaveIndexedAttestation(ctx, att); err != nil {
+			t.Fatal(err)
+		}
+		atts = append(atts, att)
+	}
+	if err := db.PruneAttHistory(ctx, 300, 44); err != nil {
+		t.Fatal(err)
+	}
+	for _, att := range atts {
+		exists, err := db.HasIndexedAttestation(ctx, att)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if pruned := att.Data.Target.Epoch <= 256; exists == pruned {
+			t.Errorf("Expected attestation with target epoch %d to exist: %v", att.Data.Target.Epoch, !pruned)
+		}
+	}
+}
+
+func TestStore_PruneEpochSpans(t *testing.T) {
+	app := cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	db := setupDB(t, cli.NewContext(&app, set, nil))
+	defer teardownDB(t, db)
+	ctx := context.Background()
+
+	spanMap := map[uint64]detectionTypes.Span{1: {MinSpan: 1, MaxSpan: 2}}
+	// Epoch 1 is persisted, the remaining epochs are only held in the span cache.
+	db.EnableSpanCache(false)
+	if err := db.SaveEpochSpansMap(ctx, 1, spanMap); err != nil {
+		t.Fatal(err)
+	}
+	db.EnableSpanCache(true)
+	for epoch := uint64(2); epoch <= 5; epoch++ {
+		if err := db.SaveEpochSpansMap(ctx, epoch, spanMap); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := db.PruneEpochSpans(ctx, 5, 2); err != nil {
+		t.Fatal(err)
+	}
+	for epoch := uint64(1); epoch <= 5; epoch++ {
+		if pruned := epoch <= 3; db.spanCache.Has(epoch) == pruned {
+			t.Errorf("Expected epoch %d to be cached: %v", epoch, !pruned)
+		}
+	}
+	db.EnableSpanCache(false)
+	for epoch := uint64(1); epoch <= 3; epoch++ {
+		persisted, _, err := db.EpochSpansMap(ctx, epoch)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(persisted) != 0 {
+			t.Errorf("Expected span map of epoch %d to be pruned, received %v", epoch, persisted)
+		}
+	}
+}
+
+func TestStore_PruneSpanChunks(t *testing.T) {
+	app := cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	db := setupDB(t, cli.NewContext(&app, set, nil))
+	defer teardownDB(t, db)
+	ctx := context.Background()
+	params := &detectionTypes.ChunkParams{ValidatorChunkSize: 2, EpochChunkSize: 4}
+
+	var keys []detectionTypes.ChunkKey
+	chunks := make(map[detectionTypes.ChunkKey][]byte)
+	for _, epoch := range []uint64{0, 4, 8} {
+		key := params.Key(detectionTypes.MinSpanChunk, 0, epoch)
+		chunks[key] = detectionTypes.NewSpanChunk(detectionTypes.MinSpanChunk, params).Bytes()
+		keys = append(keys, key)
+	}
+	if err := db.SaveSpanChunks(ctx, chunks); err != nil {
+		t.Fatal(err)
+	}
+
+	// Epoch chunk 1 holds epochs 4 to 7, of which epoch 7 is kept.
+	if err := db.PruneSpanChunks(ctx, params, 10, 4); err != nil {
+		t.Fatal(err)
+	}
+	remaining, err := db.SpanChunks(ctx, keys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range keys {
+		if _, ok := remaining[key]; ok != (key.EpochChunk > 0) {
+			t.Errorf("Expected epoch chunk %d to exist: %v", key.EpochChunk, key.EpochChunk > 0)
+		}
+	}
+}
+
+func TestStore_PruneSlashings(t *testing.T) {
+	app := cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	db := setupDB(t, cli.NewContext(&app, set, nil))
+	defer teardownDB(t, db)
+	ctx := context.Background()
+
+	attesterSlashing := func(targetEpoch uint64) *ethpb.AttesterSlashing {
+		att := func(sig byte) *ethpb.IndexedAttestation {
+			return &ethpb.IndexedAttestation{
+				AttestingIndices: []uint64{1},
+				Data: &ethpb.AttestationData{
+					Source: &ethpb.Checkpoint{},
+					Target: &ethpb.Checkpoint{Epoch: targetEpoch},
+				},
+				Signature: []byte{sig},
+			}
+		}
+		return &ethpb.AttesterSlashing{Attestation_1: att(1), Attestation_2: att(2)}
+	}
+	proposerSlashing := func(epoch uint64) *ethpb.ProposerSlashing {
+		header := func(sig byte) *ethpb.SignedBeaconBlockHeader {
+			return &ethpb.SignedBeaconBlockHeader{
+				Header:    &ethpb.BeaconBlockHeader{Slot: epoch * params.BeaconConfig().SlotsPerEpoch},
+				Signature: []byte{sig},
+			}
+		}
+		return &ethpb.ProposerSlashing{Header_1: header(1), Header_2: header(2)}
+	}
+
+	oldIncluded := attesterSlashing(1)
+	oldActive := attesterSlashing(2)
+	newIncluded := attesterSlashing(20)
+	if err := db.SaveAttesterSlashing(ctx, types.Included, oldIncluded); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveAttesterSlashing(ctx, types.Active, oldActive); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveAttesterSlashing(ctx, types.Included, newIncluded); err != nil {
+		t.Fatal(err)
+	}
+	oldIncludedProposal := proposerSlashing(1)
+	newIncludedProposal := proposerSlashing(20)
+	if err := db.SaveProposerSlashing(ctx, types.Included, oldIncludedProposal); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveProposerSlashing(ctx, types.Included, newIncludedProposal); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := db.PruneSlashings(ctx, 20, 10); err != nil {
+		t.Fatal(err)
+	}
+
+	attesterTests := []struct {
+		slashing *ethpb.AttesterSlashing
+		exists   bool
+	}{
+		{slashing: oldIncluded, exists: false},
+		{slashing: oldActive, exists: true},
+		{slashing: newIncluded, exists: true},
+	}
+	for i, tt := range attesterTests {
+		exists, _, err := db.HasAttesterSlashing(ctx, tt.slashing)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if exists != tt.exists {
+			t.Errorf("Attester slashing %d: expected to exist %v, received %v", i, tt.exists, exists)
+		}
+	}
+	proposerTests := []struct {
+		slashing *ethpb.ProposerSlashing
+		exists   bool
+	}{
+		{slashing: oldIncludedProposal, exists: false},
+		{slashing: newIncludedProposal, exists: true},
+	}
+	for i, tt := range proposerTests {
+		exists, _, err := db.HasProposerSlashing(ctx, tt.slashing)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if exists != tt.exists {
+			t.Errorf("Proposer slashing %d: expected to exist %v, received %v", i, tt.exists, exists)
+		}
+	}
+}
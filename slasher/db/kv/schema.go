@@ -6,10 +6,12 @@ import (
 )
 
 const (
-	latestEpochKey       = "LATEST_EPOCH_DETECTED"
-	chainHeadKey         = "CHAIN_HEAD"
-	cachedSpanerEpochs   = 256
-	spannerEncodedLength = 7
+	latestEpochKey        = "LATEST_EPOCH_DETECTED"
+	chainHeadKey          = "CHAIN_HEAD"
+	spanChunksMigratedKey = "SPAN_CHUNKS_MIGRATED"
+	attRootsIndexedKey    = "ATTESTATION_ROOTS_INDEXED"
+	cachedSpanerEpochs    = 256
+	spannerEncodedLength  = 7
 )
 
 var (
@@ -26,6 +28,13 @@ var (
 	// the min and max span for each validator for each epoch.
 	// see https://github.com/protolambda/eth2-surround/blob/master/README.md#min-max-surround
 	validatorsMinMaxSpanBucket = []byte("validators-min-max-span-bucket")
+	// Min and max spans stored in fixed size chunks of validators and epochs, keyed by chunk index.
+	validatorsSpanChunksBucket = []byte("validators-span-chunks-bucket")
+	// Epoch ranges historical slashing detection completed on, keyed by the first epoch of the range.
+	historicalDetectionBucket = []byte("historical-detection-bucket")
+	// Attestation data root and signature of the first attestation of a validator for a target epoch,
+	// keyed by target epoch and validator index, used to find the attestations slashing proofs consist of.
+	attestationRootsByValidatorTargetBucket = []byte("attestation-roots-by-validator-target-bucket")
 )
 
 func encodeSlotValidatorID(slot uint64, validatorID uint64) []byte {
@@ -36,6 +45,10 @@ func encodeSlotValidatorIDSig(slot uint64, validatorID uint64, sig []byte) []byt
 	return append(append(bytesutil.Bytes8(slot), bytesutil.Bytes8(validatorID)...), sig...)
 }
 
+func encodeEpochValidatorID(targetEpoch uint64, validatorID uint64) []byte {
+	return append(bytesutil.Bytes8(targetEpoch), bytesutil.Bytes8(validatorID)...)
+}
+
 func encodeEpochSig(targetEpoch uint64, sig []byte) []byte {
 	return append(bytesutil.Bytes8(targetEpoch), sig...)
 }
@@ -0,0 +1,171 @@
+package kv
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/slasher/detection/attestations/types"
+	log "github.com/sirupsen/logrus"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// SpanChunks accepts chunk keys and returns the encoded span chunks stored under them.
+// Chunks which do not exist in the db are left out of the returned map.
+func (db *Store) SpanChunks(ctx context.Context, keys []types.ChunkKey) (map[types.ChunkKey][]byte, error) {
+	ctx, span := trace.StartSpan(ctx, "slasherDB.SpanChunks")
+	defer span.End()
+	chunks := make(map[types.ChunkKey][]byte, len(keys))
+	err := db.view(func(tx *bolt.Tx) error {
+		b := tx.Bucket(validatorsSpanChunksBucket)
+		for _, key := range keys {
+			enc := b.Get(key.Marshal())
+			if enc == nil {
+				continue
+			}
+			// Bolt values are only valid for the lifetime of the transaction.
+			chunk := make([]byte, len(enc))
+			copy(chunk, enc)
+			chunks[key] = chunk
+		}
+		return nil
+	})
+	return chunks, err
+}
+
+// SaveSpanChunks accepts encoded span chunks by their keys and writes them to db in a single transaction.
+func (db *Store) SaveSpanChunks(ctx context.Context, chunks map[types.ChunkKey][]byte) error {
+	ctx, span := trace.StartSpan(ctx, "slasherDB.SaveSpanChunks")
+	defer span.End()
+	return db.update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(validatorsSpanChunksBucket)
+		for key, chunk := range chunks {
+			if err := b.Put(key.Marshal(), chunk); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// SpanChunksMigrated returns true if epoch span maps have already been migrated into span chunks.
+func (db *Store) SpanChunksMigrated(ctx context.Context) (bool, error) {
+	ctx, span := trace.StartSpan(ctx, "slasherDB.SpanChunksMigrated")
+	defer span.End()
+	var migrated bool
+	err := db.view(func(tx *bolt.Tx) error {
+		migrated = tx.Bucket(chainDataBucket).Get([]byte(spanChunksMigratedKey)) != nil
+		return nil
+	})
+	return migrated, err
+}
+
+// MigrateEpochSpansToChunks copies the span maps stored per epoch in the validatorsMinMaxSpanBucket
+// into span chunks of the given dimensions. Cached span maps are persisted first. Epochs are migrated
+// one epoch chunk at a time, so only the chunks of a single epoch chunk are held in memory.
+// The migration is done only once, the original span maps are left untouched.
+func (db *Store) MigrateEpochSpansToChunks(ctx context.Context, params *types.ChunkParams) error {
+	ctx, span := trace.StartSpan(ctx, "slasherDB.MigrateEpochSpansToChunks")
+	defer span.End()
+	migrated, err := db.SpanChunksMigrated(ctx)
+	if err != nil {
+		return err
+	}
+	if migrated {
+		return nil
+	}
+	if err := db.SaveCachedSpansMaps(ctx); err != nil {
+		return errors.Wrap(err, "could not save cached span maps")
+	}
+
+	// Epoch bucket keys are little endian, so they are not iterated in order.
+	var epochs []uint64
+	if err := db.view(func(tx *bolt.Tx) error {
+		return tx.Bucket(validatorsMinMaxSpanBucket).ForEach(func(k, v []byte) error {
+			// Epoch span maps are stored as nested buckets, which have nil values.
+			if v == nil {
+				epochs = append(epochs, bytesutil.FromBytes8(k))
+			}
+			return nil
+		})
+	}); err != nil {
+		return err
+	}
+	sort.Slice(epochs, func(i, j int) bool {
+		return epochs[i] < epochs[j]
+	})
+
+	for len(epochs) > 0 {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		epochChunk := epochs[0] / params.EpochChunkSize
+		n := sort.Search(len(epochs), func(i int) bool {
+			return epochs[i]/params.EpochChunkSize > epochChunk
+		})
+		if err := db.migrateEpochChunk(ctx, params, epochs[:n]); err != nil {
+			return errors.Wrapf(err, "could not migrate epoch chunk %d", epochChunk)
+		}
+		log.Debugf("Migrated span maps of epochs %d to %d", epochs[0], epochs[n-1])
+		epochs = epochs[n:]
+	}
+
+	return db.update(func(tx *bolt.Tx) error {
+		return tx.Bucket(chainDataBucket).Put([]byte(spanChunksMigratedKey), []byte{1})
+	})
+}
+
+// migrateEpochChunk migrates span maps of the given epochs, which all belong to the same epoch chunk.
+func (db *Store) migrateEpochChunk(ctx context.Context, params *types.ChunkParams, epochs []uint64) error {
+	chunks := make(map[types.ChunkKey]*types.SpanChunk)
+	chunk := func(kind types.ChunkKind, validatorIdx uint64, epoch uint64) *types.SpanChunk {
+		key := params.Key(kind, validatorIdx, epoch)
+		c, ok := chunks[key]
+		if !ok {
+			c = types.NewSpanChunk(kind, params)
+			chunks[key] = c
+		}
+		return c
+	}
+	for _, epoch := range epochs {
+		spanMap, _, err := db.EpochSpansMap(ctx, epoch)
+		if err != nil {
+			return err
+		}
+		for idx, s := range spanMap {
+			if s.MinSpan > 0 {
+				chunk(types.MinSpanChunk, idx, epoch).SetSpan(idx, epoch, s.MinSpan)
+			}
+			if s.MaxSpan > 0 {
+				chunk(types.MaxSpanChunk, idx, epoch).SetSpan(idx, epoch, s.MaxSpan)
+			}
+			if s.HasAttested {
+				chunk(types.AttestedChunk, idx, epoch).SetAttested(idx, epoch, s.SigBytes)
+			}
+		}
+	}
+	enc := make(map[types.ChunkKey][]byte, len(chunks))
+	for key, c := range chunks {
+		enc[key] = c.Bytes()
+	}
+	return db.SaveSpanChunks(ctx, enc)
+}
+
+// DeleteSpanChunks removes all span chunks and the mark that epoch span maps were migrated
+// into span chunks. Span chunks are not updated while they are disabled, so they are dropped,
+// and span maps are migrated again once span chunks are enabled.
+func (db *Store) DeleteSpanChunks(ctx context.Context) error {
+	ctx, span := trace.StartSpan(ctx, "slasherDB.DeleteSpanChunks")
+	defer span.End()
+	return db.update(func(tx *bolt.Tx) error {
+		if err := tx.DeleteBucket(validatorsSpanChunksBucket); err != nil {
+			return errors.Wrap(err, "failed to delete span chunks bucket")
+		}
+		if _, err := tx.CreateBucket(validatorsSpanChunksBucket); err != nil {
+			return errors.Wrap(err, "failed to create span chunks bucket")
+		}
+		return tx.Bucket(chainDataBucket).Delete([]byte(spanChunksMigratedKey))
+	})
+}
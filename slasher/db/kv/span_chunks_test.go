@@ -0,0 +1,147 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/slasher/detection/attestations/types"
+	"gopkg.in/urfave/cli.v2"
+)
+
+func TestStore_SaveSpanChunks(t *testing.T) {
+	app := cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	db := setupDB(t, cli.NewContext(&app, set, nil))
+	defer teardownDB(t, db)
+	ctx := context.Background()
+	params := &types.ChunkParams{ValidatorChunkSize: 4, EpochChunkSize: 2}
+
+	minChunk := types.NewSpanChunk(types.MinSpanChunk, params)
+	minChunk.SetSpan(5, 3, 7)
+	minKey := params.Key(types.MinSpanChunk, 5, 3)
+	missingKey := params.Key(types.MaxSpanChunk, 5, 3)
+	if err := db.SaveSpanChunks(ctx, map[types.ChunkKey][]byte{minKey: minChunk.Bytes()}); err != nil {
+		t.Fatalf("Failed to save span chunks: %v", err)
+	}
+
+	chunks, err := db.SpanChunks(ctx, []types.ChunkKey{minKey, missingKey})
+	if err != nil {
+		t.Fatalf("Failed to get span chunks: %v", err)
+	}
+	if len(chunks) != 1 {
+		t.Fatalf("Expected 1 span chunk, received %d", len(chunks))
+	}
+	if !bytes.Equal(chunks[minKey], minChunk.Bytes()) {
+		t.Errorf("Wanted span chunk %v, received %v", minChunk.Bytes(), chunks[minKey])
+	}
+}
+
+func TestStore_MigrateEpochSpansToChunks(t *testing.T) {
+	app := cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	db := setupDB(t, cli.NewContext(&app, set, nil))
+	defer teardownDB(t, db)
+	ctx := context.Background()
+	params := &types.ChunkParams{ValidatorChunkSize: 2, EpochChunkSize: 2}
+
+	for _, tt := range spanTests {
+		if err := db.SaveEpochSpansMap(ctx, tt.epoch, tt.spanMap); err != nil {
+			t.Fatalf("Save validator span map failed: %v", err)
+		}
+	}
+	if err := db.MigrateEpochSpansToChunks(ctx, params); err != nil {
+		t.Fatalf("Failed to migrate span maps: %v", err)
+	}
+	migrated, err := db.SpanChunksMigrated(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !migrated {
+		t.Error("Expected span chunks to be marked as migrated")
+	}
+
+	for _, tt := range spanTests {
+		for idx, want := range tt.spanMap {
+			keys := []types.ChunkKey{
+				params.Key(types.MinSpanChunk, idx, tt.epoch),
+				params.Key(types.MaxSpanChunk, idx, tt.epoch),
+				params.Key(types.AttestedChunk, idx, tt.epoch),
+			}
+			chunks, err := db.SpanChunks(ctx, keys)
+			if err != nil {
+				t.Fatalf("Failed to get span chunks: %v", err)
+			}
+			minChunk, err := types.SpanChunkFromBytes(types.MinSpanChunk, params, chunks[keys[0]])
+			if err != nil {
+				t.Fatal(err)
+			}
+			maxChunk, err := types.SpanChunkFromBytes(types.MaxSpanChunk, params, chunks[keys[1]])
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := minChunk.Span(idx, tt.epoch); got != want.MinSpan {
+				t.Errorf("Wanted min span %d for validator %d at epoch %d, received %d", want.MinSpan, idx, tt.epoch, got)
+			}
+			if got := maxChunk.Span(idx, tt.epoch); got != want.MaxSpan {
+				t.Errorf("Wanted max span %d for validator %d at epoch %d, received %d", want.MaxSpan, idx, tt.epoch, got)
+			}
+			attested := false
+			var sigBytes [2]byte
+			if enc, ok := chunks[keys[2]]; ok {
+				attestedChunk, err := types.SpanChunkFromBytes(types.AttestedChunk, params, enc)
+				if err != nil {
+					t.Fatal(err)
+				}
+				attested, sigBytes = attestedChunk.Attested(idx, tt.epoch)
+			}
+			if attested != want.HasAttested {
+				t.Errorf("Wanted attested %t for validator %d at epoch %d, received %t", want.HasAttested, idx, tt.epoch, attested)
+			}
+			if attested && sigBytes != want.SigBytes {
+				t.Errorf("Wanted sig bytes %v, received %v", want.SigBytes, sigBytes)
+			}
+		}
+	}
+}
+
+func TestStore_DeleteSpanChunks(t *testing.T) {
+	app := cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	db := setupDB(t, cli.NewContext(&app, set, nil))
+	defer teardownDB(t, db)
+	ctx := context.Background()
+	params := &types.ChunkParams{ValidatorChunkSize: 2, EpochChunkSize: 2}
+
+	for _, tt := range spanTests {
+		if err := db.SaveEpochSpansMap(ctx, tt.epoch, tt.spanMap); err != nil {
+			t.Fatalf("Save validator span map failed: %v", err)
+		}
+	}
+	if err := db.MigrateEpochSpansToChunks(ctx, params); err != nil {
+		t.Fatalf("Failed to migrate span maps: %v", err)
+	}
+	if err := db.DeleteSpanChunks(ctx); err != nil {
+		t.Fatalf("Failed to delete span chunks: %v", err)
+	}
+	migrated, err := db.SpanChunksMigrated(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if migrated {
+		t.Error("Expected span chunks not to be marked as migrated")
+	}
+	for _, tt := range spanTests {
+		for idx := range tt.spanMap {
+			key := params.Key(types.MinSpanChunk, idx, tt.epoch)
+			chunks, err := db.SpanChunks(ctx, []types.ChunkKey{key})
+			if err != nil {
+				t.Fatalf("Failed to get span chunks: %v", err)
+			}
+			if len(chunks) != 0 {
+				t.Errorf("Expected no span chunk for validator %d at epoch %d", idx, tt.epoch)
+			}
+		}
+	}
+}
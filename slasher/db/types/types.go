@@ -52,3 +52,9 @@ func (status SlashingType) String() string {
 	}
 	return names[status]
 }
+
+// EpochRange is an inclusive range of epochs.
+type EpochRange struct {
+	Start uint64
+	End   uint64
+}
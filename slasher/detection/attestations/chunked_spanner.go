@@ -0,0 +1,377 @@
+package attestations
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	db "github.com/prysmaticlabs/prysm/slasher/db"
+	"github.com/prysmaticlabs/prysm/slasher/detection/attestations/iface"
+	"github.com/prysmaticlabs/prysm/slasher/detection/attestations/types"
+	"go.opencensus.io/trace"
+)
+
+var _ = iface.SpanDetector(&ChunkedSpanDetector{})
+
+// ChunkedSpanDetector defines a struct which can detect slashable
+// attestation offenses the same way as SpanDetector, but stores
+// min-max spans in fixed size chunks of validators and epochs
+// instead of a span map per epoch.
+type ChunkedSpanDetector struct {
+	slasherDB db.Database
+	params    *types.ChunkParams
+	lock      sync.RWMutex
+}
+
+// NewChunkedSpanDetector creates a new instance of a struct tracking
+// min-max spans for each validator in chunks of the given dimensions.
+func NewChunkedSpanDetector(db db.Database, params *types.ChunkParams) *ChunkedSpanDetector {
+	return &ChunkedSpanDetector{
+		slasherDB: db,
+		params:    params,
+	}
+}
+
+// DetectSlashingsForAttestation uses a validator index and its corresponding
+// min-max spans during an epoch to detect an epoch in which the validator
+// committed a slashable attestation.
+func (s *ChunkedSpanDetector) DetectSlashingsForAttestation(
+	ctx context.Context,
+	att *ethpb.IndexedAttestation,
+) ([]*types.DetectionResult, error) {
+	ctx, traceSpan := trace.StartSpan(ctx, "chunkedSpanner.DetectSlashingsForAttestation")
+	defer traceSpan.End()
+	if err := checkSpanDistance(att); err != nil {
+		return nil, err
+	}
+
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return detectWithChunks(ctx, newChunkSet(s.slasherDB, s.params), att)
+}
+
+// ValidatorSpans returns the min-max spans of a validator for every epoch of an
+// inclusive epoch range, read from the span chunks.
+func (s *ChunkedSpanDetector) ValidatorSpans(ctx context.Context, validatorIdx uint64, startEpoch uint64, endEpoch uint64) ([]types.Span, error) {
+	ctx, traceSpan := trace.StartSpan(ctx, "chunkedSpanner.ValidatorSpans")
+	defer traceSpan.End()
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	chunks := newChunkSet(s.slasherDB, s.params)
+	spans := make([]types.Span, 0, endEpoch-startEpoch+1)
+	for epoch := startEpoch; epoch <= endEpoch; epoch++ {
+		minSpan, err := chunks.span(ctx, types.MinSpanChunk, validatorIdx, epoch)
+		if err != nil {
+			return nil, err
+		}
+		maxSpan, err := chunks.span(ctx, types.MaxSpanChunk, validatorIdx, epoch)
+		if err != nil {
+			return nil, err
+		}
+		attested, sigBytes, err := chunks.attested(ctx, validatorIdx, epoch)
+		if err != nil {
+			return nil, err
+		}
+		spans = append(spans, types.Span{
+			MinSpan:     minSpan,
+			MaxSpan:     maxSpan,
+			SigBytes:    sigBytes,
+			HasAttested: attested,
+		})
+	}
+	return spans, nil
+}
+
+// Detects slashable offenses of an attestation against the spans in the given chunks.
+func detectWithChunks(ctx context.Context, chunks *chunkSet, att *ethpb.IndexedAttestation) ([]*types.DetectionResult, error) {
+	sourceEpoch := att.Data.Source.Epoch
+	targetEpoch := att.Data.Target.Epoch
+	var detections []*types.DetectionResult
+	distance := uint16(targetEpoch - sourceEpoch)
+	for _, idx := range att.AttestingIndices {
+		minSpan, err := chunks.span(ctx, types.MinSpanChunk, idx, sourceEpoch)
+		if err != nil {
+			return nil, err
+		}
+		if minSpan > 0 && minSpan < distance {
+			slashableEpoch := sourceEpoch + uint64(minSpan)
+			detections = append(detections, &types.DetectionResult{
+				ValidatorIndex: idx,
+				Kind:           types.SurroundVote,
+				SlashableEpoch: slashableEpoch,
+			})
+			continue
+		}
+
+		maxSpan, err := chunks.span(ctx, types.MaxSpanChunk, idx, sourceEpoch)
+		if err != nil {
+			return nil, err
+		}
+		if maxSpan > distance {
+			slashableEpoch := sourceEpoch + uint64(maxSpan)
+			detections = append(detections, &types.DetectionResult{
+				ValidatorIndex: idx,
+				Kind:           types.SurroundVote,
+				SlashableEpoch: slashableEpoch,
+			})
+			continue
+		}
+
+		// Check if the validator has attested for this epoch or not.
+		attested, _, err := chunks.attested(ctx, idx, targetEpoch)
+		if err != nil {
+			return nil, err
+		}
+		if attested {
+			detections = append(detections, &types.DetectionResult{
+				ValidatorIndex: idx,
+				Kind:           types.DoubleVote,
+				SlashableEpoch: targetEpoch,
+			})
+		}
+	}
+
+	return detections, nil
+}
+
+// UpdateSpans given an indexed attestation for all of its attesting indices.
+func (s *ChunkedSpanDetector) UpdateSpans(ctx context.Context, att *ethpb.IndexedAttestation) error {
+	return s.UpdateSpansBatch(ctx, []*ethpb.IndexedAttestation{att})
+}
+
+// UpdateSpansBatch updates min-max spans for all attesting indices of the given attestations.
+// Chunks are loaded once for the whole batch, updated in memory and written back to the
+// database in a single transaction.
+func (s *ChunkedSpanDetector) UpdateSpansBatch(ctx context.Context, atts []*ethpb.IndexedAttestation) error {
+	ctx, traceSpan := trace.StartSpan(ctx, "chunkedSpanner.UpdateSpansBatch")
+	defer traceSpan.End()
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	chunks := newChunkSet(s.slasherDB, s.params)
+	for _, att := range atts {
+		if err := updateChunks(ctx, chunks, att); err != nil {
+			return err
+		}
+	}
+	return chunks.save(ctx)
+}
+
+// DetectAndUpdateSpans runs detection for a batch of attestations in order. Spans of the attesting
+// validators, except those with a slashing confirmed from the detection results, are updated in
+// memory along the way, so each attestation is also checked against the earlier attestations of
+// the batch, and written to the database once.
+// Attestations spanning more than the weak subjectivity period are skipped.
+func (s *ChunkedSpanDetector) DetectAndUpdateSpans(
+	ctx context.Context,
+	atts []*ethpb.IndexedAttestation,
+	confirm iface.SlashingConfirmer,
+) ([][]*types.DetectionResult, error) {
+	ctx, traceSpan := trace.StartSpan(ctx, "chunkedSpanner.DetectAndUpdateSpans")
+	defer traceSpan.End()
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	chunks := newChunkSet(s.slasherDB, s.params)
+	results := make([][]*types.DetectionResult, len(atts))
+	for i, att := range atts {
+		if checkSpanDistance(att) != nil {
+			continue
+		}
+		detections, err := detectWithChunks(ctx, chunks, att)
+		if err != nil {
+			return nil, err
+		}
+		results[i] = detections
+		att, err := withoutSlashedIndices(ctx, att, detections, confirm)
+		if err != nil {
+			return nil, err
+		}
+		if len(att.AttestingIndices) == 0 {
+			continue
+		}
+		if err := updateChunks(ctx, chunks, att); err != nil {
+			return nil, err
+		}
+	}
+	if err := chunks.save(ctx); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// Updates the spans in the given chunks for all attesting indices of an attestation.
+func updateChunks(ctx context.Context, chunks *chunkSet, att *ethpb.IndexedAttestation) error {
+	source := att.Data.Source.Epoch
+	target := att.Data.Target.Epoch
+	latestMinSpanDistanceObserved.Set(float64(target - source))
+	latestMaxSpanDistanceObserved.Set(float64(target - source))
+	sigBytes := [2]byte{0, 0}
+	if len(att.Signature) > 1 {
+		sigBytes = [2]byte{att.Signature[0], att.Signature[1]}
+	}
+	for _, idx := range att.AttestingIndices {
+		if err := chunks.setAttested(ctx, idx, target, sigBytes); err != nil {
+			return err
+		}
+		if err := updateMinSpanChunks(ctx, chunks, idx, source, target); err != nil {
+			return err
+		}
+		if err := updateMaxSpanChunks(ctx, chunks, idx, source, target); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Spans are only tracked for attestations within the weak subjectivity period.
+func checkSpanDistance(att *ethpb.IndexedAttestation) error {
+	distance := att.Data.Target.Epoch - att.Data.Source.Epoch
+	if distance > params.BeaconConfig().WeakSubjectivityPeriod {
+		return fmt.Errorf(
+			"attestation span was greater than weak subjectivity period %d, received: %d",
+			params.BeaconConfig().WeakSubjectivityPeriod,
+			distance,
+		)
+	}
+	return nil
+}
+
+// Updates min spans of a validator for all epochs before the source epoch,
+// stopping at the first epoch which already has a lower min span.
+func updateMinSpanChunks(ctx context.Context, chunks *chunkSet, idx uint64, source uint64, target uint64) error {
+	if source < 1 {
+		return nil
+	}
+	for epoch := source - 1; ; epoch-- {
+		newMinSpan := uint16(target - epoch)
+		minSpan, err := chunks.span(ctx, types.MinSpanChunk, idx, epoch)
+		if err != nil {
+			return err
+		}
+		if minSpan > 0 && minSpan <= newMinSpan {
+			return nil
+		}
+		if err := chunks.setSpan(ctx, types.MinSpanChunk, idx, epoch, newMinSpan); err != nil {
+			return err
+		}
+		if epoch == 0 {
+			return nil
+		}
+	}
+}
+
+// Updates max spans of a validator for all epochs between the source and target epochs,
+// stopping at the first epoch which already has a higher max span.
+func updateMaxSpanChunks(ctx context.Context, chunks *chunkSet, idx uint64, source uint64, target uint64) error {
+	for epoch := source + 1; epoch < target; epoch++ {
+		newMaxSpan := uint16(target - epoch)
+		maxSpan, err := chunks.span(ctx, types.MaxSpanChunk, idx, epoch)
+		if err != nil {
+			return err
+		}
+		if newMaxSpan <= maxSpan {
+			return nil
+		}
+		if err := chunks.setSpan(ctx, types.MaxSpanChunk, idx, epoch, newMaxSpan); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// chunkSet holds span chunks loaded from the database, so that each chunk is read
+// at most once per batch, and keeps track of modified chunks to be written back.
+type chunkSet struct {
+	slasherDB db.Database
+	params    *types.ChunkParams
+	chunks    map[types.ChunkKey]*types.SpanChunk
+	dirty     map[types.ChunkKey]bool
+}
+
+func newChunkSet(slasherDB db.Database, params *types.ChunkParams) *chunkSet {
+	return &chunkSet{
+		slasherDB: slasherDB,
+		params:    params,
+		chunks:    make(map[types.ChunkKey]*types.SpanChunk),
+		dirty:     make(map[types.ChunkKey]bool),
+	}
+}
+
+// chunk returns the chunk holding the value of a validator at an epoch, an empty
+// chunk is returned if none exists in the database yet.
+func (cs *chunkSet) chunk(ctx context.Context, kind types.ChunkKind, idx uint64, epoch uint64) (types.ChunkKey, *types.SpanChunk, error) {
+	key := cs.params.Key(kind, idx, epoch)
+	if c, ok := cs.chunks[key]; ok {
+		return key, c, nil
+	}
+	encoded, err := cs.slasherDB.SpanChunks(ctx, []types.ChunkKey{key})
+	if err != nil {
+		return key, nil, err
+	}
+	c := types.NewSpanChunk(kind, cs.params)
+	if enc, ok := encoded[key]; ok {
+		c, err = types.SpanChunkFromBytes(kind, cs.params, enc)
+		if err != nil {
+			return key, nil, err
+		}
+	}
+	cs.chunks[key] = c
+	return key, c, nil
+}
+
+func (cs *chunkSet) span(ctx context.Context, kind types.ChunkKind, idx uint64, epoch uint64) (uint16, error) {
+	_, c, err := cs.chunk(ctx, kind, idx, epoch)
+	if err != nil {
+		return 0, err
+	}
+	return c.Span(idx, epoch), nil
+}
+
+func (cs *chunkSet) setSpan(ctx context.Context, kind types.ChunkKind, idx uint64, epoch uint64, span uint16) error {
+	key, c, err := cs.chunk(ctx, kind, idx, epoch)
+	if err != nil {
+		return err
+	}
+	c.SetSpan(idx, epoch, span)
+	cs.dirty[key] = true
+	return nil
+}
+
+func (cs *chunkSet) attested(ctx context.Context, idx uint64, epoch uint64) (bool, [2]byte, error) {
+	_, c, err := cs.chunk(ctx, types.AttestedChunk, idx, epoch)
+	if err != nil {
+		return false, [2]byte{}, err
+	}
+	attested, sigBytes := c.Attested(idx, epoch)
+	return attested, sigBytes, nil
+}
+
+// setAttested records the signature bytes of a validator attestation for a target epoch,
+// unless the validator already attested for it.
+func (cs *chunkSet) setAttested(ctx context.Context, idx uint64, epoch uint64, sigBytes [2]byte) error {
+	key, c, err := cs.chunk(ctx, types.AttestedChunk, idx, epoch)
+	if err != nil {
+		return err
+	}
+	if attested, _ := c.Attested(idx, epoch); attested {
+		return nil
+	}
+	c.SetAttested(idx, epoch, sigBytes)
+	cs.dirty[key] = true
+	return nil
+}
+
+// save writes all modified chunks to the database.
+func (cs *chunkSet) save(ctx context.Context) error {
+	if len(cs.dirty) == 0 {
+		return nil
+	}
+	encoded := make(map[types.ChunkKey][]byte, len(cs.dirty))
+	for key := range cs.dirty {
+		encoded[key] = cs.chunks[key].Bytes()
+	}
+	return cs.slasherDB.SaveSpanChunks(ctx, encoded)
+}
@@ -0,0 +1,240 @@
+package attestations
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/slasher/db/kv"
+	testDB "github.com/prysmaticlabs/prysm/slasher/db/testing"
+	"github.com/prysmaticlabs/prysm/slasher/detection/attestations/iface"
+	"github.com/prysmaticlabs/prysm/slasher/detection/attestations/types"
+)
+
+func TestChunkedSpanDetector_MatchesSpanDetector(t *testing.T) {
+	tests := []struct {
+		name        string
+		atts        []*ethpb.IndexedAttestation
+		incomingAtt *ethpb.IndexedAttestation
+		slashCount  int
+	}{
+		{
+			name:        "double vote",
+			atts:        []*ethpb.IndexedAttestation{indexedAttestation(0, 2, []uint64{1, 2})},
+			incomingAtt: indexedAttestation(1, 2, []uint64{2}),
+			slashCount:  1,
+		},
+		{
+			name:        "surrounding vote",
+			atts:        []*ethpb.IndexedAttestation{indexedAttestation(3, 4, []uint64{1, 2, 3})},
+			incomingAtt: indexedAttestation(2, 5, []uint64{1, 3}),
+			slashCount:  2,
+		},
+		{
+			name:        "surrounded vote",
+			atts:        []*ethpb.IndexedAttestation{indexedAttestation(1, 20, []uint64{1, 2})},
+			incomingAtt: indexedAttestation(3, 18, []uint64{1, 2, 3}),
+			slashCount:  2,
+		},
+		{
+			name: "vote spanning chunks",
+			atts: []*ethpb.IndexedAttestation{
+				indexedAttestation(2, 3, []uint64{5}),
+				indexedAttestation(30, 31, []uint64{5}),
+			},
+			incomingAtt: indexedAttestation(1, 40, []uint64{5}),
+			slashCount:  1,
+		},
+		{
+			name: "consecutive votes",
+			atts: []*ethpb.IndexedAttestation{
+				indexedAttestation(0, 1, []uint64{1, 2}),
+				indexedAttestation(1, 2, []uint64{1, 2}),
+			},
+			incomingAtt: indexedAttestation(2, 3, []uint64{1, 2}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			db := testDB.SetupSlasherDB(t, false)
+			defer testDB.TeardownSlasherDB(t, db)
+
+			detectors := []iface.SpanDetector{
+				NewSpanDetector(db),
+				NewChunkedSpanDetector(db, &types.ChunkParams{ValidatorChunkSize: 2, EpochChunkSize: 4}),
+			}
+			var results [][]*types.DetectionResult
+			for _, sd := range detectors {
+				for _, att := range tt.atts {
+					if err := sd.UpdateSpans(ctx, att); err != nil {
+						t.Fatal(err)
+					}
+				}
+				res, err := sd.DetectSlashingsForAttestation(ctx, tt.incomingAtt)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(res) != tt.slashCount {
+					t.Errorf("%T: wanted %d detections, received %d", sd, tt.slashCount, len(res))
+				}
+				results = append(results, res)
+			}
+			if !reflect.DeepEqual(results[0], results[1]) {
+				t.Errorf("Detection results differ:\n%v\n%v", results[0], results[1])
+			}
+		})
+	}
+}
+
+func TestChunkedSpanDetector_UpdateSpansBatch(t *testing.T) {
+	ctx := context.Background()
+	db := testDB.SetupSlasherDB(t, false)
+	defer testDB.TeardownSlasherDB(t, db)
+	sd := NewChunkedSpanDetector(db, types.DefaultChunkParams())
+
+	atts := []*ethpb.IndexedAttestation{
+		indexedAttestation(2, 3, []uint64{1}),
+		indexedAttestation(3, 4, []uint64{1, 2}),
+	}
+	if err := sd.UpdateSpansBatch(ctx, atts); err != nil {
+		t.Fatal(err)
+	}
+	res, err := sd.DetectSlashingsForAttestation(ctx, indexedAttestation(1, 5, []uint64{1, 2}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*types.DetectionResult{
+		{ValidatorIndex: 1, Kind: types.SurroundVote, SlashableEpoch: 3},
+		{ValidatorIndex: 2, Kind: types.SurroundVote, SlashableEpoch: 4},
+	}
+	if !reflect.DeepEqual(res, want) {
+		t.Errorf("Wanted %v, received %v", want, res)
+	}
+}
+
+func TestChunkedSpanDetector_ValidatorSpansMatchSpanDetector(t *testing.T) {
+	ctx := context.Background()
+	db := testDB.SetupSlasherDB(t, false)
+	defer testDB.TeardownSlasherDB(t, db)
+	detectors := []iface.SpanDetector{
+		NewSpanDetector(db),
+		NewChunkedSpanDetector(db, &types.ChunkParams{ValidatorChunkSize: 2, EpochChunkSize: 4}),
+	}
+	atts := []*ethpb.IndexedAttestation{
+		indexedAttestation(1, 2, []uint64{3}),
+		indexedAttestation(2, 9, []uint64{3}),
+	}
+	var spans [][]types.Span
+	for _, sd := range detectors {
+		for _, att := range atts {
+			if err := sd.UpdateSpans(ctx, att); err != nil {
+				t.Fatal(err)
+			}
+		}
+		res, err := sd.ValidatorSpans(ctx, 3, 0, 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(res) != 11 {
+			t.Fatalf("%T: wanted spans for 11 epochs, received %d", sd, len(res))
+		}
+		spans = append(spans, res)
+	}
+	if !reflect.DeepEqual(spans[0], spans[1]) {
+		t.Errorf("Validator spans differ:\n%v\n%v", spans[0], spans[1])
+	}
+}
+
+// committeeAttestations returns attestations of all validators for a target epoch,
+// split into committees of the given size.
+func committeeAttestations(numValidators uint64, committeeSize uint64, target uint64) []*ethpb.IndexedAttestation {
+	var atts []*ethpb.IndexedAttestation
+	for start := uint64(0); start < numValidators; start += committeeSize {
+		indices := make([]uint64, 0, committeeSize)
+		for idx := start; idx < start+committeeSize && idx < numValidators; idx++ {
+			indices = append(indices, idx)
+		}
+		atts = append(atts, indexedAttestation(target-1, target, indices))
+	}
+	return atts
+}
+
+func benchmarkUpdateSpans(b *testing.B, newDetector func(db *kv.Store) iface.SpanDetector, numValidators uint64) {
+	ctx := context.Background()
+	db := testDB.SetupSlasherDB(b, false)
+	defer testDB.TeardownSlasherDB(b, db)
+	sd := newDetector(db)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, att := range committeeAttestations(numValidators, 128, uint64(i)+1) {
+			if err := sd.UpdateSpans(ctx, att); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func benchmarkDetectSlashings(b *testing.B, newDetector func(db *kv.Store) iface.SpanDetector, numValidators uint64) {
+	ctx := context.Background()
+	db := testDB.SetupSlasherDB(b, false)
+	defer testDB.TeardownSlasherDB(b, db)
+	sd := newDetector(db)
+	for epoch := uint64(1); epoch <= 16; epoch++ {
+		for _, att := range committeeAttestations(numValidators, 128, epoch) {
+			if err := sd.UpdateSpans(ctx, att); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+	atts := committeeAttestations(numValidators, 128, 17)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, att := range atts {
+			if _, err := sd.DetectSlashingsForAttestation(ctx, att); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func spanDetector(db *kv.Store) iface.SpanDetector {
+	return NewSpanDetector(db)
+}
+
+func chunkedSpanDetector(db *kv.Store) iface.SpanDetector {
+	return NewChunkedSpanDetector(db, types.DefaultChunkParams())
+}
+
+func BenchmarkSpanDetector_UpdateSpans(b *testing.B) {
+	benchmarkUpdateSpans(b, spanDetector, 4096)
+}
+
+func BenchmarkChunkedSpanDetector_UpdateSpans(b *testing.B) {
+	benchmarkUpdateSpans(b, chunkedSpanDetector, 4096)
+}
+
+func BenchmarkChunkedSpanDetector_UpdateSpansBatch(b *testing.B) {
+	ctx := context.Background()
+	db := testDB.SetupSlasherDB(b, false)
+	defer testDB.TeardownSlasherDB(b, db)
+	sd := NewChunkedSpanDetector(db, types.DefaultChunkParams())
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := sd.UpdateSpansBatch(ctx, committeeAttestations(4096, 128, uint64(i)+1)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkSpanDetector_DetectSlashings(b *testing.B) {
+	benchmarkDetectSlashings(b, spanDetector, 4096)
+}
+
+func BenchmarkChunkedSpanDetector_DetectSlashings(b *testing.B) {
+	benchmarkDetectSlashings(b, chunkedSpanDetector, 4096)
+}
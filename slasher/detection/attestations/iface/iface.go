@@ -7,6 +7,15 @@ import (
 	"github.com/prysmaticlabs/prysm/slasher/detection/attestations/types"
 )
 
+// SlashingConfirmer confirms the offenses detected for an attestation against the attestations
+// they conflict with, and returns the indices of the attesting validators with a confirmed
+// slashing. Spans are not updated for those validators.
+type SlashingConfirmer func(
+	ctx context.Context,
+	att *ethpb.IndexedAttestation,
+	results []*types.DetectionResult,
+) ([]uint64, error)
+
 // SpanDetector defines an interface for Spanners to follow to allow mocks.
 type SpanDetector interface {
 	// Read functions.
@@ -14,7 +23,20 @@ type SpanDetector interface {
 		ctx context.Context,
 		att *ethpb.IndexedAttestation,
 	) ([]*types.DetectionResult, error)
+	ValidatorSpans(
+		ctx context.Context,
+		validatorIdx uint64,
+		startEpoch uint64,
+		endEpoch uint64,
+	) ([]types.Span, error)
 
 	// Write functions.
 	UpdateSpans(ctx context.Context, att *ethpb.IndexedAttestation) error
+
+	// Batch functions.
+	DetectAndUpdateSpans(
+		ctx context.Context,
+		atts []*ethpb.IndexedAttestation,
+		confirm SlashingConfirmer,
+	) ([][]*types.DetectionResult, error)
 }
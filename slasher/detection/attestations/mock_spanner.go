@@ -39,7 +39,6 @@ func (s *MockSpanDetector) DetectSlashingsForAttestation(
 		detections = append(detections, &types.DetectionResult{
 			Kind:           types.SurroundVote,
 			SlashableEpoch: att.Data.Target.Epoch - 1,
-			SigBytes:       [2]byte{1, 2},
 		})
 		return detections, nil
 	// If the target epoch is >= 6 < 12, it will "detect" a surrounding saved attestation.
@@ -47,7 +46,6 @@ func (s *MockSpanDetector) DetectSlashingsForAttestation(
 		detections = append(detections, &types.DetectionResult{
 			Kind:           types.SurroundVote,
 			SlashableEpoch: att.Data.Target.Epoch + 1,
-			SigBytes:       [2]byte{1, 2},
 		})
 		return detections, nil
 	// If the target epoch is less than 6, it will "detect" a double vote.
@@ -55,7 +53,6 @@ func (s *MockSpanDetector) DetectSlashingsForAttestation(
 		detections = append(detections, &types.DetectionResult{
 			Kind:           types.DoubleVote,
 			SlashableEpoch: att.Data.Target.Epoch,
-			SigBytes:       [2]byte{1, 2},
 		})
 	}
 	return detections, nil
@@ -67,6 +64,11 @@ func (s *MockSpanDetector) SpanForEpochByValidator(ctx context.Context, valIdx u
 	return types.Span{MinSpan: 0, MaxSpan: 0, SigBytes: [2]byte{}, HasAttested: false}, nil
 }
 
+// ValidatorSpans mocks the spans of a validator for an epoch range, returning empty spans.
+func (s *MockSpanDetector) ValidatorSpans(ctx context.Context, validatorIdx uint64, startEpoch uint64, endEpoch uint64) ([]types.Span, error) {
+	return make([]types.Span, endEpoch-startEpoch+1), nil
+}
+
 // ValidatorSpansByEpoch returns a list of all validator spans in a given epoch.
 func (s *MockSpanDetector) ValidatorSpansByEpoch(ctx context.Context, epoch uint64) map[uint64]types.Span {
 	return make(map[uint64]types.Span, 0)
@@ -81,3 +83,26 @@ func (s *MockSpanDetector) DeleteValidatorSpansByEpoch(ctx context.Context, vali
 func (s *MockSpanDetector) UpdateSpans(ctx context.Context, att *ethpb.IndexedAttestation) error {
 	return nil
 }
+
+// DetectAndUpdateSpans runs the mocked detection for each of the given attestations,
+// and confirms the detected offenses.
+func (s *MockSpanDetector) DetectAndUpdateSpans(
+	ctx context.Context,
+	atts []*ethpb.IndexedAttestation,
+	confirm iface.SlashingConfirmer,
+) ([][]*types.DetectionResult, error) {
+	results := make([][]*types.DetectionResult, len(atts))
+	for i, att := range atts {
+		detections, err := s.DetectSlashingsForAttestation(ctx, att)
+		if err != nil {
+			return nil, err
+		}
+		results[i] = detections
+		if len(detections) > 0 {
+			if _, err := confirm(ctx, att, detections); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return results, nil
+}
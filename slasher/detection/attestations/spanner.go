@@ -8,6 +8,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/shared/sliceutil"
 	db "github.com/prysmaticlabs/prysm/slasher/db"
 	"github.com/prysmaticlabs/prysm/slasher/detection/attestations/iface"
 	"github.com/prysmaticlabs/prysm/slasher/detection/attestations/types"
@@ -83,15 +84,10 @@ func (s *SpanDetector) DetectSlashingsForAttestation(
 		minSpan := span.MinSpan
 		if minSpan > 0 && minSpan < distance {
 			slashableEpoch := sourceEpoch + uint64(minSpan)
-			targetSpan, err := s.slasherDB.EpochSpanByValidatorIndex(ctx, idx, slashableEpoch)
-			if err != nil {
-				return nil, err
-			}
 			detections = append(detections, &types.DetectionResult{
 				ValidatorIndex: idx,
 				Kind:           types.SurroundVote,
 				SlashableEpoch: slashableEpoch,
-				SigBytes:       targetSpan.SigBytes,
 			})
 			continue
 		}
@@ -99,15 +95,10 @@ func (s *SpanDetector) DetectSlashingsForAttestation(
 		maxSpan := span.MaxSpan
 		if maxSpan > distance {
 			slashableEpoch := sourceEpoch + uint64(maxSpan)
-			targetSpan, err := s.slasherDB.EpochSpanByValidatorIndex(ctx, idx, slashableEpoch)
-			if err != nil {
-				return nil, err
-			}
 			detections = append(detections, &types.DetectionResult{
 				ValidatorIndex: idx,
 				Kind:           types.SurroundVote,
 				SlashableEpoch: slashableEpoch,
-				SigBytes:       targetSpan.SigBytes,
 			})
 			continue
 		}
@@ -119,7 +110,6 @@ func (s *SpanDetector) DetectSlashingsForAttestation(
 				ValidatorIndex: idx,
 				Kind:           types.DoubleVote,
 				SlashableEpoch: targetEpoch,
-				SigBytes:       targetSpan.SigBytes,
 			})
 			continue
 		}
@@ -128,6 +118,22 @@ func (s *SpanDetector) DetectSlashingsForAttestation(
 	return detections, nil
 }
 
+// ValidatorSpans returns the min-max spans of a validator for every epoch of an
+// inclusive epoch range.
+func (s *SpanDetector) ValidatorSpans(ctx context.Context, validatorIdx uint64, startEpoch uint64, endEpoch uint64) ([]types.Span, error) {
+	ctx, traceSpan := trace.StartSpan(ctx, "spanner.ValidatorSpans")
+	defer traceSpan.End()
+	spans := make([]types.Span, 0, endEpoch-startEpoch+1)
+	for epoch := startEpoch; epoch <= endEpoch; epoch++ {
+		span, err := s.slasherDB.EpochSpanByValidatorIndex(ctx, validatorIdx, epoch)
+		if err != nil {
+			return nil, err
+		}
+		spans = append(spans, span)
+	}
+	return spans, nil
+}
+
 // UpdateSpans given an indexed attestation for all of its attesting indices.
 func (s *SpanDetector) UpdateSpans(ctx context.Context, att *ethpb.IndexedAttestation) error {
 	ctx, span := trace.StartSpan(ctx, "spanner.UpdateSpans")
@@ -146,6 +152,66 @@ func (s *SpanDetector) UpdateSpans(ctx context.Context, att *ethpb.IndexedAttest
 	return nil
 }
 
+// DetectAndUpdateSpans runs detection for a batch of attestations in order, updating spans
+// of the attesting validators one attestation at a time, except for the validators with a
+// slashing confirmed from the detection results.
+// Attestations spanning more than the weak subjectivity period are skipped.
+func (s *SpanDetector) DetectAndUpdateSpans(
+	ctx context.Context,
+	atts []*ethpb.IndexedAttestation,
+	confirm iface.SlashingConfirmer,
+) ([][]*types.DetectionResult, error) {
+	ctx, span := trace.StartSpan(ctx, "spanner.DetectAndUpdateSpans")
+	defer span.End()
+	results := make([][]*types.DetectionResult, len(atts))
+	for i, att := range atts {
+		if checkSpanDistance(att) != nil {
+			continue
+		}
+		detections, err := s.DetectSlashingsForAttestation(ctx, att)
+		if err != nil {
+			return nil, err
+		}
+		results[i] = detections
+		att, err := withoutSlashedIndices(ctx, att, detections, confirm)
+		if err != nil {
+			return nil, err
+		}
+		if len(att.AttestingIndices) == 0 {
+			continue
+		}
+		if err := s.UpdateSpans(ctx, att); err != nil {
+			return nil, err
+		}
+	}
+	return results, nil
+}
+
+// withoutSlashedIndices returns the attestation without the attesting validators with
+// a slashing confirmed from the detection results.
+func withoutSlashedIndices(
+	ctx context.Context,
+	att *ethpb.IndexedAttestation,
+	detections []*types.DetectionResult,
+	confirm iface.SlashingConfirmer,
+) (*ethpb.IndexedAttestation, error) {
+	if len(detections) == 0 {
+		return att, nil
+	}
+	slashed, err := confirm(ctx, att, detections)
+	if err != nil {
+		return nil, err
+	}
+	if len(slashed) == 0 {
+		return att, nil
+	}
+	return &ethpb.IndexedAttestation{
+		AttestingIndices: sliceutil.NotUint64(slashed, att.AttestingIndices),
+		Data:             att.Data,
+		Signature:        att.Signature,
+	}, nil
+}
+
 // saveSigBytes saves the first 2 bytes of the signature for the att we're updating the spans to.
 // Later used to help us find the violating attestation in the DB.
 func (s *SpanDetector) saveSigBytes(ctx context.Context, att *ethpb.IndexedAttestation) error {
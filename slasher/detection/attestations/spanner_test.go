@@ -258,7 +258,6 @@ func TestSpanDetector_DetectSlashingsForAttestation_Double(t *testing.T) {
 						ValidatorIndex: indice,
 						Kind:           types.DoubleVote,
 						SlashableEpoch: tt.incomingAtt.Data.Target.Epoch,
-						SigBytes:       [2]byte{1, 2},
 					})
 				}
 			}
@@ -671,14 +670,12 @@ func TestSpanDetector_DetectSlashingsForAttestation_MultipleValidators(t *testin
 							ValidatorIndex: uint64(i),
 							Kind:           types.DoubleVote,
 							SlashableEpoch: tt.slashableEpochs[i],
-							SigBytes:       [2]byte{1, 2},
 						})
 					} else {
 						want = append(want, &types.DetectionResult{
 							ValidatorIndex: uint64(i),
 							Kind:           types.SurroundVote,
 							SlashableEpoch: tt.slashableEpochs[i],
-							SigBytes:       [2]byte{1, 2},
 						})
 					}
 				}
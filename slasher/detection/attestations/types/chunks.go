@@ -0,0 +1,168 @@
+package types
+
+import (
+	"fmt"
+
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+)
+
+const (
+	// DefaultValidatorChunkSize is the number of validators stored in a single span chunk.
+	DefaultValidatorChunkSize = 256
+	// DefaultEpochChunkSize is the number of epochs stored in a single span chunk.
+	DefaultEpochChunkSize = 16
+)
+
+// ChunkKind defines an enum type for the kind of data a span chunk holds.
+type ChunkKind uint8
+
+const (
+	// MinSpanChunk holds min spans, used for detecting surrounding votes.
+	MinSpanChunk ChunkKind = iota
+	// MaxSpanChunk holds max spans, used for detecting surrounded votes.
+	MaxSpanChunk
+	// AttestedChunk holds whether a validator attested for a target epoch along
+	// with the first 2 bytes of the attestation signature, used for detecting double votes.
+	AttestedChunk
+)
+
+// String returns the string representation of the chunk kind.
+func (k ChunkKind) String() string {
+	switch k {
+	case MinSpanChunk:
+		return "MinSpan"
+	case MaxSpanChunk:
+		return "MaxSpan"
+	case AttestedChunk:
+		return "Attested"
+	default:
+		return "Unknown"
+	}
+}
+
+// cellSize returns the number of bytes used per validator and epoch.
+func (k ChunkKind) cellSize() uint64 {
+	if k == AttestedChunk {
+		return 3
+	}
+	return 2
+}
+
+// ChunkParams defines the dimensions of span chunks. A chunk holds the spans of
+// ValidatorChunkSize validators for EpochChunkSize epochs.
+type ChunkParams struct {
+	ValidatorChunkSize uint64
+	EpochChunkSize     uint64
+}
+
+// DefaultChunkParams returns the chunk dimensions used by the slasher.
+func DefaultChunkParams() *ChunkParams {
+	return &ChunkParams{
+		ValidatorChunkSize: DefaultValidatorChunkSize,
+		EpochChunkSize:     DefaultEpochChunkSize,
+	}
+}
+
+// ChunkKey identifies a span chunk in the database.
+type ChunkKey struct {
+	Kind           ChunkKind
+	ValidatorChunk uint64
+	EpochChunk     uint64
+}
+
+// Key returns the chunk key containing the given validator index and epoch.
+func (p *ChunkParams) Key(kind ChunkKind, validatorIdx uint64, epoch uint64) ChunkKey {
+	return ChunkKey{
+		Kind:           kind,
+		ValidatorChunk: validatorIdx / p.ValidatorChunkSize,
+		EpochChunk:     epoch / p.EpochChunkSize,
+	}
+}
+
+// Marshal the chunk key into bytes, used as a database key.
+func (k ChunkKey) Marshal() []byte {
+	return append(append([]byte{byte(k.Kind)}, bytesutil.Bytes8(k.ValidatorChunk)...), bytesutil.Bytes8(k.EpochChunk)...)
+}
+
+// UnmarshalChunkKey decodes a chunk key from a database key.
+func UnmarshalChunkKey(enc []byte) (ChunkKey, error) {
+	if len(enc) != 17 {
+		return ChunkKey{}, fmt.Errorf("wrong data length for chunk key: %d", len(enc))
+	}
+	return ChunkKey{
+		Kind:           ChunkKind(enc[0]),
+		ValidatorChunk: bytesutil.FromBytes8(enc[1:9]),
+		EpochChunk:     bytesutil.FromBytes8(enc[9:]),
+	}, nil
+}
+
+// SpanChunk is a flat array of span values for a range of validators and epochs.
+// Values are laid out validator by validator, each validator holding a value
+// for every epoch of the chunk.
+type SpanChunk struct {
+	kind   ChunkKind
+	params *ChunkParams
+	data   []byte
+}
+
+// NewSpanChunk returns an empty span chunk of the given kind.
+func NewSpanChunk(kind ChunkKind, params *ChunkParams) *SpanChunk {
+	return &SpanChunk{
+		kind:   kind,
+		params: params,
+		data:   make([]byte, params.ValidatorChunkSize*params.EpochChunkSize*kind.cellSize()),
+	}
+}
+
+// SpanChunkFromBytes wraps encoded chunk data of the given kind.
+func SpanChunkFromBytes(kind ChunkKind, params *ChunkParams, enc []byte) (*SpanChunk, error) {
+	size := params.ValidatorChunkSize * params.EpochChunkSize * kind.cellSize()
+	if uint64(len(enc)) != size {
+		return nil, fmt.Errorf("wrong data length for %s chunk, wanted %d, received %d", kind, size, len(enc))
+	}
+	data := make([]byte, len(enc))
+	copy(data, enc)
+	return &SpanChunk{kind: kind, params: params, data: data}, nil
+}
+
+// Kind of data held by the chunk.
+func (c *SpanChunk) Kind() ChunkKind {
+	return c.kind
+}
+
+// Bytes returns the encoded chunk.
+func (c *SpanChunk) Bytes() []byte {
+	return c.data
+}
+
+func (c *SpanChunk) offset(validatorIdx uint64, epoch uint64) uint64 {
+	cell := (validatorIdx%c.params.ValidatorChunkSize)*c.params.EpochChunkSize + epoch%c.params.EpochChunkSize
+	return cell * c.kind.cellSize()
+}
+
+// Span returns the min or max span of a validator at an epoch, 0 if none was set.
+func (c *SpanChunk) Span(validatorIdx uint64, epoch uint64) uint16 {
+	i := c.offset(validatorIdx, epoch)
+	return bytesutil.FromBytes2(c.data[i : i+2])
+}
+
+// SetSpan sets the min or max span of a validator at an epoch.
+func (c *SpanChunk) SetSpan(validatorIdx uint64, epoch uint64, span uint16) {
+	i := c.offset(validatorIdx, epoch)
+	copy(c.data[i:i+2], bytesutil.Bytes2(uint64(span)))
+}
+
+// Attested returns whether a validator attested for a target epoch and the
+// signature bytes of the attestation.
+func (c *SpanChunk) Attested(validatorIdx uint64, epoch uint64) (bool, [2]byte) {
+	i := c.offset(validatorIdx, epoch)
+	return bytesutil.ToBool(c.data[i]), [2]byte{c.data[i+1], c.data[i+2]}
+}
+
+// SetAttested records an attestation of a validator for a target epoch.
+func (c *SpanChunk) SetAttested(validatorIdx uint64, epoch uint64, sigBytes [2]byte) {
+	i := c.offset(validatorIdx, epoch)
+	c.data[i] = bytesutil.FromBool(true)
+	c.data[i+1] = sigBytes[0]
+	c.data[i+2] = sigBytes[1]
+}
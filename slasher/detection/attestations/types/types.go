@@ -21,13 +21,12 @@ const (
 // DetectionResult tells us the kind of slashable
 // offense found from detecting on min-max spans +
 // the slashable epoch for the offense.
-// Also includes the signature bytes for assistance in
-// finding the attestation for the slashing proof.
+// The validator index and slashable epoch are the key of
+// the attestation used for the slashing proof.
 type DetectionResult struct {
 	ValidatorIndex uint64
 	SlashableEpoch uint64
 	Kind           DetectionKind
-	SigBytes       [2]byte
 }
 
 // Marshal the result into bytes, used for removing duplicates.
@@ -35,12 +34,15 @@ func (result *DetectionResult) Marshal() []byte {
 	numBytes := bytesutil.ToBytes(result.SlashableEpoch, 8)
 	var resultBytes []byte
 	resultBytes = append(resultBytes, uint8(result.Kind))
-	resultBytes = append(resultBytes, result.SigBytes[:]...)
+	resultBytes = append(resultBytes, bytesutil.ToBytes(result.ValidatorIndex, 8)...)
 	resultBytes = append(resultBytes, numBytes...)
 	return resultBytes
 }
 
 // Span defines the structure used for detecting surround and double votes.
+// SigBytes is no longer used for detection, attestations are looked up by
+// validator index and target epoch instead. It is kept to not change the
+// encoding of stored spans.
 type Span struct {
 	MinSpan     uint16
 	MaxSpan     uint16
@@ -9,12 +9,12 @@ import (
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/prysmaticlabs/prysm/shared/hashutil"
 	"github.com/prysmaticlabs/prysm/shared/sliceutil"
-	status "github.com/prysmaticlabs/prysm/slasher/db/types"
 	"github.com/prysmaticlabs/prysm/slasher/detection/attestations/types"
 	"go.opencensus.io/trace"
 )
 
 // DetectAttesterSlashings detects double, surround and surrounding attestation offences given an attestation.
+// The slashings found are not saved, so checking an attestation which is never broadcast leaves no evidence.
 func (ds *Service) DetectAttesterSlashings(
 	ctx context.Context,
 	att *ethpb.IndexedAttestation,
@@ -25,11 +25,22 @@ func (ds *Service) DetectAttesterSlashings(
 	if err != nil {
 		return nil, err
 	}
+	return ds.attesterSlashingsFromResults(ctx, att, results)
+}
+
+// attesterSlashingsFromResults finds the attestations conflicting with the given attestation
+// according to the detection results, and returns the resulting slashings without duplicates.
+func (ds *Service) attesterSlashingsFromResults(
+	ctx context.Context,
+	att *ethpb.IndexedAttestation,
+	results []*types.DetectionResult,
+) ([]*ethpb.AttesterSlashing, error) {
 	// If the response is nil, there was no slashing detected.
 	if len(results) == 0 {
 		return nil, nil
 	}
 
+	var err error
 	var slashings []*ethpb.AttesterSlashing
 	for _, result := range results {
 		var slashing *ethpb.AttesterSlashing
@@ -63,10 +74,6 @@ func (ds *Service) DetectAttesterSlashings(
 			slashingList = append(slashingList, ss)
 		}
 	}
-
-	if err = ds.slasherDB.SaveAttesterSlashings(ctx, status.Active, slashings); err != nil {
-		return nil, err
-	}
 	return slashingList, nil
 }
 
@@ -75,8 +82,16 @@ func (ds *Service) UpdateSpans(ctx context.Context, att *ethpb.IndexedAttestatio
 	return ds.minMaxSpanDetector.UpdateSpans(ctx, att)
 }
 
-// detectDoubleVote cross references the passed in attestation with the bloom filter maintained
-// for every epoch for the validator in order to determine if it is a double vote.
+// ValidatorSpans passthrough function that returns the min-max spans of a validator
+// for every epoch of an inclusive epoch range.
+func (ds *Service) ValidatorSpans(ctx context.Context, validatorIdx uint64, startEpoch uint64, endEpoch uint64) ([]types.Span, error) {
+	return ds.minMaxSpanDetector.ValidatorSpans(ctx, validatorIdx, startEpoch, endEpoch)
+}
+
+// detectDoubleVote compares the data root of the passed in attestation with the data root of the
+// attestation the validator saved for the same target epoch in order to determine if it is a double vote.
+// If the incoming attestation is the one saved first, the conflicting attestation is looked up among all
+// attestations saved for the target epoch, as batches are detected in a different order than saved.
 func (ds *Service) detectDoubleVote(
 	ctx context.Context,
 	incomingAtt *ethpb.IndexedAttestation,
@@ -88,34 +103,61 @@ func (ds *Service) detectDoubleVote(
 		return nil, nil
 	}
 
-	otherAtts, err := ds.slasherDB.IndexedAttestationsWithPrefix(ctx, detectionResult.SlashableEpoch, detectionResult.SigBytes[:])
+	savedRoot, found, err := ds.slasherDB.ValidatorAttestationDataRoot(ctx, detectionResult.ValidatorIndex, detectionResult.SlashableEpoch)
 	if err != nil {
 		return nil, err
 	}
-	for _, att := range otherAtts {
-		if att.Data == nil {
-			continue
-		}
+	if !found {
+		return nil, nil
+	}
+	incomingRoot, err := hashutil.HashProto(incomingAtt.Data)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not hash attestation data")
+	}
+	var att *ethpb.IndexedAttestation
+	if incomingRoot == savedRoot {
+		att, err = ds.conflictingAttestation(ctx, incomingAtt, detectionResult.ValidatorIndex, detectionResult.SlashableEpoch)
+	} else {
+		att, err = ds.slasherDB.ValidatorAttestation(ctx, detectionResult.ValidatorIndex, detectionResult.SlashableEpoch)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if att == nil || att.Data == nil || !isDoubleVote(incomingAtt, att) {
+		return nil, nil
+	}
+	doubleVotesDetected.Inc()
+	return &ethpb.AttesterSlashing{
+		Attestation_1: incomingAtt,
+		Attestation_2: att,
+	}, nil
+}
 
-		// If there are no shared indices, there is no validator to slash.
-		if len(sliceutil.IntersectionUint64(att.AttestingIndices, []uint64{detectionResult.ValidatorIndex})) == 0 {
+// conflictingAttestation returns an attestation of the validator saved for the target epoch with
+// different data than the passed in attestation, or nil if the same data was signed again.
+func (ds *Service) conflictingAttestation(
+	ctx context.Context,
+	incomingAtt *ethpb.IndexedAttestation,
+	validatorIdx uint64,
+	targetEpoch uint64,
+) (*ethpb.IndexedAttestation, error) {
+	atts, err := ds.slasherDB.IndexedAttestationsForTarget(ctx, targetEpoch)
+	if err != nil {
+		return nil, err
+	}
+	for _, att := range atts {
+		if att.Data == nil || !sliceutil.IsInUint64(validatorIdx, att.AttestingIndices) {
 			continue
 		}
-
 		if isDoubleVote(incomingAtt, att) {
-			doubleVotesDetected.Inc()
-			return &ethpb.AttesterSlashing{
-				Attestation_1: incomingAtt,
-				Attestation_2: att,
-			}, nil
+			return att, nil
 		}
-
 	}
 	return nil, nil
 }
 
-// detectSurroundVotes cross references the passed in attestation with the requested validator's
-// voting history in order to detect any possible surround votes.
+// detectSurroundVotes fetches the attestation the requested validator saved for the slashable epoch
+// and checks whether it surrounds or is surrounded by the passed in attestation.
 func (ds *Service) detectSurroundVotes(
 	ctx context.Context,
 	incomingAtt *ethpb.IndexedAttestation,
@@ -127,19 +169,11 @@ func (ds *Service) detectSurroundVotes(
 		return nil, nil
 	}
 
-	otherAtts, err := ds.slasherDB.IndexedAttestationsWithPrefix(ctx, detectionResult.SlashableEpoch, detectionResult.SigBytes[:])
+	att, err := ds.slasherDB.ValidatorAttestation(ctx, detectionResult.ValidatorIndex, detectionResult.SlashableEpoch)
 	if err != nil {
 		return nil, err
 	}
-	for _, att := range otherAtts {
-		if att.Data == nil {
-			continue
-		}
-		// If there are no shared indices, there is no validator to slash.
-		if len(sliceutil.IntersectionUint64(att.AttestingIndices, []uint64{detectionResult.ValidatorIndex})) == 0 {
-			continue
-		}
-
+	if att != nil && att.Data != nil {
 		// Slashings must be submitted as the incoming attestation surrounding the saved attestation.
 		// So we swap the order if needed.
 		if isSurrounding(incomingAtt, att) {
@@ -160,6 +194,7 @@ func (ds *Service) detectSurroundVotes(
 }
 
 // DetectDoubleProposals checks if the given signed beacon block is a slashable offense and returns the slashing.
+// The slashing found is not saved.
 func (ds *Service) DetectDoubleProposals(ctx context.Context, incomingBlock *ethpb.SignedBeaconBlockHeader) (*ethpb.ProposerSlashing, error) {
 	return ds.proposalsDetector.DetectDoublePropose(ctx, incomingBlock)
 }
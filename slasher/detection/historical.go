@@ -0,0 +1,314 @@
+package detection
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/slasher/db/types"
+	"github.com/sirupsen/logrus"
+	"go.opencensus.io/trace"
+)
+
+const (
+	// historicalDetectionRangeSize is the number of epochs requested and persisted
+	// as detected at once by historical detection.
+	historicalDetectionRangeSize = 16
+	// defaultHistoricalDetectionWorkers is the number of epoch ranges requested
+	// concurrently from the beacon node if no number is configured.
+	defaultHistoricalDetectionWorkers = 4
+)
+
+// HistoricalDetectionProgress reports the progress of the current, or last,
+// run of slashing detection on historical chain data.
+type HistoricalDetectionProgress struct {
+	Running        bool
+	HeadEpoch      uint64
+	TotalEpochs    uint64
+	DetectedEpochs uint64
+	// ETA is the estimated time until the run completes, based on the rate
+	// epochs were detected on so far. It is zero if not running.
+	ETA time.Duration
+}
+
+// historicalProgress tracks the progress of historical detection runs.
+type historicalProgress struct {
+	lock           sync.RWMutex
+	running        bool
+	headEpoch      uint64
+	totalEpochs    uint64
+	detectedEpochs uint64
+	started        time.Time
+}
+
+func (p *historicalProgress) start(headEpoch uint64, totalEpochs uint64) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.running = true
+	p.headEpoch = headEpoch
+	p.totalEpochs = totalEpochs
+	p.detectedEpochs = 0
+	p.started = time.Now()
+}
+
+func (p *historicalProgress) addDetected(epochs uint64) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.detectedEpochs += epochs
+}
+
+func (p *historicalProgress) stop() {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.running = false
+}
+
+func (p *historicalProgress) progress(now time.Time) HistoricalDetectionProgress {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	res := HistoricalDetectionProgress{
+		Running:        p.running,
+		HeadEpoch:      p.headEpoch,
+		TotalEpochs:    p.totalEpochs,
+		DetectedEpochs: p.detectedEpochs,
+	}
+	if p.running && p.detectedEpochs > 0 {
+		elapsed := now.Sub(p.started)
+		remaining := p.totalEpochs - p.detectedEpochs
+		res.ETA = time.Duration(float64(elapsed) / float64(p.detectedEpochs) * float64(remaining))
+	}
+	return res
+}
+
+// HistoricalDetectionProgress returns the progress of slashing detection on historical chain data.
+func (ds *Service) HistoricalDetectionProgress() HistoricalDetectionProgress {
+	return ds.historicalProgress.progress(time.Now())
+}
+
+// RescanEpochRange marks the epochs of the given inclusive range as not detected and triggers
+// historical detection, which runs slashing detection on their chain data again. If historical
+// detection is running, the range is re-scanned once the current run completes.
+func (ds *Service) RescanEpochRange(ctx context.Context, startEpoch uint64, endEpoch uint64) error {
+	if startEpoch > endEpoch {
+		return fmt.Errorf("start epoch %d is greater than end epoch %d", startEpoch, endEpoch)
+	}
+	epochRange := types.EpochRange{Start: startEpoch, End: endEpoch}
+	if err := ds.slasherDB.DeleteDetectedEpochRange(ctx, epochRange); err != nil {
+		return errors.Wrap(err, "could not unmark epoch range as detected")
+	}
+	log.WithFields(logrus.Fields{
+		"startEpoch": startEpoch,
+		"endEpoch":   endEpoch,
+	}).Info("Scheduled slashing detection on historical chain data")
+	select {
+	case ds.historicalTrigger <- struct{}{}:
+	default:
+		// A run is already scheduled, which will include the range.
+	}
+	return nil
+}
+
+// runHistoricalDetection runs slashing detection on historical chain data on start,
+// and again whenever an epoch range is scheduled to be re-scanned.
+func (ds *Service) runHistoricalDetection(ctx context.Context) {
+	for {
+		if err := ds.detectHistoricalChainData(ctx); err != nil {
+			log.WithError(err).Error("Could not run slashing detection on historical chain data")
+		}
+		select {
+		case <-ds.historicalTrigger:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// fetchedEpochRange holds the attestations retrieved for an epoch range.
+type fetchedEpochRange struct {
+	epochRange types.EpochRange
+	atts       []*ethpb.IndexedAttestation
+	err        error
+}
+
+// detectHistoricalChainData runs slashing detection on the chain data of all epochs up to the
+// current head epoch of the beacon node which were not yet detected. Epoch ranges are requested
+// from the beacon node concurrently, while detection runs on one epoch range at a time, so that
+// every attestation is checked against the spans of all attestations detected before it. Each
+// epoch range is persisted as detected once detection completed on it, so an interrupted run
+// resumes from where it stopped.
+func (ds *Service) detectHistoricalChainData(ctx context.Context) error {
+	ctx, span := trace.StartSpan(ctx, "detection.detectHistoricalChainData")
+	defer span.End()
+	currentChainHead, err := ds.chainFetcher.ChainHead(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not retrieve chain head from beacon node")
+	}
+	detected, err := ds.detectedEpochRanges(ctx)
+	if err != nil {
+		return err
+	}
+	pending := pendingEpochRanges(detected, currentChainHead.HeadEpoch, historicalDetectionRangeSize)
+	var totalEpochs uint64
+	for _, r := range pending {
+		totalEpochs += r.End - r.Start + 1
+	}
+	ds.historicalProgress.start(currentChainHead.HeadEpoch, totalEpochs)
+	defer ds.historicalProgress.stop()
+	if totalEpochs == 0 {
+		return nil
+	}
+	log.WithFields(logrus.Fields{
+		"headEpoch":   currentChainHead.HeadEpoch,
+		"totalEpochs": totalEpochs,
+		"workers":     ds.historicalWorkers,
+	}).Info("Running slashing detection on historical chain data")
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	fetched := ds.fetchEpochRanges(ctx, pending)
+	var failed bool
+	for f := range fetched {
+		if f.err != nil {
+			log.WithError(f.err).Errorf("Could not fetch attestations for epochs %d to %d", f.epochRange.Start, f.epochRange.End)
+			failed = true
+			continue
+		}
+		if len(f.atts) > 0 {
+			if err := ds.detectAttestations(ctx, f.atts); err != nil {
+				log.WithError(err).Errorf("Could not detect attester slashings for epochs %d to %d", f.epochRange.Start, f.epochRange.End)
+				failed = true
+				continue
+			}
+		}
+		if err := ds.slasherDB.SaveDetectedEpochRange(ctx, f.epochRange); err != nil {
+			return errors.Wrap(err, "could not persist detected epoch range")
+		}
+		ds.historicalProgress.addDetected(f.epochRange.End - f.epochRange.Start + 1)
+		progress := ds.HistoricalDetectionProgress()
+		log.WithFields(logrus.Fields{
+			"startEpoch":     f.epochRange.Start,
+			"endEpoch":       f.epochRange.End,
+			"attestations":   len(f.atts),
+			"detectedEpochs": progress.DetectedEpochs,
+			"totalEpochs":    progress.TotalEpochs,
+			"eta":            progress.ETA.Round(time.Second),
+		}).Debug("Completed slashing detection on historical epoch range")
+	}
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if failed {
+		return errors.New("could not run detection on all epoch ranges, they are retried on the next rescan or restart")
+	}
+	log.Infof("Completed slashing detection on historical chain data up to epoch %d", currentChainHead.HeadEpoch)
+	return nil
+}
+
+// detectedEpochRanges returns the epoch ranges historical detection completed on. Previous
+// versions only persisted the last detected epoch as the chain head, which is carried over.
+func (ds *Service) detectedEpochRanges(ctx context.Context) ([]types.EpochRange, error) {
+	detected, err := ds.slasherDB.DetectedEpochRanges(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not retrieve detected epoch ranges")
+	}
+	if len(detected) > 0 {
+		return detected, nil
+	}
+	latestStoredHead, err := ds.slasherDB.ChainHead(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not retrieve chain head from DB")
+	}
+	if latestStoredHead == nil || latestStoredHead.HeadEpoch == 0 {
+		return nil, nil
+	}
+	detectedRange := types.EpochRange{Start: 0, End: latestStoredHead.HeadEpoch}
+	if err := ds.slasherDB.SaveDetectedEpochRange(ctx, detectedRange); err != nil {
+		return nil, errors.Wrap(err, "could not persist detected epoch range")
+	}
+	return []types.EpochRange{detectedRange}, nil
+}
+
+// fetchEpochRanges requests the attestations of the given epoch ranges from the beacon node,
+// using the configured number of workers. The returned channel is closed once all epoch
+// ranges were requested or the context is canceled.
+func (ds *Service) fetchEpochRanges(ctx context.Context, ranges []types.EpochRange) <-chan *fetchedEpochRange {
+	rangesChan := make(chan types.EpochRange)
+	fetched := make(chan *fetchedEpochRange, ds.historicalWorkers)
+	go func() {
+		defer close(rangesChan)
+		for _, r := range ranges {
+			select {
+			case rangesChan <- r:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	var wg sync.WaitGroup
+	for i := 0; i < ds.historicalWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for r := range rangesChan {
+				f := &fetchedEpochRange{epochRange: r}
+				for epoch := r.Start; epoch <= r.End; epoch++ {
+					atts, err := ds.beaconClient.RequestHistoricalAttestations(ctx, epoch)
+					if err != nil {
+						f.err = err
+						break
+					}
+					f.atts = append(f.atts, atts...)
+				}
+				select {
+				case fetched <- f:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(fetched)
+	}()
+	return fetched
+}
+
+// pendingEpochRanges returns the ranges of epochs before the head epoch which are not covered
+// by the detected ranges, split into ranges of at most rangeSize epochs. Detected ranges must
+// be sorted by their first epoch.
+func pendingEpochRanges(detected []types.EpochRange, headEpoch uint64, rangeSize uint64) []types.EpochRange {
+	var pending []types.EpochRange
+	addGap := func(start uint64, end uint64) {
+		for s := start; s <= end; s += rangeSize {
+			e := s + rangeSize - 1
+			if e > end {
+				e = end
+			}
+			pending = append(pending, types.EpochRange{Start: s, End: e})
+		}
+	}
+	var next uint64
+	for _, r := range detected {
+		if next >= headEpoch {
+			break
+		}
+		if r.Start > next {
+			end := r.Start - 1
+			if end >= headEpoch {
+				end = headEpoch - 1
+			}
+			addGap(next, end)
+		}
+		if r.End+1 > next {
+			next = r.End + 1
+		}
+	}
+	if next < headEpoch {
+		addGap(next, headEpoch-1)
+	}
+	return pending
+}
@@ -0,0 +1,118 @@
+package detection
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	testDB "github.com/prysmaticlabs/prysm/slasher/db/testing"
+	"github.com/prysmaticlabs/prysm/slasher/db/types"
+)
+
+func TestPendingEpochRanges(t *testing.T) {
+	tests := []struct {
+		name      string
+		detected  []types.EpochRange
+		headEpoch uint64
+		want      []types.EpochRange
+	}{
+		{
+			name:      "nothing detected",
+			headEpoch: 10,
+			want:      []types.EpochRange{{Start: 0, End: 3}, {Start: 4, End: 7}, {Start: 8, End: 9}},
+		},
+		{
+			name:      "genesis head",
+			headEpoch: 0,
+		},
+		{
+			name:      "all detected",
+			detected:  []types.EpochRange{{Start: 0, End: 11}},
+			headEpoch: 10,
+		},
+		{
+			name:      "gaps between detected ranges",
+			detected:  []types.EpochRange{{Start: 2, End: 3}, {Start: 4, End: 5}, {Start: 10, End: 12}},
+			headEpoch: 16,
+			want: []types.EpochRange{
+				{Start: 0, End: 1},
+				{Start: 6, End: 9},
+				{Start: 13, End: 15},
+			},
+		},
+		{
+			name:      "overlapping detected ranges",
+			detected:  []types.EpochRange{{Start: 0, End: 8}, {Start: 3, End: 5}},
+			headEpoch: 12,
+			want:      []types.EpochRange{{Start: 9, End: 11}},
+		},
+		{
+			name:      "detected ranges past head",
+			detected:  []types.EpochRange{{Start: 20, End: 30}},
+			headEpoch: 6,
+			want:      []types.EpochRange{{Start: 0, End: 3}, {Start: 4, End: 5}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := pendingEpochRanges(tt.detected, tt.headEpoch, 4)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Wanted %v, received %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestHistoricalProgress_ETA(t *testing.T) {
+	p := &historicalProgress{}
+	p.start(100, 100)
+	if eta := p.progress(time.Now()).ETA; eta != 0 {
+		t.Errorf("Expected no ETA before any epoch was detected, received %v", eta)
+	}
+	p.addDetected(25)
+	got := p.progress(p.started.Add(10 * time.Second))
+	if got.ETA != 30*time.Second {
+		t.Errorf("Wanted ETA %v, received %v", 30*time.Second, got.ETA)
+	}
+	if !got.Running || got.DetectedEpochs != 25 || got.TotalEpochs != 100 {
+		t.Errorf("Unexpected progress %+v", got)
+	}
+	p.stop()
+	if got := p.progress(time.Now()); got.Running || got.ETA != 0 {
+		t.Errorf("Expected stopped progress without ETA, received %+v", got)
+	}
+}
+
+func TestService_RescanEpochRange(t *testing.T) {
+	db := testDB.SetupSlasherDB(t, false)
+	defer testDB.TeardownSlasherDB(t, db)
+	ctx := context.Background()
+	ds := NewDetectionService(ctx, &Config{SlasherDB: db})
+
+	if err := db.SaveDetectedEpochRange(ctx, types.EpochRange{Start: 0, End: 15}); err != nil {
+		t.Fatal(err)
+	}
+	if err := ds.RescanEpochRange(ctx, 5, 3); err == nil {
+		t.Error("Expected error for start epoch greater than end epoch")
+	}
+	if err := ds.RescanEpochRange(ctx, 4, 7); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-ds.historicalTrigger:
+	default:
+		t.Error("Expected historical detection to be triggered")
+	}
+	detected, err := db.DetectedEpochRanges(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []types.EpochRange{{Start: 0, End: 3}, {Start: 8, End: 15}}
+	if !reflect.DeepEqual(detected, want) {
+		t.Errorf("Wanted detected ranges %v, received %v", want, detected)
+	}
+	if got := pendingEpochRanges(detected, 16, historicalDetectionRangeSize); !reflect.DeepEqual(got, []types.EpochRange{{Start: 4, End: 7}}) {
+		t.Errorf("Expected epochs 4 to 7 to be pending, received %v", got)
+	}
+}
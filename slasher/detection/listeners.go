@@ -8,32 +8,42 @@ package detection
 
 import (
 	"context"
+	"sort"
+	"time"
 
 	"github.com/pkg/errors"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/prysmaticlabs/go-ssz"
+	"github.com/prysmaticlabs/prysm/shared/event"
+	"github.com/prysmaticlabs/prysm/shared/sliceutil"
+	status "github.com/prysmaticlabs/prysm/slasher/db/types"
+	"github.com/prysmaticlabs/prysm/slasher/detection/attestations/types"
 	"go.opencensus.io/trace"
 )
 
-// detectIncomingBlocks subscribes to an event feed for
-// block objects from a notifier interface. Upon receiving
+const (
+	// attestationBatchPeriod is the interval at which queued attestations are processed.
+	attestationBatchPeriod = time.Second
+	// maxAttestationBatchSize is the number of queued attestations which triggers
+	// processing before the end of the interval.
+	maxAttestationBatchSize = 4096
+)
+
+// detectIncomingBlocks listens to a subscription to an event feed
+// for block objects from a notifier interface. Upon receiving
 // a signed beacon block from the feed, we run proposer slashing
 // detection on the block.
-func (ds *Service) detectIncomingBlocks(ctx context.Context, ch chan *ethpb.SignedBeaconBlock) {
+func (ds *Service) detectIncomingBlocks(ctx context.Context, ch chan *ethpb.SignedBeaconBlock, sub event.Subscription) {
 	ctx, span := trace.StartSpan(ctx, "detection.detectIncomingBlocks")
 	defer span.End()
-	sub := ds.notifier.BlockFeed().Subscribe(ch)
 	defer sub.Unsubscribe()
 	for {
 		select {
 		case sblk := <-ch:
 			log.Debug("Running detection on block...")
-			sbh, err := signedBeaconBlockHeaderFromBlock(sblk)
-			if err != nil {
-				log.WithError(err)
+			if err := ds.detectBlock(ctx, sblk); err != nil {
+				log.WithError(err).Error("Could not run detection on block")
 			}
-			slashing, err := ds.proposalsDetector.DetectDoublePropose(ctx, sbh)
-			ds.submitProposerSlashing(ctx, slashing)
 		case <-sub.Err():
 			log.Error("Subscriber closed, exiting goroutine")
 			return
@@ -44,29 +54,56 @@ func (ds *Service) detectIncomingBlocks(ctx context.Context, ch chan *ethpb.Sign
 	}
 }
 
-// detectIncomingAttestations subscribes to an event feed for
-// attestation objects from a notifier interface. Upon receiving
-// an attestation from the feed, we run surround vote and double vote
-// detection on the attestation.
-func (ds *Service) detectIncomingAttestations(ctx context.Context, ch chan *ethpb.IndexedAttestation) {
+// detectBlock runs proposer slashing detection on a block, saves and submits the slashing
+// found, and saves the block header for later blocks of the same proposer and slot to be
+// checked against it. Blocks which cannot be checked are not saved.
+func (ds *Service) detectBlock(ctx context.Context, sblk *ethpb.SignedBeaconBlock) error {
+	sbh, err := signedBeaconBlockHeaderFromBlock(sblk)
+	if err != nil {
+		return errors.Wrap(err, "could not get block header from block")
+	}
+	slashing, err := ds.proposalsDetector.DetectDoublePropose(ctx, sbh)
+	if err != nil {
+		return errors.Wrap(err, "could not detect proposer slashing")
+	}
+	if slashing != nil {
+		if err := ds.slasherDB.SaveProposerSlashing(ctx, status.Active, slashing); err != nil {
+			return errors.Wrap(err, "could not save proposer slashing")
+		}
+		ds.submitProposerSlashing(ctx, slashing)
+	}
+	return ds.slasherDB.SaveBlockHeader(ctx, sbh)
+}
+
+// detectIncomingAttestations listens to a subscription to an event feed
+// for attestation objects from a notifier interface. Received attestations
+// are queued and detection runs for the whole queue at a fixed interval,
+// or as soon as the queue is full. While a batch is being processed no
+// attestations are received, which slows down the feed.
+func (ds *Service) detectIncomingAttestations(ctx context.Context, ch chan *ethpb.IndexedAttestation, sub event.Subscription) {
 	ctx, span := trace.StartSpan(ctx, "detection.detectIncomingAttestations")
 	defer span.End()
-	sub := ds.notifier.AttestationFeed().Subscribe(ch)
 	defer sub.Unsubscribe()
+	ticker := time.NewTicker(attestationBatchPeriod)
+	defer ticker.Stop()
+	var queue []*ethpb.IndexedAttestation
 	for {
 		select {
 		case indexedAtt := <-ch:
-			slashings, err := ds.DetectAttesterSlashings(ctx, indexedAtt)
-			if err != nil {
-				log.WithError(err).Error("Could not detect attester slashings")
-				continue
+			queue = append(queue, indexedAtt)
+			attestationQueueSize.Set(float64(len(queue)))
+			if len(queue) >= maxAttestationBatchSize {
+				fullAttestationBatches.Inc()
+				ds.detectAttestationBatch(ctx, queue)
+				queue = nil
+				attestationQueueSize.Set(0)
 			}
-			if len(slashings) < 1 {
-				if err := ds.minMaxSpanDetector.UpdateSpans(ctx, indexedAtt); err != nil {
-					log.WithError(err).Error("Could not update spans")
-				}
+		case <-ticker.C:
+			if len(queue) > 0 {
+				ds.detectAttestationBatch(ctx, queue)
+				queue = nil
+				attestationQueueSize.Set(0)
 			}
-			ds.submitAttesterSlashings(ctx, slashings)
 		case <-sub.Err():
 			log.Error("Subscriber closed, exiting goroutine")
 			return
@@ -77,6 +114,81 @@ func (ds *Service) detectIncomingAttestations(ctx context.Context, ch chan *ethp
 	}
 }
 
+// detectAttestationBatch runs surround vote and double vote detection on a batch
+// of attestations. Attestations are grouped by validator chunk and target epoch, so
+// that attestations of the same validators are processed together, and spans are
+// written once for the whole batch.
+func (ds *Service) detectAttestationBatch(ctx context.Context, atts []*ethpb.IndexedAttestation) {
+	ctx, span := trace.StartSpan(ctx, "detection.detectAttestationBatch")
+	defer span.End()
+	start := time.Now()
+	attestationBatchSize.Observe(float64(len(atts)))
+
+	if err := ds.detectAttestations(ctx, atts); err != nil {
+		log.WithError(err).Error("Could not detect attester slashings")
+		return
+	}
+	attestationBatchDuration.Observe(time.Since(start).Seconds())
+}
+
+// detectAttestations runs slashing detection on attestations, updates the spans of the
+// attesting validators which are not slashed, and saves and submits the detected slashings.
+func (ds *Service) detectAttestations(ctx context.Context, atts []*ethpb.IndexedAttestation) error {
+	sortAttestationBatch(atts, types.DefaultValidatorChunkSize)
+	var slashings []*ethpb.AttesterSlashing
+	confirm := func(ctx context.Context, att *ethpb.IndexedAttestation, results []*types.DetectionResult) ([]uint64, error) {
+		found, err := ds.attesterSlashingsFromResults(ctx, att, results)
+		if err != nil {
+			log.WithError(err).Error("Could not detect attester slashings")
+			// Spans are not updated for an attestation which could not be checked.
+			return att.AttestingIndices, nil
+		}
+		slashings = append(slashings, found...)
+		return slashedIndices(found), nil
+	}
+	if _, err := ds.minMaxSpanDetector.DetectAndUpdateSpans(ctx, atts, confirm); err != nil {
+		return err
+	}
+	if len(slashings) == 0 {
+		return nil
+	}
+	if err := ds.slasherDB.SaveAttesterSlashings(ctx, status.Active, slashings); err != nil {
+		return errors.Wrap(err, "could not save attester slashings")
+	}
+	ds.submitAttesterSlashings(ctx, slashings)
+	return nil
+}
+
+// slashedIndices returns the indices of the validators slashed by attester slashings.
+func slashedIndices(slashings []*ethpb.AttesterSlashing) []uint64 {
+	var indices []uint64
+	for _, slashing := range slashings {
+		indices = append(indices, sliceutil.IntersectionUint64(
+			slashing.Attestation_1.AttestingIndices,
+			slashing.Attestation_2.AttestingIndices,
+		)...)
+	}
+	return indices
+}
+
+// sortAttestationBatch orders attestations by the validator chunk of their first
+// attesting index, then by target epoch.
+func sortAttestationBatch(atts []*ethpb.IndexedAttestation, validatorChunkSize uint64) {
+	validatorChunk := func(att *ethpb.IndexedAttestation) uint64 {
+		if len(att.AttestingIndices) == 0 {
+			return 0
+		}
+		return att.AttestingIndices[0] / validatorChunkSize
+	}
+	sort.SliceStable(atts, func(i, j int) bool {
+		ci, cj := validatorChunk(atts[i]), validatorChunk(atts[j])
+		if ci != cj {
+			return ci < cj
+		}
+		return atts[i].Data.Target.Epoch < atts[j].Data.Target.Epoch
+	})
+}
+
 func signedBeaconBlockHeaderFromBlock(block *ethpb.SignedBeaconBlock) (*ethpb.SignedBeaconBlockHeader, error) {
 	bodyRoot, err := ssz.HashTreeRoot(block.Block.Body)
 	if err != nil {
@@ -6,10 +6,15 @@ import (
 	"testing"
 
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
 	"github.com/prysmaticlabs/prysm/shared/event"
 	"github.com/prysmaticlabs/prysm/shared/testutil"
+	"github.com/prysmaticlabs/prysm/slasher/db"
 	testDB "github.com/prysmaticlabs/prysm/slasher/db/testing"
+	status "github.com/prysmaticlabs/prysm/slasher/db/types"
 	"github.com/prysmaticlabs/prysm/slasher/detection/attestations"
+	"github.com/prysmaticlabs/prysm/slasher/detection/attestations/iface"
+	"github.com/prysmaticlabs/prysm/slasher/detection/attestations/types"
 	"github.com/prysmaticlabs/prysm/slasher/detection/proposals"
 	"github.com/sirupsen/logrus"
 	logTest "github.com/sirupsen/logrus/hooks/test"
@@ -40,6 +45,7 @@ func TestService_DetectIncomingBlocks(t *testing.T) {
 	defer testDB.TeardownSlasherDB(t, db)
 	ds := Service{
 		notifier:          &mockNotifier{},
+		slasherDB:         db,
 		proposalsDetector: proposals.NewProposeDetector(db),
 	}
 	blk := &ethpb.SignedBeaconBlock{
@@ -48,9 +54,10 @@ func TestService_DetectIncomingBlocks(t *testing.T) {
 	}
 	exitRoutine := make(chan bool)
 	blocksChan := make(chan *ethpb.SignedBeaconBlock)
+	sub := ds.notifier.BlockFeed().Subscribe(blocksChan)
 	ctx, cancel := context.WithCancel(context.Background())
 	go func(tt *testing.T) {
-		ds.detectIncomingBlocks(ctx, blocksChan)
+		ds.detectIncomingBlocks(ctx, blocksChan, sub)
 		<-exitRoutine
 	}(t)
 	blocksChan <- blk
@@ -60,6 +67,59 @@ func TestService_DetectIncomingBlocks(t *testing.T) {
 	testutil.AssertLogsContain(t, hook, "Context canceled")
 }
 
+func TestService_DetectBlock_SavesHeaders(t *testing.T) {
+	db := testDB.SetupSlasherDB(t, false)
+	defer testDB.TeardownSlasherDB(t, db)
+	ctx := context.Background()
+	ds := Service{
+		slasherDB:             db,
+		proposalsDetector:     proposals.NewProposeDetector(db),
+		proposerSlashingsFeed: new(event.Feed),
+	}
+	slashingsChan := make(chan *ethpb.ProposerSlashing, 1)
+	sub := ds.proposerSlashingsFeed.Subscribe(slashingsChan)
+	defer sub.Unsubscribe()
+	newBlock := func(graffiti byte) *ethpb.SignedBeaconBlock {
+		return &ethpb.SignedBeaconBlock{
+			Block: &ethpb.BeaconBlock{
+				Slot:          1,
+				ProposerIndex: 3,
+				Body:          &ethpb.BeaconBlockBody{Graffiti: bytesutil.PadTo([]byte{graffiti}, 32)},
+			},
+			Signature: bytesutil.PadTo([]byte{graffiti}, 96),
+		}
+	}
+
+	if err := ds.detectBlock(ctx, newBlock(1)); err != nil {
+		t.Fatal(err)
+	}
+	headers, err := db.BlockHeaders(ctx, 1, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(headers) != 1 {
+		t.Fatalf("Expected the block header to be saved, received %d headers", len(headers))
+	}
+	if len(slashingsChan) != 0 {
+		t.Fatalf("Expected no slashing for the first block, received %d", len(slashingsChan))
+	}
+
+	// A second block of the same proposer and slot is checked against the saved header.
+	if err := ds.detectBlock(ctx, newBlock(2)); err != nil {
+		t.Fatal(err)
+	}
+	if len(slashingsChan) != 1 {
+		t.Fatalf("Expected 1 slashing, received %d", len(slashingsChan))
+	}
+	saved, err := db.ProposalSlashingsByStatus(ctx, status.Active)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(saved) != 1 {
+		t.Errorf("Expected 1 saved slashing, received %d", len(saved))
+	}
+}
+
 func TestService_DetectIncomingAttestations(t *testing.T) {
 	hook := logTest.NewGlobal()
 	ds := Service{
@@ -80,9 +140,10 @@ func TestService_DetectIncomingAttestations(t *testing.T) {
 	}
 	exitRoutine := make(chan bool)
 	attsChan := make(chan *ethpb.IndexedAttestation)
+	sub := ds.notifier.AttestationFeed().Subscribe(attsChan)
 	ctx, cancel := context.WithCancel(context.Background())
 	go func(tt *testing.T) {
-		ds.detectIncomingAttestations(ctx, attsChan)
+		ds.detectIncomingAttestations(ctx, attsChan, sub)
 		<-exitRoutine
 	}(t)
 	attsChan <- att
@@ -90,3 +151,188 @@ func TestService_DetectIncomingAttestations(t *testing.T) {
 	exitRoutine <- true
 	testutil.AssertLogsContain(t, hook, "Context canceled")
 }
+
+func TestService_DetectAttestationBatch(t *testing.T) {
+	db := testDB.SetupSlasherDB(t, false)
+	defer testDB.TeardownSlasherDB(t, db)
+	ctx := context.Background()
+	ds := Service{
+		ctx:                   ctx,
+		slasherDB:             db,
+		minMaxSpanDetector:    attestations.NewChunkedSpanDetector(db, types.DefaultChunkParams()),
+		attesterSlashingsFeed: new(event.Feed),
+	}
+	newAtt := func(indices []uint64, source uint64, target uint64, targetRoot []byte) *ethpb.IndexedAttestation {
+		return &ethpb.IndexedAttestation{
+			AttestingIndices: indices,
+			Data: &ethpb.AttestationData{
+				Source: &ethpb.Checkpoint{Epoch: source},
+				Target: &ethpb.Checkpoint{Epoch: target, Root: targetRoot},
+			},
+			Signature: bytesutil.PadTo([]byte{1, 2}, 96),
+		}
+	}
+	// Both double votes are in the same batch, so the second has to be checked
+	// against spans of the first before they are written to the db.
+	atts := []*ethpb.IndexedAttestation{
+		newAtt([]uint64{300}, 1, 2, []byte("good target")),
+		newAtt([]uint64{1, 2}, 1, 3, []byte("good target")),
+		newAtt([]uint64{2}, 1, 3, []byte("bad target")),
+	}
+	if err := db.SaveIndexedAttestations(ctx, atts); err != nil {
+		t.Fatal(err)
+	}
+	slashingsChan := make(chan *ethpb.AttesterSlashing, 2)
+	sub := ds.attesterSlashingsFeed.Subscribe(slashingsChan)
+	defer sub.Unsubscribe()
+
+	ds.detectAttestationBatch(ctx, atts)
+
+	if atts[2].AttestingIndices[0] != 300 {
+		t.Errorf("Expected attestations to be grouped by validator chunk, received %v last", atts[2].AttestingIndices)
+	}
+	if len(slashingsChan) != 1 {
+		t.Fatalf("Expected 1 slashing, received %d", len(slashingsChan))
+	}
+	slashing := <-slashingsChan
+	if !isDoubleVote(slashing.Attestation_1, slashing.Attestation_2) {
+		t.Errorf("Expected a double vote slashing, received %v", slashing)
+	}
+	// Slashings detected on chain data are saved to be re-submitted until included.
+	saved, err := db.AttesterSlashings(ctx, status.Active)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(saved) != 1 {
+		t.Errorf("Expected 1 saved slashing, received %d", len(saved))
+	}
+}
+
+func TestService_DetectAttestationBatch_DoubleVoteDetectedBeforeSaved(t *testing.T) {
+	db := testDB.SetupSlasherDB(t, false)
+	defer testDB.TeardownSlasherDB(t, db)
+	ctx := context.Background()
+	ds := Service{
+		ctx:                   ctx,
+		slasherDB:             db,
+		minMaxSpanDetector:    attestations.NewChunkedSpanDetector(db, types.DefaultChunkParams()),
+		attesterSlashingsFeed: new(event.Feed),
+	}
+	newAtt := func(indices []uint64, targetRoot []byte, sig []byte) *ethpb.IndexedAttestation {
+		return &ethpb.IndexedAttestation{
+			AttestingIndices: indices,
+			Data: &ethpb.AttestationData{
+				Source: &ethpb.Checkpoint{Epoch: 1},
+				Target: &ethpb.Checkpoint{Epoch: 2, Root: targetRoot},
+			},
+			Signature: bytesutil.PadTo(sig, 96),
+		}
+	}
+	// The attestation of validator 300 alone is saved first, but detected last as the batch is
+	// grouped by the validator chunk of the first attesting index.
+	first := newAtt([]uint64{300}, []byte("good target"), []byte{1, 2})
+	second := newAtt([]uint64{5, 300}, []byte("bad target"), []byte{3, 4})
+	atts := []*ethpb.IndexedAttestation{first, second}
+	if err := db.SaveIndexedAttestations(ctx, atts); err != nil {
+		t.Fatal(err)
+	}
+	slashingsChan := make(chan *ethpb.AttesterSlashing, 2)
+	sub := ds.attesterSlashingsFeed.Subscribe(slashingsChan)
+	defer sub.Unsubscribe()
+
+	if err := ds.detectAttestations(ctx, atts); err != nil {
+		t.Fatal(err)
+	}
+	if atts[0] != second {
+		t.Fatalf("Expected the second saved attestation to be detected first, received %v", atts[0].AttestingIndices)
+	}
+	if len(slashingsChan) != 1 {
+		t.Fatalf("Expected 1 slashing, received %d", len(slashingsChan))
+	}
+	slashing := <-slashingsChan
+	if !isDoubleVote(slashing.Attestation_1, slashing.Attestation_2) {
+		t.Errorf("Expected a double vote slashing, received %v", slashing)
+	}
+	if indices := slashedIndices([]*ethpb.AttesterSlashing{slashing}); len(indices) != 1 || indices[0] != 300 {
+		t.Errorf("Expected validator 300 to be slashed, received %v", indices)
+	}
+}
+
+func TestService_DetectAttestationBatch_OverlappingAggregates(t *testing.T) {
+	tests := []struct {
+		name     string
+		detector func(slasherDB db.Database) iface.SpanDetector
+	}{
+		{
+			name: "span detector",
+			detector: func(slasherDB db.Database) iface.SpanDetector {
+				return attestations.NewSpanDetector(slasherDB)
+			},
+		},
+		{
+			name: "chunked span detector",
+			detector: func(slasherDB db.Database) iface.SpanDetector {
+				return attestations.NewChunkedSpanDetector(slasherDB, types.DefaultChunkParams())
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := testDB.SetupSlasherDB(t, false)
+			defer testDB.TeardownSlasherDB(t, db)
+			ctx := context.Background()
+			ds := Service{
+				ctx:                   ctx,
+				slasherDB:             db,
+				minMaxSpanDetector:    tt.detector(db),
+				attesterSlashingsFeed: new(event.Feed),
+			}
+			newAtt := func(indices []uint64, source uint64, target uint64, sig []byte) *ethpb.IndexedAttestation {
+				return &ethpb.IndexedAttestation{
+					AttestingIndices: indices,
+					Data: &ethpb.AttestationData{
+						Source: &ethpb.Checkpoint{Epoch: source},
+						Target: &ethpb.Checkpoint{Epoch: target},
+					},
+					Signature: bytesutil.PadTo(sig, 96),
+				}
+			}
+			slashingsChan := make(chan *ethpb.AttesterSlashing, 2)
+			sub := ds.attesterSlashingsFeed.Subscribe(slashingsChan)
+			defer sub.Unsubscribe()
+
+			// Aggregates of the same data are not slashable, even though validator 2
+			// appears in both with different signatures.
+			aggregates := []*ethpb.IndexedAttestation{
+				newAtt([]uint64{1, 2}, 1, 3, []byte{1, 2}),
+				newAtt([]uint64{2, 3}, 1, 3, []byte{3, 4}),
+			}
+			if err := db.SaveIndexedAttestations(ctx, aggregates); err != nil {
+				t.Fatal(err)
+			}
+			if err := ds.detectAttestations(ctx, aggregates); err != nil {
+				t.Fatal(err)
+			}
+			if len(slashingsChan) != 0 {
+				t.Fatalf("Expected no slashings for aggregates of the same data, received %d", len(slashingsChan))
+			}
+
+			// Validator 3 only attested in the second aggregate, so the surround vote is
+			// only detected if its spans were updated.
+			surrounding := []*ethpb.IndexedAttestation{newAtt([]uint64{3}, 0, 4, []byte{5, 6})}
+			if err := db.SaveIndexedAttestations(ctx, surrounding); err != nil {
+				t.Fatal(err)
+			}
+			if err := ds.detectAttestations(ctx, surrounding); err != nil {
+				t.Fatal(err)
+			}
+			if len(slashingsChan) != 1 {
+				t.Fatalf("Expected 1 slashing, received %d", len(slashingsChan))
+			}
+			slashing := <-slashingsChan
+			if !isSurrounding(slashing.Attestation_1, slashing.Attestation_2) && !isSurrounding(slashing.Attestation_2, slashing.Attestation_1) {
+				t.Errorf("Expected a surround vote slashing, received %v", slashing)
+			}
+		})
+	}
+}
@@ -22,4 +22,21 @@ var (
 		Name: "surrounded_votes_detected_total",
 		Help: "The # of surrounded slashable events detected",
 	})
+	attestationQueueSize = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "attestation_detection_queue_size",
+		Help: "The # of attestations waiting for detection",
+	})
+	attestationBatchSize = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "attestation_detection_batch_size",
+		Help:    "The # of attestations processed per detection batch",
+		Buckets: []float64{16, 64, 256, 1024, 4096},
+	})
+	attestationBatchDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name: "attestation_detection_batch_duration_seconds",
+		Help: "The time it takes to run detection on a batch of attestations",
+	})
+	fullAttestationBatches = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "attestation_detection_full_batches_total",
+		Help: "The # of batches processed early because the queue was full, blocking incoming attestations",
+	})
 )
@@ -6,7 +6,6 @@ import (
 
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/prysmaticlabs/prysm/slasher/db"
-	status "github.com/prysmaticlabs/prysm/slasher/db/types"
 	"go.opencensus.io/trace"
 )
 
@@ -24,6 +23,7 @@ func NewProposeDetector(db db.Database) *ProposeDetector {
 }
 
 // DetectDoublePropose detects double proposals given a block by looking in the db.
+// The slashing found is not saved.
 func (dd *ProposeDetector) DetectDoublePropose(
 	ctx context.Context,
 	incomingBlk *ethpb.SignedBeaconBlockHeader,
@@ -38,12 +38,7 @@ func (dd *ProposeDetector) DetectDoublePropose(
 		if bytes.Equal(bh.Signature, incomingBlk.Signature) {
 			continue
 		}
-		ps := &ethpb.ProposerSlashing{Header_1: incomingBlk, Header_2: bh}
-		err := dd.slasherDB.SaveProposerSlashing(ctx, status.Active, ps)
-		if err != nil {
-			return nil, err
-		}
-		return ps, nil
+		return &ethpb.ProposerSlashing{Header_1: incomingBlk, Header_2: bh}, nil
 	}
 	return nil, nil
 }
@@ -10,6 +10,7 @@ import (
 	"github.com/prysmaticlabs/prysm/slasher/db"
 	"github.com/prysmaticlabs/prysm/slasher/detection/attestations"
 	"github.com/prysmaticlabs/prysm/slasher/detection/attestations/iface"
+	"github.com/prysmaticlabs/prysm/slasher/detection/attestations/types"
 	"github.com/prysmaticlabs/prysm/slasher/detection/proposals"
 	proposerIface "github.com/prysmaticlabs/prysm/slasher/detection/proposals/iface"
 	"github.com/sirupsen/logrus"
@@ -32,6 +33,9 @@ type Service struct {
 	proposerSlashingsFeed *event.Feed
 	minMaxSpanDetector    iface.SpanDetector
 	proposalsDetector     proposerIface.ProposalsDetector
+	historicalWorkers     int
+	historicalTrigger     chan struct{}
+	historicalProgress    *historicalProgress
 }
 
 // Config options for the detection service.
@@ -42,11 +46,24 @@ type Config struct {
 	BeaconClient          *beaconclient.Service
 	AttesterSlashingsFeed *event.Feed
 	ProposerSlashingsFeed *event.Feed
+	// ChunkedSpans enables storing min-max spans in fixed size chunks.
+	ChunkedSpans bool
+	// HistoricalDetectionWorkers is the number of epoch ranges of historical
+	// chain data requested concurrently from the beacon node.
+	HistoricalDetectionWorkers int
 }
 
 // NewDetectionService instantiation.
 func NewDetectionService(ctx context.Context, cfg *Config) *Service {
 	ctx, cancel := context.WithCancel(ctx)
+	var spanDetector iface.SpanDetector = attestations.NewSpanDetector(cfg.SlasherDB)
+	if cfg.ChunkedSpans {
+		spanDetector = attestations.NewChunkedSpanDetector(cfg.SlasherDB, types.DefaultChunkParams())
+	}
+	historicalWorkers := cfg.HistoricalDetectionWorkers
+	if historicalWorkers <= 0 {
+		historicalWorkers = defaultHistoricalDetectionWorkers
+	}
 	return &Service{
 		ctx:                   ctx,
 		cancel:                cancel,
@@ -58,8 +75,11 @@ func NewDetectionService(ctx context.Context, cfg *Config) *Service {
 		attsChan:              make(chan *ethpb.IndexedAttestation, 1),
 		attesterSlashingsFeed: cfg.AttesterSlashingsFeed,
 		proposerSlashingsFeed: cfg.ProposerSlashingsFeed,
-		minMaxSpanDetector:    attestations.NewSpanDetector(cfg.SlasherDB),
+		minMaxSpanDetector:    spanDetector,
 		proposalsDetector:     proposals.NewProposeDetector(cfg.SlasherDB),
+		historicalWorkers:     historicalWorkers,
+		historicalTrigger:     make(chan struct{}, 1),
+		historicalProgress:    &historicalProgress{},
 	}
 }
 
@@ -78,6 +98,13 @@ func (ds *Service) Status() error {
 
 // Start the detection service runtime.
 func (ds *Service) Start() {
+	// We subscribe to incoming blocks from the beacon node via
+	// our gRPC client to keep detecting slashable offenses. The
+	// subscriptions are made before the client is ready, so that
+	// no block or attestation sent once it is ready is missed.
+	blocksSub := ds.notifier.BlockFeed().Subscribe(ds.blocksChan)
+	attsSub := ds.notifier.AttestationFeed().Subscribe(ds.attsChan)
+
 	// We wait for the gRPC beacon client to be ready and the beacon node
 	// to be fully synced before proceeding.
 	ch := make(chan bool)
@@ -89,60 +116,9 @@ func (ds *Service) Start() {
 	// chain data since genesis.
 	// TODO(#5030): Re-enable after issue is resolved.
 
-	// We subscribe to incoming blocks from the beacon node via
-	// our gRPC client to keep detecting slashable offenses.
-	go ds.detectIncomingBlocks(ds.ctx, ds.blocksChan)
-	go ds.detectIncomingAttestations(ds.ctx, ds.attsChan)
-	go ds.detectHistoricalChainData(ds.ctx)
-}
-
-func (ds *Service) detectHistoricalChainData(ctx context.Context) {
-	ctx, span := trace.StartSpan(ctx, "detection.detectHistoricalChainData")
-	defer span.End()
-	// We fetch both the latest persisted chain head in our DB as well
-	// as the current chain head from the beacon node via gRPC.
-	latestStoredHead, err := ds.slasherDB.ChainHead(ctx)
-	if err != nil {
-		log.WithError(err).Fatal("Could not retrieve chain head from DB")
-	}
-	currentChainHead, err := ds.chainFetcher.ChainHead(ctx)
-	if err != nil {
-		log.WithError(err).Fatal("Cannot retrieve chain head from beacon node")
-	}
-	var latestStoredEpoch uint64
-	if latestStoredHead != nil {
-		latestStoredEpoch = latestStoredHead.HeadEpoch
-	}
-
-	// We retrieve historical chain data from the last persisted chain head in the
-	// slasher DB up to the current beacon node's head epoch we retrieved via gRPC.
-	// If no data was persisted from previous sessions, we request data starting from
-	// the genesis epoch.
-	for epoch := latestStoredEpoch; epoch < currentChainHead.HeadEpoch; epoch++ {
-		indexedAtts, err := ds.beaconClient.RequestHistoricalAttestations(ctx, epoch)
-		if err != nil {
-			log.WithError(err).Errorf("Could not fetch attestations for epoch: %d", epoch)
-		}
-		log.Debugf(
-			"Running slashing detection on %d attestations in epoch %d...",
-			len(indexedAtts),
-			epoch,
-		)
-
-		for _, att := range indexedAtts {
-			slashings, err := ds.DetectAttesterSlashings(ctx, att)
-			if err != nil {
-				log.WithError(err).Error("Could not detect attester slashings")
-				continue
-			}
-			ds.submitAttesterSlashings(ctx, slashings)
-		}
-		latestStoredHead = &ethpb.ChainHead{HeadEpoch: epoch}
-		if err := ds.slasherDB.SaveChainHead(ctx, latestStoredHead); err != nil {
-			log.WithError(err).Error("Could not persist chain head to disk")
-		}
-	}
-	log.Infof("Completed slashing detection on historical chain data up to epoch %d", currentChainHead.HeadEpoch)
+	go ds.detectIncomingBlocks(ds.ctx, ds.blocksChan, blocksSub)
+	go ds.detectIncomingAttestations(ds.ctx, ds.attsChan, attsSub)
+	go ds.runHistoricalDetection(ds.ctx)
 }
 
 func (ds *Service) submitAttesterSlashings(ctx context.Context, slashings []*ethpb.AttesterSlashing) {
@@ -16,6 +16,19 @@ var (
 		Usage: "Beacon node RPC provider endpoint",
 		Value: "localhost:4000",
 	}
+	// AdditionalBeaconRPCProvidersFlag defines a flag for beacon nodes to stream blocks and attestations from,
+	// in addition to the beacon RPC provider.
+	AdditionalBeaconRPCProvidersFlag = &cli.StringSliceFlag{
+		Name: "additional-beacon-rpc-providers",
+		Usage: "Additional beacon node RPC provider endpoints to receive blocks and attestations from. " +
+			"Chain data is only requested from the beacon RPC provider. Multiple endpoints can be passed by repeating the flag.",
+	}
+	// EnableGossipStreamsFlag defines a flag to receive the blocks and attestations a beacon node received over gossip.
+	EnableGossipStreamsFlag = &cli.BoolFlag{
+		Name: "enable-gossip-streams",
+		Usage: "Receive blocks and attestations as soon as beacon nodes receive them over gossip, " +
+			"including blocks and attestations which are rejected by fork choice.",
+	}
 	// CertFlag defines a flag for the node's TLS certificate.
 	CertFlag = &cli.StringFlag{
 		Name:  "tls-cert",
@@ -38,9 +51,43 @@ var (
 		Usage: "RPC port exposed by the slasher",
 		Value: 5000,
 	}
+	// GRPCGatewayPort enables a gRPC gateway to be exposed for the slasher.
+	GRPCGatewayPort = &cli.IntFlag{
+		Name:  "grpc-gateway-port",
+		Usage: "Enable gRPC gateway for JSON requests",
+	}
+	// ChunkedSpansFlag enables storing min-max spans in fixed size chunks of validators and epochs.
+	ChunkedSpansFlag = &cli.BoolFlag{
+		Name:  "chunked-spans",
+		Usage: "Store min-max spans in fixed size chunks of validators and epochs, migrating existing span maps on start",
+	}
 	// RebuildSpanMapsFlag iterate through all indexed attestations in db and update all validators span maps from scratch.
+	// Deprecated: epoch ranges are re-scanned with the RescanEpochRange RPC instead.
 	RebuildSpanMapsFlag = &cli.BoolFlag{
-		Name:  "rebuild-span-maps",
-		Usage: "Rebuild span maps from indexed attestations in db",
+		Name:   "rebuild-span-maps",
+		Usage:  "Deprecated, use the RescanEpochRange RPC to run detection on an epoch range again",
+		Hidden: true,
+	}
+	// HistoricalDetectionWorkersFlag defines the number of epoch ranges of historical chain data requested concurrently.
+	HistoricalDetectionWorkersFlag = &cli.IntFlag{
+		Name:  "historical-detection-workers",
+		Usage: "Number of epoch ranges of historical chain data requested concurrently from the beacon node for slashing detection",
+		Value: 4,
+	}
+	// SlashingResubmitEpochsFlag defines the number of epochs after which detected slashings not included on chain are submitted again.
+	SlashingResubmitEpochsFlag = &cli.Uint64Flag{
+		Name:  "slashing-resubmit-epochs",
+		Usage: "Number of epochs after which detected slashings which were not included on chain are submitted to the beacon node again",
+		Value: 4,
+	}
+	// PruningEpochsFlag defines the number of epochs slasher data is kept for before it is pruned.
+	PruningEpochsFlag = &cli.Uint64Flag{
+		Name:  "pruning-epochs",
+		Usage: "Number of epochs attestations, block headers, spans and included slashings are kept for before they are pruned, defaults to the weak subjectivity period if 0",
+	}
+	// DisableDBCompactionFlag disables compacting the slasher database after pruning.
+	DisableDBCompactionFlag = &cli.BoolFlag{
+		Name:  "disable-db-compaction",
+		Usage: "Disables compacting the database after pruning, which releases the space of pruned data on disk but blocks database access while running",
 	}
 )
@@ -0,0 +1,102 @@
+// Package gateway defines a gRPC gateway to serve HTTP JSON traffic as a proxy
+// and forward it to the slasher gRPC server.
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	gwruntime "github.com/grpc-ecosystem/grpc-gateway/runtime"
+	slashpb "github.com/prysmaticlabs/prysm/proto/slashing_gateway"
+	"github.com/prysmaticlabs/prysm/shared"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+)
+
+var log = logrus.WithField("prefix", "gateway")
+
+var _ = shared.Service(&Gateway{})
+
+// Gateway is the gRPC gateway to serve HTTP JSON traffic as a proxy and forward
+// it to the slasher gRPC server.
+type Gateway struct {
+	conn        *grpc.ClientConn
+	ctx         context.Context
+	cancel      context.CancelFunc
+	gatewayAddr string
+	remoteAddr  string
+	server      *http.Server
+
+	startFailure error
+}
+
+// New returns a new gateway server which translates HTTP into gRPC.
+func New(ctx context.Context, remoteAddress, gatewayAddress string) *Gateway {
+	return &Gateway{
+		remoteAddr:  remoteAddress,
+		gatewayAddr: gatewayAddress,
+		ctx:         ctx,
+	}
+}
+
+// Start the gateway service. This serves the HTTP JSON traffic on the specified
+// port.
+func (g *Gateway) Start() {
+	ctx, cancel := context.WithCancel(g.ctx)
+	g.cancel = cancel
+
+	log.WithField("address", g.gatewayAddr).Info("Starting gRPC gateway")
+
+	conn, err := grpc.DialContext(ctx, g.remoteAddr, grpc.WithInsecure())
+	if err != nil {
+		log.WithError(err).Error("Failed to connect to gRPC server")
+		g.startFailure = err
+		return
+	}
+	g.conn = conn
+
+	gwmux := gwruntime.NewServeMux(gwruntime.WithMarshalerOption(gwruntime.MIMEWildcard, &gwruntime.JSONPb{OrigName: false, EmitDefaults: true}))
+	if err := slashpb.RegisterSlasherHandler(ctx, gwmux, conn); err != nil {
+		log.WithError(err).Error("Failed to start gateway")
+		g.startFailure = err
+		return
+	}
+
+	g.server = &http.Server{
+		Addr:    g.gatewayAddr,
+		Handler: gwmux,
+	}
+	go func() {
+		if err := g.server.ListenAndServe(); err != http.ErrServerClosed {
+			log.WithError(err).Error("Failed to listen and serve")
+			g.startFailure = err
+			return
+		}
+	}()
+}
+
+// Status of grpc gateway. Returns an error if this service is unhealthy.
+func (g *Gateway) Status() error {
+	if g.startFailure != nil {
+		return g.startFailure
+	}
+	if s := g.conn.GetState(); s != connectivity.Ready {
+		return fmt.Errorf("grpc server is %s", s)
+	}
+	return nil
+}
+
+// Stop the gateway with a graceful shutdown.
+func (g *Gateway) Stop() error {
+	if g.server != nil {
+		if err := g.server.Shutdown(g.ctx); err != nil {
+			log.WithError(err).Error("Failed to shut down server")
+		}
+	}
+	if g.cancel != nil {
+		g.cancel()
+	}
+	return nil
+}
@@ -55,10 +55,18 @@ var appFlags = []cli.Flag{
 	debug.CPUProfileFlag,
 	debug.TraceFlag,
 	flags.RPCPort,
+	flags.GRPCGatewayPort,
 	flags.KeyFlag,
 	flags.RebuildSpanMapsFlag,
+	flags.ChunkedSpansFlag,
+	flags.HistoricalDetectionWorkersFlag,
+	flags.SlashingResubmitEpochsFlag,
+	flags.PruningEpochsFlag,
+	flags.DisableDBCompactionFlag,
 	flags.BeaconCertFlag,
 	flags.BeaconRPCProviderFlag,
+	flags.AdditionalBeaconRPCProvidersFlag,
+	flags.EnableGossipStreamsFlag,
 }
 
 func init() {
@@ -20,7 +20,10 @@ import (
 	"github.com/prysmaticlabs/prysm/slasher/db"
 	"github.com/prysmaticlabs/prysm/slasher/db/kv"
 	"github.com/prysmaticlabs/prysm/slasher/detection"
+	"github.com/prysmaticlabs/prysm/slasher/detection/attestations/types"
 	"github.com/prysmaticlabs/prysm/slasher/flags"
+	"github.com/prysmaticlabs/prysm/slasher/gateway"
+	"github.com/prysmaticlabs/prysm/slasher/pruning"
 	"github.com/prysmaticlabs/prysm/slasher/rpc"
 	"github.com/sirupsen/logrus"
 	"gopkg.in/urfave/cli.v2"
@@ -77,7 +80,11 @@ func NewSlasherNode(ctx *cli.Context) (*SlasherNode, error) {
 		return nil, err
 	}
 
-	if err := slasher.registerDetectionService(); err != nil {
+	if err := slasher.registerDetectionService(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := slasher.registerPruningService(ctx); err != nil {
 		return nil, err
 	}
 
@@ -85,6 +92,10 @@ func NewSlasherNode(ctx *cli.Context) (*SlasherNode, error) {
 		return nil, err
 	}
 
+	if err := slasher.registerGRPCGateway(ctx); err != nil {
+		return nil, err
+	}
+
 	return slasher, nil
 }
 
@@ -169,6 +180,26 @@ func (s *SlasherNode) startDB(ctx *cli.Context) error {
 		}
 	}
 	log.WithField("database-path", baseDir).Info("Checking DB")
+	if err := d.MigrateAttestationRoots(context.Background()); err != nil {
+		return errors.Wrap(err, "could not index attestation data roots")
+	}
+	if ctx.Bool(flags.ChunkedSpansFlag.Name) {
+		log.Info("Migrating span maps into span chunks")
+		if err := d.MigrateEpochSpansToChunks(context.Background(), types.DefaultChunkParams()); err != nil {
+			return errors.Wrap(err, "could not migrate span maps into span chunks")
+		}
+	} else {
+		migrated, err := d.SpanChunksMigrated(context.Background())
+		if err != nil {
+			return errors.Wrap(err, "could not check span chunks migration")
+		}
+		if migrated {
+			log.Info("Span chunks are disabled, deleting outdated span chunks")
+			if err := d.DeleteSpanChunks(context.Background()); err != nil {
+				return errors.Wrap(err, "could not delete span chunks")
+			}
+		}
+	}
 	s.db = d
 	return nil
 }
@@ -181,11 +212,14 @@ func (s *SlasherNode) registerBeaconClientService(ctx *cli.Context) error {
 	}
 
 	bs, err := beaconclient.NewBeaconClientService(context.Background(), &beaconclient.Config{
-		BeaconCert:            beaconCert,
-		SlasherDB:             s.db,
-		BeaconProvider:        beaconProvider,
-		AttesterSlashingsFeed: s.attesterSlashingsFeed,
-		ProposerSlashingsFeed: s.proposerSlashingsFeed,
+		BeaconCert:                beaconCert,
+		SlasherDB:                 s.db,
+		BeaconProvider:            beaconProvider,
+		AdditionalBeaconProviders: ctx.StringSlice(flags.AdditionalBeaconRPCProvidersFlag.Name),
+		GossipStreams:             ctx.Bool(flags.EnableGossipStreamsFlag.Name),
+		AttesterSlashingsFeed:     s.attesterSlashingsFeed,
+		ProposerSlashingsFeed:     s.proposerSlashingsFeed,
+		SlashingResubmitEpochs:    ctx.Uint64(flags.SlashingResubmitEpochsFlag.Name),
 	})
 	if err != nil {
 		return errors.Wrap(err, "failed to initialize beacon client")
@@ -193,22 +227,38 @@ func (s *SlasherNode) registerBeaconClientService(ctx *cli.Context) error {
 	return s.services.RegisterService(bs)
 }
 
-func (s *SlasherNode) registerDetectionService() error {
+func (s *SlasherNode) registerDetectionService(ctx *cli.Context) error {
 	var bs *beaconclient.Service
 	if err := s.services.FetchService(&bs); err != nil {
 		panic(err)
 	}
 	ds := detection.NewDetectionService(context.Background(), &detection.Config{
-		Notifier:              bs,
-		SlasherDB:             s.db,
-		BeaconClient:          bs,
-		ChainFetcher:          bs,
-		AttesterSlashingsFeed: s.attesterSlashingsFeed,
-		ProposerSlashingsFeed: s.proposerSlashingsFeed,
+		Notifier:                   bs,
+		SlasherDB:                  s.db,
+		BeaconClient:               bs,
+		ChainFetcher:               bs,
+		AttesterSlashingsFeed:      s.attesterSlashingsFeed,
+		ProposerSlashingsFeed:      s.proposerSlashingsFeed,
+		ChunkedSpans:               ctx.Bool(flags.ChunkedSpansFlag.Name),
+		HistoricalDetectionWorkers: ctx.Int(flags.HistoricalDetectionWorkersFlag.Name),
 	})
 	return s.services.RegisterService(ds)
 }
 
+func (s *SlasherNode) registerPruningService(ctx *cli.Context) error {
+	var bs *beaconclient.Service
+	if err := s.services.FetchService(&bs); err != nil {
+		return err
+	}
+	ps := pruning.NewPruningService(context.Background(), &pruning.Config{
+		SlasherDB:         s.db,
+		ChainFetcher:      bs,
+		PruningEpochs:     ctx.Uint64(flags.PruningEpochsFlag.Name),
+		DisableCompaction: ctx.Bool(flags.DisableDBCompactionFlag.Name),
+	})
+	return s.services.RegisterService(ps)
+}
+
 func (s *SlasherNode) registerRPCService(ctx *cli.Context) error {
 	var detectionService *detection.Service
 	if err := s.services.FetchService(&detectionService); err != nil {
@@ -224,7 +274,20 @@ func (s *SlasherNode) registerRPCService(ctx *cli.Context) error {
 		KeyFlag:   key,
 		Detector:  detectionService,
 		SlasherDB: s.db,
+
+		AttesterSlashingsFeed: s.attesterSlashingsFeed,
+		ProposerSlashingsFeed: s.proposerSlashingsFeed,
 	})
 
 	return s.services.RegisterService(rpcService)
 }
+
+func (s *SlasherNode) registerGRPCGateway(ctx *cli.Context) error {
+	gatewayPort := ctx.Int(flags.GRPCGatewayPort.Name)
+	if gatewayPort > 0 {
+		selfAddress := fmt.Sprintf("127.0.0.1:%d", ctx.Int(flags.RPCPort.Name))
+		gatewayAddress := fmt.Sprintf("0.0.0.0:%d", gatewayPort)
+		return s.services.RegisterService(gateway.New(context.Background(), selfAddress, gatewayAddress))
+	}
+	return nil
+}
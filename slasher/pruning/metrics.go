@@ -0,0 +1,31 @@
+package pruning
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	pruningDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "slasher_db_pruning_duration_seconds",
+		Help:    "The time it took to prune the slasher database",
+		Buckets: prometheus.ExponentialBuckets(1, 2, 12),
+	})
+	compactionDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "slasher_db_compaction_duration_seconds",
+		Help:    "The time it took to compact the slasher database",
+		Buckets: prometheus.ExponentialBuckets(1, 2, 12),
+	})
+	compactionFreedBytes = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "slasher_db_compaction_freed_bytes",
+		Help: "The # of bytes freed on disk by the last compaction of the slasher database",
+	})
+	compactionFreedBytesTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "slasher_db_compaction_freed_bytes_total",
+		Help: "The # of bytes freed on disk by compactions of the slasher database",
+	})
+	databaseSize = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "slasher_db_size_bytes",
+		Help: "The size of the slasher database file after the last pruning",
+	})
+)
@@ -0,0 +1,173 @@
+// Package pruning defines a service which periodically removes slasher data older
+// than a configurable number of epochs from the database and compacts it.
+package pruning
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/shared"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/slasher/beaconclient"
+	"github.com/prysmaticlabs/prysm/slasher/db"
+	"github.com/prysmaticlabs/prysm/slasher/detection/attestations/types"
+	"github.com/sirupsen/logrus"
+	"go.opencensus.io/trace"
+)
+
+var log = logrus.WithField("prefix", "pruning")
+
+var _ = shared.Service(&Service{})
+
+// defaultCompactionThreshold is the free space of the database, in bytes, above which
+// it is compacted after pruning.
+const defaultCompactionThreshold = 64 * 1024 * 1024
+
+// Service prunes the slasher database every PruneSlasherStoragePeriod epochs.
+type Service struct {
+	ctx                 context.Context
+	cancel              context.CancelFunc
+	slasherDB           db.Database
+	chainFetcher        beaconclient.ChainFetcher
+	pruningEpochs       uint64
+	disableCompaction   bool
+	compactionThreshold int64
+	lastPrunedEpoch     uint64
+}
+
+// Config options for the pruning service.
+type Config struct {
+	SlasherDB    db.Database
+	ChainFetcher beaconclient.ChainFetcher
+	// PruningEpochs is the number of epochs data is kept for, defaults to
+	// the weak subjectivity period.
+	PruningEpochs uint64
+	// DisableCompaction disables compacting the database after pruning.
+	DisableCompaction bool
+	// CompactionThreshold is the free space of the database in bytes above which it is
+	// compacted after pruning, defaults to 64MiB.
+	CompactionThreshold int64
+}
+
+// NewPruningService instantiation.
+func NewPruningService(ctx context.Context, cfg *Config) *Service {
+	ctx, cancel := context.WithCancel(ctx)
+	pruningEpochs := cfg.PruningEpochs
+	if pruningEpochs == 0 {
+		pruningEpochs = params.BeaconConfig().WeakSubjectivityPeriod
+	}
+	compactionThreshold := cfg.CompactionThreshold
+	if compactionThreshold == 0 {
+		compactionThreshold = defaultCompactionThreshold
+	}
+	return &Service{
+		ctx:                 ctx,
+		cancel:              cancel,
+		slasherDB:           cfg.SlasherDB,
+		chainFetcher:        cfg.ChainFetcher,
+		pruningEpochs:       pruningEpochs,
+		disableCompaction:   cfg.DisableCompaction,
+		compactionThreshold: compactionThreshold,
+	}
+}
+
+// Start the pruning service runtime.
+func (s *Service) Start() {
+	log.WithField("pruningEpochs", s.pruningEpochs).Info("Starting service")
+	go s.run(s.ctx)
+}
+
+// Stop the pruning service.
+func (s *Service) Stop() error {
+	s.cancel()
+	log.Info("Stopping service")
+	return nil
+}
+
+// Status returns an error if there exists an error in
+// the pruning service.
+func (s *Service) Status() error {
+	return nil
+}
+
+// run checks the head epoch of the beacon node once per epoch and prunes the
+// database whenever PruneSlasherStoragePeriod epochs passed since the last run.
+func (s *Service) run(ctx context.Context) {
+	secondsPerEpoch := params.BeaconConfig().SecondsPerSlot * params.BeaconConfig().SlotsPerEpoch
+	ticker := time.NewTicker(time.Duration(secondsPerEpoch) * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			head, err := s.chainFetcher.ChainHead(ctx)
+			if err != nil {
+				log.WithError(err).Error("Could not retrieve chain head from beacon node")
+				continue
+			}
+			if s.lastPrunedEpoch != 0 && head.HeadEpoch < s.lastPrunedEpoch+params.BeaconConfig().PruneSlasherStoragePeriod {
+				continue
+			}
+			if err := s.prune(ctx, head.HeadEpoch); err != nil {
+				log.WithError(err).Error("Could not prune slasher database")
+				continue
+			}
+			s.lastPrunedEpoch = head.HeadEpoch
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// prune removes indexed attestations, block headers, epoch spans, span chunks and included
+// slashings older than the pruning epochs from the database. The database is compacted
+// once enough space is free, as compacting rewrites the whole file and blocks access to it.
+func (s *Service) prune(ctx context.Context, currentEpoch uint64) error {
+	ctx, span := trace.StartSpan(ctx, "pruning.prune")
+	defer span.End()
+	start := time.Now()
+	if err := s.slasherDB.PruneAttHistory(ctx, currentEpoch, s.pruningEpochs); err != nil {
+		return errors.Wrap(err, "could not prune indexed attestations")
+	}
+	if err := s.slasherDB.PruneBlockHistory(ctx, currentEpoch, s.pruningEpochs); err != nil {
+		return errors.Wrap(err, "could not prune block headers")
+	}
+	if err := s.slasherDB.PruneEpochSpans(ctx, currentEpoch, s.pruningEpochs); err != nil {
+		return errors.Wrap(err, "could not prune epoch spans")
+	}
+	if err := s.slasherDB.PruneSpanChunks(ctx, types.DefaultChunkParams(), currentEpoch, s.pruningEpochs); err != nil {
+		return errors.Wrap(err, "could not prune span chunks")
+	}
+	if err := s.slasherDB.PruneSlashings(ctx, currentEpoch, s.pruningEpochs); err != nil {
+		return errors.Wrap(err, "could not prune slashings")
+	}
+	pruningDuration.Observe(time.Since(start).Seconds())
+
+	var freed int64
+	free := s.slasherDB.FreeSpace()
+	if !s.disableCompaction && free >= s.compactionThreshold {
+		compactionStart := time.Now()
+		var err error
+		freed, err = s.slasherDB.Compact(ctx)
+		if err != nil {
+			return errors.Wrap(err, "could not compact database")
+		}
+		compactionDuration.Observe(time.Since(compactionStart).Seconds())
+		compactionFreedBytes.Set(float64(freed))
+		if freed > 0 {
+			compactionFreedBytesTotal.Add(float64(freed))
+		}
+	}
+	if info, err := os.Stat(s.slasherDB.DatabasePath()); err == nil {
+		databaseSize.Set(float64(info.Size()))
+	}
+	log.WithFields(logrus.Fields{
+		"currentEpoch": currentEpoch,
+		"pruningEpoch": int64(currentEpoch) - int64(s.pruningEpochs),
+		"freeBytes":    free,
+		"freedBytes":   freed,
+		"duration":     time.Since(start),
+	}).Info("Pruned slasher database")
+	return nil
+}
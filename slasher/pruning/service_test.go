@@ -0,0 +1,69 @@
+package pruning
+
+import (
+	"context"
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	testDB "github.com/prysmaticlabs/prysm/slasher/db/testing"
+)
+
+func TestService_Prune(t *testing.T) {
+	db := testDB.SetupSlasherDB(t, false)
+	defer testDB.TeardownSlasherDB(t, db)
+	ctx := context.Background()
+
+	s := NewPruningService(ctx, &Config{
+		SlasherDB:     db,
+		PruningEpochs: 10,
+	})
+	var atts []*ethpb.IndexedAttestation
+	var headers []*ethpb.SignedBeaconBlockHeader
+	for _, epoch := range []uint64{1, 9, 10, 11, 20} {
+		att := &ethpb.IndexedAttestation{
+			AttestingIndices: []uint64{epoch},
+			Data: &ethpb.AttestationData{
+				Source: &ethpb.Checkpoint{Epoch: epoch - 1},
+				Target: &ethpb.Checkpoint{Epoch: epoch},
+			},
+			Signature: []byte{byte(epoch)},
+		}
+		if err := db.SaveIndexedAttestation(ctx, att); err != nil {
+			t.Fatal(err)
+		}
+		atts = append(atts, att)
+		header := &ethpb.SignedBeaconBlockHeader{
+			Header: &ethpb.BeaconBlockHeader{
+				Slot:          epoch*params.BeaconConfig().SlotsPerEpoch + 1,
+				ProposerIndex: epoch,
+			},
+			Signature: []byte{byte(epoch)},
+		}
+		if err := db.SaveBlockHeader(ctx, header); err != nil {
+			t.Fatal(err)
+		}
+		headers = append(headers, header)
+	}
+
+	if err := s.prune(ctx, 20); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, att := range atts {
+		exists, err := db.HasIndexedAttestation(ctx, att)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if pruned := att.Data.Target.Epoch <= 10; exists == pruned {
+			t.Errorf("Expected attestation with target epoch %d to exist: %v", att.Data.Target.Epoch, !pruned)
+		}
+	}
+	for _, header := range headers {
+		epoch := header.Header.ProposerIndex
+		exists := db.HasBlockHeader(ctx, header.Header.Slot, header.Header.ProposerIndex)
+		if pruned := epoch <= 10; exists == pruned {
+			t.Errorf("Expected block header of epoch %d to exist: %v", epoch, !pruned)
+		}
+	}
+}
@@ -2,10 +2,13 @@ package rpc
 
 import (
 	"context"
+	"sort"
+	"sync"
 
-	"github.com/pkg/errors"
+	ptypes "github.com/gogo/protobuf/types"
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	slashpb "github.com/prysmaticlabs/prysm/proto/slashing"
+	"github.com/prysmaticlabs/prysm/shared/event"
 	"github.com/prysmaticlabs/prysm/slasher/db"
 	"github.com/prysmaticlabs/prysm/slasher/detection"
 	log "github.com/sirupsen/logrus"
@@ -17,26 +20,60 @@ import (
 // Server defines a server implementation of the gRPC Slasher service,
 // providing RPC endpoints for retrieving slashing proofs for malicious validators.
 type Server struct {
-	ctx       context.Context
-	detector  *detection.Service
-	slasherDB db.Database
+	ctx                   context.Context
+	detector              *detection.Service
+	slasherDB             db.Database
+	attesterSlashingsFeed *event.Feed
+	proposerSlashingsFeed *event.Feed
+	validatorLocks        [validatorLockStripes]sync.Mutex
+}
+
+// validatorLockStripes is the number of locks checks of attestations and blocks are serialized on.
+// Checks of the same validator always take the same lock, so that two conflicting attestations or
+// blocks checked at the same time cannot both pass detection before either is recorded.
+const validatorLockStripes = 256
+
+// lockValidators locks the checks of the given validator indices, and returns the function
+// unlocking them. Locks are taken in increasing order so that concurrent checks do not deadlock.
+func (ss *Server) lockValidators(indices []uint64) func() {
+	stripes := make([]int, 0, len(indices))
+	seen := make(map[int]bool, len(indices))
+	for _, idx := range indices {
+		stripe := int(idx % validatorLockStripes)
+		if !seen[stripe] {
+			seen[stripe] = true
+			stripes = append(stripes, stripe)
+		}
+	}
+	sort.Ints(stripes)
+	for _, stripe := range stripes {
+		ss.validatorLocks[stripe].Lock()
+	}
+	return func() {
+		for _, stripe := range stripes {
+			ss.validatorLocks[stripe].Unlock()
+		}
+	}
 }
 
 // IsSlashableAttestation returns an attester slashing if the attestation submitted
-// is a slashable vote.
+// is a slashable vote. A slashable attestation is neither saved nor are its slashings,
+// as it is expected to be rejected by the validator which requested the check.
 func (ss *Server) IsSlashableAttestation(ctx context.Context, req *ethpb.IndexedAttestation) (*slashpb.AttesterSlashingResponse, error) {
 	ctx, span := trace.StartSpan(ctx, "detection.IsSlashableAttestation")
 	defer span.End()
 	//TODO(#5189) add signature validation to prevent DOS attack on the endpoint.
-	if err := ss.slasherDB.SaveIndexedAttestation(ctx, req); err != nil {
-		log.WithError(err).Error("Could not save indexed attestation")
-		return nil, status.Errorf(codes.Internal, "Could not save indexed attestation: %v: %v", req, err)
-	}
+	unlock := ss.lockValidators(req.AttestingIndices)
+	defer unlock()
 	slashings, err := ss.detector.DetectAttesterSlashings(ctx, req)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "Could not detect attester slashings for attestation: %v: %v", req, err)
 	}
 	if len(slashings) < 1 {
+		if err := ss.slasherDB.SaveIndexedAttestation(ctx, req); err != nil {
+			log.WithError(err).Error("Could not save indexed attestation")
+			return nil, status.Errorf(codes.Internal, "Could not save indexed attestation: %v: %v", req, err)
+		}
 		if err := ss.detector.UpdateSpans(ctx, req); err != nil {
 			log.WithError(err).Error("Could not update spans")
 		}
@@ -47,7 +84,51 @@ func (ss *Server) IsSlashableAttestation(ctx context.Context, req *ethpb.Indexed
 }
 
 // IsSlashableBlock returns an proposer slashing if the block submitted
-// is a double proposal.
+// is a double proposal. A slashable block header is neither saved nor is its slashing.
 func (ss *Server) IsSlashableBlock(ctx context.Context, req *ethpb.SignedBeaconBlockHeader) (*slashpb.ProposerSlashingResponse, error) {
-	return nil, errors.New("unimplemented")
+	ctx, span := trace.StartSpan(ctx, "detection.IsSlashableBlock")
+	defer span.End()
+	if req.Header == nil {
+		return nil, status.Error(codes.InvalidArgument, "Block header cannot be nil")
+	}
+	unlock := ss.lockValidators([]uint64{req.Header.ProposerIndex})
+	defer unlock()
+	slashing, err := ss.detector.DetectDoubleProposals(ctx, req)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not detect proposer slashing for block header: %v: %v", req, err)
+	}
+	if slashing == nil {
+		if err := ss.slasherDB.SaveBlockHeader(ctx, req); err != nil {
+			log.WithError(err).Error("Could not save block header")
+			return nil, status.Errorf(codes.Internal, "Could not save block header: %v: %v", req, err)
+		}
+		return &slashpb.ProposerSlashingResponse{}, nil
+	}
+	return &slashpb.ProposerSlashingResponse{
+		ProposerSlashing: []*ethpb.ProposerSlashing{slashing},
+	}, nil
+}
+
+// HistoricalDetectionStatus returns the progress of slashing detection on historical chain data.
+func (ss *Server) HistoricalDetectionStatus(ctx context.Context, _ *ptypes.Empty) (*slashpb.HistoricalDetectionStatusResponse, error) {
+	progress := ss.detector.HistoricalDetectionProgress()
+	return &slashpb.HistoricalDetectionStatusResponse{
+		Running:        progress.Running,
+		HeadEpoch:      progress.HeadEpoch,
+		TotalEpochs:    progress.TotalEpochs,
+		DetectedEpochs: progress.DetectedEpochs,
+		EtaSeconds:     uint64(progress.ETA.Seconds()),
+	}, nil
+}
+
+// RescanEpochRange schedules slashing detection to run again on the historical chain data
+// of an inclusive epoch range.
+func (ss *Server) RescanEpochRange(ctx context.Context, req *slashpb.RescanEpochRangeRequest) (*ptypes.Empty, error) {
+	if req.StartEpoch > req.EndEpoch {
+		return nil, status.Errorf(codes.InvalidArgument, "Start epoch %d cannot be greater than end epoch %d", req.StartEpoch, req.EndEpoch)
+	}
+	if err := ss.detector.RescanEpochRange(ctx, req.StartEpoch, req.EndEpoch); err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not re-scan epoch range: %v", err)
+	}
+	return &ptypes.Empty{}, nil
 }
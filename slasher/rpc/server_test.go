@@ -2,6 +2,7 @@ package rpc
 
 import (
 	"context"
+	"sync"
 	"testing"
 
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
@@ -52,3 +53,139 @@ func Test_DetectionFlow(t *testing.T) {
 		t.Fatalf("only one slashing should have been found. got: %v", len(slashing.AttesterSlashing))
 	}
 }
+
+func TestServer_IsSlashableBlock(t *testing.T) {
+	db := testDB.SetupSlasherDB(t, false)
+	defer testDB.TeardownSlasherDB(t, db)
+	ctx := context.Background()
+	ds := detection.NewDetectionService(ctx, &detection.Config{SlasherDB: db})
+	server := Server{ctx: ctx, detector: ds, slasherDB: db}
+
+	header := func(sig byte) *ethpb.SignedBeaconBlockHeader {
+		return &ethpb.SignedBeaconBlockHeader{
+			Header: &ethpb.BeaconBlockHeader{
+				Slot:          5,
+				ProposerIndex: 3,
+				BodyRoot:      bytesutil.PadTo([]byte{sig}, 32),
+			},
+			Signature: bytesutil.PadTo([]byte{sig}, 96),
+		}
+	}
+	res, err := server.IsSlashableBlock(ctx, header(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.ProposerSlashing) != 0 {
+		t.Fatalf("Expected no slashing on first block, received %v", res.ProposerSlashing)
+	}
+	// Requesting the same block again is not a double proposal.
+	res, err = server.IsSlashableBlock(ctx, header(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.ProposerSlashing) != 0 {
+		t.Fatalf("Expected no slashing on the same block, received %v", res.ProposerSlashing)
+	}
+	res, err = server.IsSlashableBlock(ctx, header(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.ProposerSlashing) != 1 {
+		t.Fatalf("Expected 1 slashing on double proposal, received %d", len(res.ProposerSlashing))
+	}
+}
+
+func TestServer_IsSlashableAttestation_ConcurrentDoubleVotes(t *testing.T) {
+	db := testDB.SetupSlasherDB(t, false)
+	defer testDB.TeardownSlasherDB(t, db)
+	ctx := context.Background()
+	ds := detection.NewDetectionService(ctx, &detection.Config{SlasherDB: db})
+	server := Server{ctx: ctx, detector: ds, slasherDB: db}
+
+	att := func(targetRoot byte) *ethpb.IndexedAttestation {
+		return &ethpb.IndexedAttestation{
+			AttestingIndices: []uint64{3},
+			Data: &ethpb.AttestationData{
+				Source: &ethpb.Checkpoint{Epoch: 3},
+				Target: &ethpb.Checkpoint{Epoch: 4, Root: bytesutil.PadTo([]byte{targetRoot}, 32)},
+			},
+			Signature: bytesutil.PadTo([]byte{targetRoot}, 96),
+		}
+	}
+	// Two validator clients sharing a key request checks of conflicting votes at the same time.
+	start := make(chan struct{})
+	accepted := make(chan bool, 2)
+	var wg sync.WaitGroup
+	for _, root := range []byte{1, 2} {
+		wg.Add(1)
+		go func(req *ethpb.IndexedAttestation) {
+			defer wg.Done()
+			<-start
+			res, err := server.IsSlashableAttestation(ctx, req)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			accepted <- len(res.AttesterSlashing) == 0
+		}(att(root))
+	}
+	close(start)
+	wg.Wait()
+	close(accepted)
+	numAccepted := 0
+	for ok := range accepted {
+		if ok {
+			numAccepted++
+		}
+	}
+	if numAccepted != 1 {
+		t.Errorf("Expected exactly 1 of the conflicting attestations to be accepted, received %d", numAccepted)
+	}
+}
+
+func TestServer_IsSlashableBlock_ConcurrentDoubleProposals(t *testing.T) {
+	db := testDB.SetupSlasherDB(t, false)
+	defer testDB.TeardownSlasherDB(t, db)
+	ctx := context.Background()
+	ds := detection.NewDetectionService(ctx, &detection.Config{SlasherDB: db})
+	server := Server{ctx: ctx, detector: ds, slasherDB: db}
+
+	header := func(sig byte) *ethpb.SignedBeaconBlockHeader {
+		return &ethpb.SignedBeaconBlockHeader{
+			Header: &ethpb.BeaconBlockHeader{
+				Slot:          5,
+				ProposerIndex: 3,
+				BodyRoot:      bytesutil.PadTo([]byte{sig}, 32),
+			},
+			Signature: bytesutil.PadTo([]byte{sig}, 96),
+		}
+	}
+	start := make(chan struct{})
+	accepted := make(chan bool, 2)
+	var wg sync.WaitGroup
+	for _, sig := range []byte{1, 2} {
+		wg.Add(1)
+		go func(req *ethpb.SignedBeaconBlockHeader) {
+			defer wg.Done()
+			<-start
+			res, err := server.IsSlashableBlock(ctx, req)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			accepted <- len(res.ProposerSlashing) == 0
+		}(header(sig))
+	}
+	close(start)
+	wg.Wait()
+	close(accepted)
+	numAccepted := 0
+	for ok := range accepted {
+		if ok {
+			numAccepted++
+		}
+	}
+	if numAccepted != 1 {
+		t.Errorf("Expected exactly 1 of the conflicting blocks to be accepted, received %d", numAccepted)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	grpc_opentracing "github.com/grpc-ecosystem/go-grpc-middleware/tracing/opentracing"
 	grpc_prometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
 	slashpb "github.com/prysmaticlabs/prysm/proto/slashing"
+	"github.com/prysmaticlabs/prysm/shared/event"
 	"github.com/prysmaticlabs/prysm/shared/traceutil"
 	"github.com/prysmaticlabs/prysm/slasher/db"
 	"github.com/prysmaticlabs/prysm/slasher/detection"
@@ -34,6 +35,9 @@ type Service struct {
 	withCert        string
 	withKey         string
 	credentialError error
+
+	attesterSlashingsFeed *event.Feed
+	proposerSlashingsFeed *event.Feed
 }
 
 // Config options for the slasher node RPC server.
@@ -44,6 +48,10 @@ type Config struct {
 	KeyFlag   string
 	Detector  *detection.Service
 	SlasherDB db.Database
+	// AttesterSlashingsFeed and ProposerSlashingsFeed carry the slashings found by
+	// the detection service, which are streamed to clients.
+	AttesterSlashingsFeed *event.Feed
+	ProposerSlashingsFeed *event.Feed
 }
 
 // NewService instantiates a new RPC service instance that will
@@ -57,6 +65,9 @@ func NewService(ctx context.Context, cfg *Config) *Service {
 		port:      cfg.Port,
 		detector:  cfg.Detector,
 		slasherDB: cfg.SlasherDB,
+
+		attesterSlashingsFeed: cfg.AttesterSlashingsFeed,
+		proposerSlashingsFeed: cfg.ProposerSlashingsFeed,
 	}
 }
 
@@ -103,9 +114,11 @@ func (s *Service) Start() {
 	s.grpcServer = grpc.NewServer(opts...)
 
 	slasherServer := &Server{
-		ctx:       s.ctx,
-		detector:  s.detector,
-		slasherDB: s.slasherDB,
+		ctx:                   s.ctx,
+		detector:              s.detector,
+		slasherDB:             s.slasherDB,
+		attesterSlashingsFeed: s.attesterSlashingsFeed,
+		proposerSlashingsFeed: s.proposerSlashingsFeed,
 	}
 	slashpb.RegisterSlasherServer(s.grpcServer, slasherServer)
 
@@ -0,0 +1,129 @@
+package rpc
+
+import (
+	"context"
+
+	ptypes "github.com/gogo/protobuf/types"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	slashpb "github.com/prysmaticlabs/prysm/proto/slashing"
+	"github.com/prysmaticlabs/prysm/shared/params"
+	"github.com/prysmaticlabs/prysm/slasher/db/types"
+	log "github.com/sirupsen/logrus"
+	"go.opencensus.io/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ListAttesterSlashings returns the attester slashings found by the slasher with the requested
+// status, for attestations targeting an epoch in the requested range.
+func (ss *Server) ListAttesterSlashings(ctx context.Context, req *slashpb.ListSlashingsRequest) (*slashpb.AttesterSlashingResponse, error) {
+	ctx, span := trace.StartSpan(ctx, "rpc.ListAttesterSlashings")
+	defer span.End()
+	if req.EndEpoch != 0 && req.StartEpoch > req.EndEpoch {
+		return nil, status.Errorf(codes.InvalidArgument, "Start epoch %d cannot be greater than end epoch %d", req.StartEpoch, req.EndEpoch)
+	}
+	var slashings []*ethpb.AttesterSlashing
+	for _, st := range slashingStatuses(req.Status) {
+		res, err := ss.slasherDB.AttesterSlashings(ctx, st)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not retrieve %s attester slashings: %v", st, err)
+		}
+		for _, slashing := range res {
+			if inEpochRange(slashing.Attestation_1.Data.Target.Epoch, req) {
+				slashings = append(slashings, slashing)
+			}
+		}
+	}
+	return &slashpb.AttesterSlashingResponse{
+		AttesterSlashing: slashings,
+	}, nil
+}
+
+// ListProposerSlashings returns the proposer slashings found by the slasher with the requested
+// status, for proposals in an epoch of the requested range.
+func (ss *Server) ListProposerSlashings(ctx context.Context, req *slashpb.ListSlashingsRequest) (*slashpb.ProposerSlashingResponse, error) {
+	ctx, span := trace.StartSpan(ctx, "rpc.ListProposerSlashings")
+	defer span.End()
+	if req.EndEpoch != 0 && req.StartEpoch > req.EndEpoch {
+		return nil, status.Errorf(codes.InvalidArgument, "Start epoch %d cannot be greater than end epoch %d", req.StartEpoch, req.EndEpoch)
+	}
+	var slashings []*ethpb.ProposerSlashing
+	for _, st := range slashingStatuses(req.Status) {
+		res, err := ss.slasherDB.ProposalSlashingsByStatus(ctx, st)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not retrieve %s proposer slashings: %v", st, err)
+		}
+		for _, slashing := range res {
+			epoch := slashing.Header_1.Header.Slot / params.BeaconConfig().SlotsPerEpoch
+			if inEpochRange(epoch, req) {
+				slashings = append(slashings, slashing)
+			}
+		}
+	}
+	return &slashpb.ProposerSlashingResponse{
+		ProposerSlashing: slashings,
+	}, nil
+}
+
+// streamSlashingsBufferSize is the number of slashings buffered for each slashings stream.
+// Slashings found while the buffer of a stream is full are dropped for that stream, so that
+// a slow client does not hold up the detection services, which send slashings over the feeds.
+const streamSlashingsBufferSize = 256
+
+// StreamSlashings sends attester and proposer slashings over a gRPC stream as they are found.
+func (ss *Server) StreamSlashings(_ *ptypes.Empty, stream slashpb.Slasher_StreamSlashingsServer) error {
+	attesterSlashingsChan := make(chan *ethpb.AttesterSlashing, 1)
+	attSub := ss.attesterSlashingsFeed.Subscribe(attesterSlashingsChan)
+	defer attSub.Unsubscribe()
+	proposerSlashingsChan := make(chan *ethpb.ProposerSlashing, 1)
+	propSub := ss.proposerSlashingsFeed.Subscribe(proposerSlashingsChan)
+	defer propSub.Unsubscribe()
+	events := make(chan *slashpb.SlashingEvent, streamSlashingsBufferSize)
+	go func() {
+		for {
+			var event *slashpb.SlashingEvent
+			select {
+			case slashing := <-attesterSlashingsChan:
+				event = &slashpb.SlashingEvent{AttesterSlashing: slashing}
+			case slashing := <-proposerSlashingsChan:
+				event = &slashpb.SlashingEvent{ProposerSlashing: slashing}
+			case <-stream.Context().Done():
+				return
+			}
+			select {
+			case events <- event:
+			default:
+				log.Warn("Slashings stream buffer full, dropping slashing")
+			}
+		}
+	}()
+	for {
+		select {
+		case event := <-events:
+			if err := stream.Send(event); err != nil {
+				return status.Errorf(codes.Unavailable, "Could not send over stream: %v", err)
+			}
+		case <-attSub.Err():
+			return status.Error(codes.Aborted, "Subscriber closed, exiting goroutine")
+		case <-propSub.Err():
+			return status.Error(codes.Aborted, "Subscriber closed, exiting goroutine")
+		case <-ss.ctx.Done():
+			return status.Error(codes.Canceled, "Context canceled")
+		case <-stream.Context().Done():
+			return status.Error(codes.Canceled, "Context canceled")
+		}
+	}
+}
+
+// slashingStatuses returns the slashing statuses to list slashings for, all
+// statuses if the requested status is unknown.
+func slashingStatuses(st slashpb.SlashingStatus) []types.SlashingStatus {
+	if st == slashpb.SlashingStatus_UNKNOWN {
+		return []types.SlashingStatus{types.Active, types.Included, types.Reverted}
+	}
+	return []types.SlashingStatus{types.SlashingStatus(st)}
+}
+
+func inEpochRange(epoch uint64, req *slashpb.ListSlashingsRequest) bool {
+	return epoch >= req.StartEpoch && (req.EndEpoch == 0 || epoch <= req.EndEpoch)
+}
@@ -0,0 +1,209 @@
+package rpc
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	ptypes "github.com/gogo/protobuf/types"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	slashpb "github.com/prysmaticlabs/prysm/proto/slashing"
+	"github.com/prysmaticlabs/prysm/shared/event"
+	testDB "github.com/prysmaticlabs/prysm/slasher/db/testing"
+	"github.com/prysmaticlabs/prysm/slasher/db/types"
+	"google.golang.org/grpc"
+)
+
+func attesterSlashingForTarget(target uint64, sig byte) *ethpb.AttesterSlashing {
+	att := func(source uint64, sig byte) *ethpb.IndexedAttestation {
+		return &ethpb.IndexedAttestation{
+			AttestingIndices: []uint64{1},
+			Data: &ethpb.AttestationData{
+				Source: &ethpb.Checkpoint{Epoch: source},
+				Target: &ethpb.Checkpoint{Epoch: target},
+			},
+			Signature: []byte{sig},
+		}
+	}
+	return &ethpb.AttesterSlashing{
+		Attestation_1: att(target-1, sig),
+		Attestation_2: att(target-2, sig+1),
+	}
+}
+
+func TestServer_ListAttesterSlashings(t *testing.T) {
+	db := testDB.SetupSlasherDB(t, false)
+	defer testDB.TeardownSlasherDB(t, db)
+	ctx := context.Background()
+	server := Server{ctx: ctx, slasherDB: db}
+
+	active := []*ethpb.AttesterSlashing{attesterSlashingForTarget(3, 1), attesterSlashingForTarget(8, 3)}
+	included := []*ethpb.AttesterSlashing{attesterSlashingForTarget(5, 5)}
+	if err := db.SaveAttesterSlashings(ctx, types.Active, active); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SaveAttesterSlashings(ctx, types.Included, included); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		req  *slashpb.ListSlashingsRequest
+		want []*ethpb.AttesterSlashing
+	}{
+		{
+			name: "by status",
+			req:  &slashpb.ListSlashingsRequest{Status: slashpb.SlashingStatus_INCLUDED},
+			want: included,
+		},
+		{
+			name: "by status and epoch range",
+			req:  &slashpb.ListSlashingsRequest{Status: slashpb.SlashingStatus_ACTIVE, StartEpoch: 4, EndEpoch: 10},
+			want: []*ethpb.AttesterSlashing{active[1]},
+		},
+		{
+			name: "any status without upper bound",
+			req:  &slashpb.ListSlashingsRequest{StartEpoch: 4},
+			want: []*ethpb.AttesterSlashing{active[1], included[0]},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := server.ListAttesterSlashings(ctx, tt.req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(res.AttesterSlashing) != len(tt.want) {
+				t.Fatalf("Wanted %d slashings, received %d", len(tt.want), len(res.AttesterSlashing))
+			}
+			for _, want := range tt.want {
+				found := false
+				for _, got := range res.AttesterSlashing {
+					if reflect.DeepEqual(got, want) {
+						found = true
+					}
+				}
+				if !found {
+					t.Errorf("Slashing %v was not listed", want)
+				}
+			}
+		})
+	}
+
+	if _, err := server.ListAttesterSlashings(ctx, &slashpb.ListSlashingsRequest{StartEpoch: 5, EndEpoch: 4}); err == nil {
+		t.Error("Expected error for start epoch greater than end epoch")
+	}
+}
+
+func TestServer_ListProposerSlashings(t *testing.T) {
+	db := testDB.SetupSlasherDB(t, false)
+	defer testDB.TeardownSlasherDB(t, db)
+	ctx := context.Background()
+	server := Server{ctx: ctx, slasherDB: db}
+
+	proposerSlashing := func(slot uint64) *ethpb.ProposerSlashing {
+		header := func(sig byte) *ethpb.SignedBeaconBlockHeader {
+			return &ethpb.SignedBeaconBlockHeader{
+				Header:    &ethpb.BeaconBlockHeader{Slot: slot, ProposerIndex: 1},
+				Signature: []byte{sig},
+			}
+		}
+		return &ethpb.ProposerSlashing{Header_1: header(1), Header_2: header(2)}
+	}
+	early := proposerSlashing(1)
+	late := proposerSlashing(100)
+	if err := db.SaveProposerSlashings(ctx, types.Active, []*ethpb.ProposerSlashing{early, late}); err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := server.ListProposerSlashings(ctx, &slashpb.ListSlashingsRequest{Status: slashpb.SlashingStatus_ACTIVE, StartEpoch: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.ProposerSlashing) != 1 || !reflect.DeepEqual(res.ProposerSlashing[0], late) {
+		t.Errorf("Wanted %v, received %v", []*ethpb.ProposerSlashing{late}, res.ProposerSlashing)
+	}
+}
+
+type mockSlashingsStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent chan *slashpb.SlashingEvent
+}
+
+func (m *mockSlashingsStream) Send(e *slashpb.SlashingEvent) error {
+	m.sent <- e
+	return nil
+}
+
+func (m *mockSlashingsStream) Context() context.Context {
+	return m.ctx
+}
+
+func TestServer_StreamSlashings(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	server := &Server{
+		ctx:                   ctx,
+		attesterSlashingsFeed: new(event.Feed),
+		proposerSlashingsFeed: new(event.Feed),
+	}
+	stream := &mockSlashingsStream{ctx: ctx, sent: make(chan *slashpb.SlashingEvent, 2)}
+	go func(tt *testing.T) {
+		if err := server.StreamSlashings(&ptypes.Empty{}, stream); err == nil {
+			tt.Error("Expected error on context cancellation")
+		}
+	}(t)
+
+	attSlashing := attesterSlashingForTarget(3, 1)
+	propSlashing := &ethpb.ProposerSlashing{
+		Header_1: &ethpb.SignedBeaconBlockHeader{Header: &ethpb.BeaconBlockHeader{Slot: 1}, Signature: []byte{1}},
+		Header_2: &ethpb.SignedBeaconBlockHeader{Header: &ethpb.BeaconBlockHeader{Slot: 1}, Signature: []byte{2}},
+	}
+	// Send in a loop to ensure it is delivered (busy wait for the service to subscribe to the feeds).
+	for sent := 0; sent == 0; {
+		sent = server.attesterSlashingsFeed.Send(attSlashing)
+	}
+	if e := <-stream.sent; !proto.Equal(e.AttesterSlashing, attSlashing) {
+		t.Errorf("Wanted attester slashing %v, received %v", attSlashing, e)
+	}
+	for sent := 0; sent == 0; {
+		sent = server.proposerSlashingsFeed.Send(propSlashing)
+	}
+	if e := <-stream.sent; !proto.Equal(e.ProposerSlashing, propSlashing) {
+		t.Errorf("Wanted proposer slashing %v, received %v", propSlashing, e)
+	}
+}
+
+func TestServer_StreamSlashings_SlowSubscriberDoesNotBlockFeed(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	server := &Server{
+		ctx:                   ctx,
+		attesterSlashingsFeed: new(event.Feed),
+		proposerSlashingsFeed: new(event.Feed),
+	}
+	// The stream never takes any slashing off its unbuffered channel.
+	stream := &mockSlashingsStream{ctx: ctx, sent: make(chan *slashpb.SlashingEvent)}
+	go func() {
+		_ = server.StreamSlashings(&ptypes.Empty{}, stream)
+	}()
+	for sent := 0; sent == 0; {
+		sent = server.attesterSlashingsFeed.Send(attesterSlashingForTarget(3, 1))
+	}
+
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < 2*streamSlashingsBufferSize; i++ {
+			server.attesterSlashingsFeed.Send(attesterSlashingForTarget(3, 1))
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Sending slashings over the feed was blocked by a slow stream")
+	}
+}
@@ -0,0 +1,60 @@
+package rpc
+
+import (
+	"context"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	slashpb "github.com/prysmaticlabs/prysm/proto/slashing"
+	"github.com/prysmaticlabs/prysm/shared/sliceutil"
+	"go.opencensus.io/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxValidatorHistoryEpochs is the maximum number of epochs the history
+// of a validator can be requested for at once.
+const maxValidatorHistoryEpochs = 256
+
+// GetValidatorHistory returns the attestations and min-max spans recorded for a validator
+// in the requested epoch range.
+func (ss *Server) GetValidatorHistory(ctx context.Context, req *slashpb.ValidatorHistoryRequest) (*slashpb.ValidatorHistory, error) {
+	ctx, span := trace.StartSpan(ctx, "rpc.GetValidatorHistory")
+	defer span.End()
+	if req.StartEpoch > req.EndEpoch {
+		return nil, status.Errorf(codes.InvalidArgument, "Start epoch %d cannot be greater than end epoch %d", req.StartEpoch, req.EndEpoch)
+	}
+	if req.EndEpoch-req.StartEpoch >= maxValidatorHistoryEpochs {
+		return nil, status.Errorf(codes.InvalidArgument, "Requested epoch range exceeds the maximum of %d epochs", maxValidatorHistoryEpochs)
+	}
+
+	var atts []*ethpb.IndexedAttestation
+	for epoch := req.StartEpoch; epoch <= req.EndEpoch; epoch++ {
+		epochAtts, err := ss.slasherDB.IndexedAttestationsForTarget(ctx, epoch)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Could not retrieve attestations for target epoch %d: %v", epoch, err)
+		}
+		for _, att := range epochAtts {
+			if sliceutil.IsInUint64(req.ValidatorIndex, att.AttestingIndices) {
+				atts = append(atts, att)
+			}
+		}
+	}
+
+	spans, err := ss.detector.ValidatorSpans(ctx, req.ValidatorIndex, req.StartEpoch, req.EndEpoch)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Could not retrieve spans: %v", err)
+	}
+	epochSpans := make([]*slashpb.ValidatorEpochSpan, len(spans))
+	for i, s := range spans {
+		epochSpans[i] = &slashpb.ValidatorEpochSpan{
+			Epoch:       req.StartEpoch + uint64(i),
+			MinSpan:     uint32(s.MinSpan),
+			MaxSpan:     uint32(s.MaxSpan),
+			HasAttested: s.HasAttested,
+		}
+	}
+	return &slashpb.ValidatorHistory{
+		Attestations: atts,
+		Spans:        epochSpans,
+	}, nil
+}
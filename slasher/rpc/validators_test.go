@@ -0,0 +1,69 @@
+package rpc
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	slashpb "github.com/prysmaticlabs/prysm/proto/slashing"
+	testDB "github.com/prysmaticlabs/prysm/slasher/db/testing"
+	"github.com/prysmaticlabs/prysm/slasher/detection"
+)
+
+func TestServer_GetValidatorHistory(t *testing.T) {
+	db := testDB.SetupSlasherDB(t, false)
+	defer testDB.TeardownSlasherDB(t, db)
+	ctx := context.Background()
+	ds := detection.NewDetectionService(ctx, &detection.Config{SlasherDB: db})
+	server := Server{ctx: ctx, detector: ds, slasherDB: db}
+
+	att := func(source uint64, target uint64, indices []uint64, sig byte) *ethpb.IndexedAttestation {
+		return &ethpb.IndexedAttestation{
+			AttestingIndices: indices,
+			Data: &ethpb.AttestationData{
+				Source: &ethpb.Checkpoint{Epoch: source},
+				Target: &ethpb.Checkpoint{Epoch: target},
+			},
+			Signature: []byte{sig},
+		}
+	}
+	atts := []*ethpb.IndexedAttestation{
+		att(1, 2, []uint64{1, 2}, 1),
+		att(2, 3, []uint64{2}, 2),
+		att(2, 4, []uint64{1}, 3),
+	}
+	for _, a := range atts {
+		if _, err := server.IsSlashableAttestation(ctx, a); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	res, err := server.GetValidatorHistory(ctx, &slashpb.ValidatorHistoryRequest{ValidatorIndex: 1, StartEpoch: 2, EndEpoch: 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantAtts := []*ethpb.IndexedAttestation{atts[0], atts[2]}
+	if !reflect.DeepEqual(res.Attestations, wantAtts) {
+		t.Errorf("Wanted attestations %v, received %v", wantAtts, res.Attestations)
+	}
+	if len(res.Spans) != 3 {
+		t.Fatalf("Wanted spans for 3 epochs, received %d", len(res.Spans))
+	}
+	for i, s := range res.Spans {
+		if s.Epoch != uint64(2+i) {
+			t.Errorf("Wanted span for epoch %d, received %d", 2+i, s.Epoch)
+		}
+	}
+	if !res.Spans[0].HasAttested || res.Spans[1].HasAttested || !res.Spans[2].HasAttested {
+		t.Errorf("Unexpected attested epochs in spans %v", res.Spans)
+	}
+	// The attestation from epoch 2 to 4 is recorded as a max span of 1 at epoch 3.
+	if res.Spans[1].MaxSpan != 1 {
+		t.Errorf("Wanted max span 1 at epoch 3, received %d", res.Spans[1].MaxSpan)
+	}
+
+	if _, err := server.GetValidatorHistory(ctx, &slashpb.ValidatorHistoryRequest{StartEpoch: 0, EndEpoch: maxValidatorHistoryEpochs}); err == nil {
+		t.Error("Expected error for epoch range exceeding the maximum")
+	}
+}
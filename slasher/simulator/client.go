@@ -0,0 +1,64 @@
+package simulator
+
+import (
+	"context"
+
+	ptypes "github.com/gogo/protobuf/types"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"google.golang.org/grpc"
+)
+
+// chainClient is a beacon chain client streaming the simulated blocks and indexed
+// attestations to the beacon client service, in place of a beacon node.
+type chainClient struct {
+	ethpb.BeaconChainClient
+	blocks chan *ethpb.SignedBeaconBlock
+	atts   chan *ethpb.IndexedAttestation
+}
+
+func newChainClient() *chainClient {
+	return &chainClient{
+		blocks: make(chan *ethpb.SignedBeaconBlock),
+		atts:   make(chan *ethpb.IndexedAttestation),
+	}
+}
+
+// StreamBlocks streams the simulated blocks until the context is done.
+func (c *chainClient) StreamBlocks(ctx context.Context, _ *ptypes.Empty, _ ...grpc.CallOption) (ethpb.BeaconChain_StreamBlocksClient, error) {
+	return &blocksStream{ctx: ctx, blocks: c.blocks}, nil
+}
+
+// StreamIndexedAttestations streams the simulated indexed attestations until the context is done.
+func (c *chainClient) StreamIndexedAttestations(ctx context.Context, _ *ptypes.Empty, _ ...grpc.CallOption) (ethpb.BeaconChain_StreamIndexedAttestationsClient, error) {
+	return &attestationsStream{ctx: ctx, atts: c.atts}, nil
+}
+
+type blocksStream struct {
+	grpc.ClientStream
+	ctx    context.Context
+	blocks chan *ethpb.SignedBeaconBlock
+}
+
+func (s *blocksStream) Recv() (*ethpb.SignedBeaconBlock, error) {
+	select {
+	case blk := <-s.blocks:
+		return blk, nil
+	case <-s.ctx.Done():
+		return nil, s.ctx.Err()
+	}
+}
+
+type attestationsStream struct {
+	grpc.ClientStream
+	ctx  context.Context
+	atts chan *ethpb.IndexedAttestation
+}
+
+func (s *attestationsStream) Recv() (*ethpb.IndexedAttestation, error) {
+	select {
+	case att := <-s.atts:
+		return att, nil
+	case <-s.ctx.Done():
+		return nil, s.ctx.Err()
+	}
+}
@@ -0,0 +1,155 @@
+package simulator
+
+import (
+	"context"
+
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/bytesutil"
+	"github.com/prysmaticlabs/prysm/shared/params"
+)
+
+// simulateEpoch streams the blocks and attestations of every slot of an epoch to the beacon client service.
+func (s *Simulator) simulateEpoch(ctx context.Context, epoch uint64) error {
+	slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+	for slot := epoch * slotsPerEpoch; slot < (epoch+1)*slotsPerEpoch; slot++ {
+		for _, blk := range s.generateBlocks(slot) {
+			select {
+			case s.chainClient.blocks <- blk:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		for _, att := range s.generateAttestations(slot) {
+			select {
+			case s.chainClient.atts <- att:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+	return nil
+}
+
+// generateBlocks returns the block of the proposer of a slot, and a second block
+// for the same slot if a double proposal is injected.
+func (s *Simulator) generateBlocks(slot uint64) []*ethpb.SignedBeaconBlock {
+	proposerIdx := slot % s.params.NumValidators
+	blocks := []*ethpb.SignedBeaconBlock{s.block(slot, proposerIdx, 0)}
+	if s.rand.Float64() < s.params.ProposerSlashingProbab {
+		blocks = append(blocks, s.block(slot, proposerIdx, 1))
+		s.injectedProposerSlashings[offense{validatorIdx: proposerIdx, slotOrEpoch: slot}] = true
+	}
+	return blocks
+}
+
+// generateAttestations returns the aggregated attestations of the committee of a slot, and the
+// double votes and surround votes injected for validators of the committee, in random order.
+// Committees consist of every validator with an index equal to the slot modulo the slots per epoch.
+func (s *Simulator) generateAttestations(slot uint64) []*ethpb.IndexedAttestation {
+	slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+	epoch := slot / slotsPerEpoch
+	var source uint64
+	if epoch > 0 {
+		source = epoch - 1
+	}
+	var indices []uint64
+	var injected []*ethpb.IndexedAttestation
+	for idx := slot % slotsPerEpoch; idx < s.params.NumValidators; idx += slotsPerEpoch {
+		switch {
+		case s.canSurround(idx, epoch) && s.rand.Float64() < s.params.AttesterSurroundVoteProbab:
+			// An attestation from 3 epochs ago surrounds the regular attestation of the previous epoch.
+			injected = append(injected, s.attestation(slot, []uint64{idx}, epoch-3, epoch, 0))
+			s.injectedAttesterSlashings[offense{validatorIdx: idx, slotOrEpoch: epoch}] = true
+			s.surroundEpochs[idx] = epoch
+		case s.rand.Float64() < s.params.AttesterDoubleVoteProbab:
+			indices = append(indices, idx)
+			injected = append(injected, s.attestation(slot, []uint64{idx}, source, epoch, 1))
+			s.injectedAttesterSlashings[offense{validatorIdx: idx, slotOrEpoch: epoch}] = true
+		default:
+			indices = append(indices, idx)
+		}
+	}
+	atts := injected
+	for _, aggregate := range s.aggregate(indices) {
+		atts = append(atts, s.attestation(slot, aggregate, source, epoch, 0))
+	}
+	s.rand.Shuffle(len(atts), func(i, j int) {
+		atts[i], atts[j] = atts[j], atts[i]
+	})
+	return atts
+}
+
+// aggregate splits the attesting indices of a committee into overlapping aggregates, like
+// the aggregates of the same data broadcast by several aggregators. Each validator is in
+// one aggregate, and in each of the others with a probability of one half.
+func (s *Simulator) aggregate(indices []uint64) [][]uint64 {
+	numAggregates := s.params.AggregatesPerCommittee
+	if numAggregates == 0 {
+		numAggregates = 1
+	}
+	aggregates := make([][]uint64, numAggregates)
+	for _, idx := range indices {
+		home := uint64(s.rand.Intn(int(numAggregates)))
+		for i := range aggregates {
+			if uint64(i) == home || s.rand.Float64() < 0.5 {
+				aggregates[i] = append(aggregates[i], idx)
+			}
+		}
+	}
+	var nonEmpty [][]uint64
+	for _, aggregate := range aggregates {
+		if len(aggregate) > 0 {
+			nonEmpty = append(nonEmpty, aggregate)
+		}
+	}
+	return nonEmpty
+}
+
+// canSurround returns true if the attestation of a validator for the previous epoch is
+// a regular attestation, which an attestation from 3 epochs ago to the epoch surrounds.
+func (s *Simulator) canSurround(validatorIdx uint64, epoch uint64) bool {
+	if epoch < 3 {
+		return false
+	}
+	last, ok := s.surroundEpochs[validatorIdx]
+	return !ok || last != epoch-1
+}
+
+func (s *Simulator) block(slot uint64, proposerIdx uint64, graffiti byte) *ethpb.SignedBeaconBlock {
+	return &ethpb.SignedBeaconBlock{
+		Block: &ethpb.BeaconBlock{
+			Slot:          slot,
+			ProposerIndex: proposerIdx,
+			ParentRoot:    make([]byte, 32),
+			StateRoot:     make([]byte, 32),
+			Body: &ethpb.BeaconBlockBody{
+				RandaoReveal: make([]byte, 96),
+				Eth1Data: &ethpb.Eth1Data{
+					DepositRoot: make([]byte, 32),
+					BlockHash:   make([]byte, 32),
+				},
+				Graffiti: bytesutil.PadTo([]byte{graffiti}, 32),
+			},
+		},
+		Signature: s.signature(),
+	}
+}
+
+func (s *Simulator) attestation(slot uint64, indices []uint64, source uint64, target uint64, blockRoot byte) *ethpb.IndexedAttestation {
+	return &ethpb.IndexedAttestation{
+		AttestingIndices: indices,
+		Data: &ethpb.AttestationData{
+			Slot:            slot,
+			BeaconBlockRoot: bytesutil.PadTo([]byte{blockRoot}, 32),
+			Source:          &ethpb.Checkpoint{Epoch: source, Root: make([]byte, 32)},
+			Target:          &ethpb.Checkpoint{Epoch: target, Root: make([]byte, 32)},
+		},
+		Signature: s.signature(),
+	}
+}
+
+// signature returns a unique signature, as attestations and block headers are stored by signature.
+func (s *Simulator) signature() []byte {
+	s.sigCounter++
+	return bytesutil.PadTo(bytesutil.Bytes8(s.sigCounter), 96)
+}
@@ -0,0 +1,35 @@
+package simulator
+
+import "github.com/prysmaticlabs/prysm/shared/params"
+
+// Parameters for a slasher simulation.
+type Parameters struct {
+	// NumValidators is the number of validators proposing blocks and attesting.
+	NumValidators uint64
+	// NumEpochs is the number of epochs of chain data generated.
+	NumEpochs uint64
+	// AggregatesPerCommittee is the number of overlapping aggregated attestations of the
+	// same data generated for each committee.
+	AggregatesPerCommittee uint64
+	// ProposerSlashingProbab is the probability of a proposer proposing a second block for its slot.
+	ProposerSlashingProbab float64
+	// AttesterDoubleVoteProbab is the probability of an attester casting a second,
+	// conflicting attestation for the same target epoch.
+	AttesterDoubleVoteProbab float64
+	// AttesterSurroundVoteProbab is the probability of an attester casting an attestation
+	// surrounding its attestation of the previous epoch, instead of its regular attestation.
+	AttesterSurroundVoteProbab float64
+}
+
+// DefaultParams returns the parameters of a simulation with 16 validators per slot
+// over 8 epochs, with 2 aggregates per committee.
+func DefaultParams() *Parameters {
+	return &Parameters{
+		NumValidators:              params.BeaconConfig().SlotsPerEpoch * 16,
+		NumEpochs:                  8,
+		AggregatesPerCommittee:     2,
+		ProposerSlashingProbab:     0.1,
+		AttesterDoubleVoteProbab:   0.05,
+		AttesterSurroundVoteProbab: 0.05,
+	}
+}
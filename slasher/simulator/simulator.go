@@ -0,0 +1,253 @@
+/*
+Package simulator generates streams of blocks and indexed attestations with injected
+double proposals, double votes and surround votes, and streams them to the slasher beacon
+client service in place of a beacon node, which feeds them into the detection service.
+It verifies every injected offense is detected, and no slashing is detected for an offense
+which was not injected.
+*/
+package simulator
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/event"
+	"github.com/prysmaticlabs/prysm/slasher/beaconclient"
+	"github.com/prysmaticlabs/prysm/slasher/db"
+	"github.com/prysmaticlabs/prysm/slasher/detection"
+	"github.com/sirupsen/logrus"
+)
+
+var log = logrus.WithField("prefix", "simulator")
+
+const (
+	// settlePeriod is the time detection keeps running after all injected offenses were
+	// detected, so that slashings detected for offenses which were not injected are caught.
+	settlePeriod = 2 * time.Second
+	// pollPeriod is the interval at which detected slashings are compared to injected offenses.
+	pollPeriod = 100 * time.Millisecond
+)
+
+// offense identifies a slashable offense by the offending validator and the slot of a double
+// proposal, or the latest target epoch of the attestations of an attester slashing.
+type offense struct {
+	validatorIdx uint64
+	slotOrEpoch  uint64
+}
+
+// Config options for the simulator.
+type Config struct {
+	Params    *Parameters
+	SlasherDB db.Database
+	// ChunkedSpans runs detection on min-max spans stored in fixed size chunks.
+	ChunkedSpans bool
+	// Seed of the random generation of offenses and aggregates.
+	Seed int64
+}
+
+// Simulator streams generated chain data to a beacon client service feeding
+// a detection service, and collects the detected slashings.
+type Simulator struct {
+	params                    *Parameters
+	slasherDB                 db.Database
+	beaconClient              *beaconclient.Service
+	chainClient               *chainClient
+	detection                 *detection.Service
+	rand                      *rand.Rand
+	attesterSlashingsFeed     *event.Feed
+	proposerSlashingsFeed     *event.Feed
+	sigCounter                uint64
+	surroundEpochs            map[uint64]uint64
+	injectedProposerSlashings map[offense]bool
+	injectedAttesterSlashings map[offense]bool
+	detectedLock              sync.Mutex
+	detectedProposerSlashings map[offense]bool
+	detectedAttesterSlashings map[offense]bool
+	invalidSlashings          int
+}
+
+// NewSimulator instantiates a simulator with a beacon client service receiving the
+// simulated chain data, and a detection service reading from its feeds.
+func NewSimulator(ctx context.Context, cfg *Config) (*Simulator, error) {
+	p := cfg.Params
+	if p == nil {
+		p = DefaultParams()
+	}
+	s := &Simulator{
+		params:                    p,
+		slasherDB:                 cfg.SlasherDB,
+		chainClient:               newChainClient(),
+		rand:                      rand.New(rand.NewSource(cfg.Seed)),
+		attesterSlashingsFeed:     new(event.Feed),
+		proposerSlashingsFeed:     new(event.Feed),
+		surroundEpochs:            make(map[uint64]uint64),
+		injectedProposerSlashings: make(map[offense]bool),
+		injectedAttesterSlashings: make(map[offense]bool),
+		detectedProposerSlashings: make(map[offense]bool),
+		detectedAttesterSlashings: make(map[offense]bool),
+	}
+	bs, err := beaconclient.NewBeaconClientService(ctx, &beaconclient.Config{
+		SlasherDB:             cfg.SlasherDB,
+		AttesterSlashingsFeed: s.attesterSlashingsFeed,
+		ProposerSlashingsFeed: s.proposerSlashingsFeed,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create beacon client service")
+	}
+	s.beaconClient = bs
+	s.detection = detection.NewDetectionService(ctx, &detection.Config{
+		Notifier:              bs,
+		SlasherDB:             cfg.SlasherDB,
+		ChainFetcher:          s,
+		AttesterSlashingsFeed: s.attesterSlashingsFeed,
+		ProposerSlashingsFeed: s.proposerSlashingsFeed,
+		ChunkedSpans:          cfg.ChunkedSpans,
+	})
+	return s, nil
+}
+
+// ChainHead returns an empty chain head. The simulator serves no historical chain data,
+// so historical detection has no epochs to run on.
+func (s *Simulator) ChainHead(ctx context.Context) (*ethpb.ChainHead, error) {
+	return &ethpb.ChainHead{}, nil
+}
+
+// Simulate streams the generated blocks and attestations of all epochs to the beacon client
+// service and waits until every injected offense is detected, or the context is done. It returns an
+// error if an injected offense was not detected, or a slashing was detected for an offense
+// which was not injected.
+func (s *Simulator) Simulate(ctx context.Context) error {
+	attesterSlashingsChan := make(chan *ethpb.AttesterSlashing, 1)
+	attSub := s.attesterSlashingsFeed.Subscribe(attesterSlashingsChan)
+	defer attSub.Unsubscribe()
+	proposerSlashingsChan := make(chan *ethpb.ProposerSlashing, 1)
+	propSub := s.proposerSlashingsFeed.Subscribe(proposerSlashingsChan)
+	defer propSub.Unsubscribe()
+
+	go s.detection.Start()
+	defer func() {
+		if err := s.detection.Stop(); err != nil {
+			log.WithError(err).Error("Could not stop detection service")
+		}
+	}()
+	defer func() {
+		if err := s.beaconClient.Stop(); err != nil {
+			log.WithError(err).Error("Could not stop beacon client service")
+		}
+	}()
+	if err := s.send(ctx, s.beaconClient.ClientReadyFeed(), true); err != nil {
+		return err
+	}
+	s.beaconClient.ReceiveChainData(s.chainClient)
+	collectCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go s.collectSlashings(collectCtx, attesterSlashingsChan, proposerSlashingsChan)
+
+	for epoch := uint64(0); epoch < s.params.NumEpochs; epoch++ {
+		if err := s.simulateEpoch(ctx, epoch); err != nil {
+			return errors.Wrapf(err, "could not simulate epoch %d", epoch)
+		}
+	}
+	log.WithFields(logrus.Fields{
+		"proposerSlashings": len(s.injectedProposerSlashings),
+		"attesterSlashings": len(s.injectedAttesterSlashings),
+	}).Info("Sent simulated chain data, waiting for injected offenses to be detected")
+	s.waitForDetection(ctx)
+	return s.verify()
+}
+
+// send sends a value over a feed once the feed has a subscriber, as the detection
+// service subscribes to the client ready feed asynchronously after it is started.
+func (s *Simulator) send(ctx context.Context, feed *event.Feed, value interface{}) error {
+	for feed.Send(value) == 0 {
+		select {
+		case <-time.After(10 * time.Millisecond):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// waitForDetection returns once all injected offenses were detected and detection
+// kept running for the settle period, or when the context is done.
+func (s *Simulator) waitForDetection(ctx context.Context) {
+	ticker := time.NewTicker(pollPeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if s.allDetected() {
+				select {
+				case <-time.After(settlePeriod):
+				case <-ctx.Done():
+				}
+				return
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (s *Simulator) allDetected() bool {
+	s.detectedLock.Lock()
+	defer s.detectedLock.Unlock()
+	for o := range s.injectedProposerSlashings {
+		if !s.detectedProposerSlashings[o] {
+			return false
+		}
+	}
+	for o := range s.injectedAttesterSlashings {
+		if !s.detectedAttesterSlashings[o] {
+			return false
+		}
+	}
+	return true
+}
+
+// verify compares the detected slashings to the injected offenses.
+func (s *Simulator) verify() error {
+	s.detectedLock.Lock()
+	defer s.detectedLock.Unlock()
+	missing := func(injected map[offense]bool, detected map[offense]bool, kind string) int {
+		var n int
+		for o := range injected {
+			if !detected[o] {
+				log.WithFields(logrus.Fields{
+					"validatorIndex": o.validatorIdx,
+					"slotOrEpoch":    o.slotOrEpoch,
+				}).Errorf("Injected %s was not detected", kind)
+				n++
+			}
+		}
+		return n
+	}
+	missingProposerSlashings := missing(s.injectedProposerSlashings, s.detectedProposerSlashings, "double proposal")
+	missingAttesterSlashings := missing(s.injectedAttesterSlashings, s.detectedAttesterSlashings, "attester offense")
+	falseProposerSlashings := missing(s.detectedProposerSlashings, s.injectedProposerSlashings, "false positive double proposal")
+	falseAttesterSlashings := missing(s.detectedAttesterSlashings, s.injectedAttesterSlashings, "false positive attester offense")
+
+	if missingProposerSlashings+missingAttesterSlashings+falseProposerSlashings+falseAttesterSlashings+s.invalidSlashings > 0 {
+		return errors.Errorf(
+			"%d of %d proposer slashings and %d of %d attester slashings not detected, "+
+				"%d proposer slashings and %d attester slashings falsely detected, %d invalid slashings detected",
+			missingProposerSlashings,
+			len(s.injectedProposerSlashings),
+			missingAttesterSlashings,
+			len(s.injectedAttesterSlashings),
+			falseProposerSlashings,
+			falseAttesterSlashings,
+			s.invalidSlashings,
+		)
+	}
+	log.WithFields(logrus.Fields{
+		"proposerSlashings": len(s.detectedProposerSlashings),
+		"attesterSlashings": len(s.detectedAttesterSlashings),
+	}).Info("All injected offenses were detected")
+	return nil
+}
@@ -0,0 +1,42 @@
+package simulator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/shared/params"
+	testDB "github.com/prysmaticlabs/prysm/slasher/db/testing"
+)
+
+func TestSimulator_DetectsInjectedOffenses(t *testing.T) {
+	for _, chunkedSpans := range []bool{false, true} {
+		db := testDB.SetupSlasherDB(t, false)
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		sim, err := NewSimulator(ctx, &Config{
+			Params: &Parameters{
+				// Committees span more than one chunk of validators.
+				NumValidators:              params.BeaconConfig().SlotsPerEpoch * 12,
+				NumEpochs:                  6,
+				AggregatesPerCommittee:     3,
+				ProposerSlashingProbab:     0.2,
+				AttesterDoubleVoteProbab:   0.1,
+				AttesterSurroundVoteProbab: 0.1,
+			},
+			SlasherDB:    db,
+			ChunkedSpans: chunkedSpans,
+			Seed:         1,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := sim.Simulate(ctx); err != nil {
+			t.Errorf("Chunked spans %v: %v", chunkedSpans, err)
+		}
+		if len(sim.injectedProposerSlashings) == 0 || len(sim.injectedAttesterSlashings) == 0 {
+			t.Errorf("Chunked spans %v: expected offenses to be injected", chunkedSpans)
+		}
+		cancel()
+		testDB.TeardownSlasherDB(t, db)
+	}
+}
@@ -0,0 +1,68 @@
+package simulator
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/gogo/protobuf/proto"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/prysm/shared/sliceutil"
+)
+
+// collectSlashings records the slashings sent by the detection service until the context is done.
+func (s *Simulator) collectSlashings(
+	ctx context.Context,
+	attesterSlashingsChan chan *ethpb.AttesterSlashing,
+	proposerSlashingsChan chan *ethpb.ProposerSlashing,
+) {
+	for {
+		select {
+		case slashing := <-attesterSlashingsChan:
+			s.recordAttesterSlashing(slashing)
+		case slashing := <-proposerSlashingsChan:
+			s.recordProposerSlashing(slashing)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// recordAttesterSlashing records the offense of every validator slashed by an attester slashing.
+func (s *Simulator) recordAttesterSlashing(slashing *ethpb.AttesterSlashing) {
+	s.detectedLock.Lock()
+	defer s.detectedLock.Unlock()
+	att1, att2 := slashing.Attestation_1, slashing.Attestation_2
+	if att1 == nil || att2 == nil || att1.Data == nil || att2.Data == nil {
+		s.invalidSlashings++
+		return
+	}
+	isDoubleVote := att1.Data.Target.Epoch == att2.Data.Target.Epoch && !proto.Equal(att1.Data, att2.Data)
+	isSurroundVote := att1.Data.Source.Epoch < att2.Data.Source.Epoch && att1.Data.Target.Epoch > att2.Data.Target.Epoch
+	indices := sliceutil.IntersectionUint64(att1.AttestingIndices, att2.AttestingIndices)
+	if (!isDoubleVote && !isSurroundVote) || len(indices) == 0 {
+		s.invalidSlashings++
+		return
+	}
+	epoch := att1.Data.Target.Epoch
+	if att2.Data.Target.Epoch > epoch {
+		epoch = att2.Data.Target.Epoch
+	}
+	for _, idx := range indices {
+		s.detectedAttesterSlashings[offense{validatorIdx: idx, slotOrEpoch: epoch}] = true
+	}
+}
+
+// recordProposerSlashing records the offense of the validator slashed by a proposer slashing.
+func (s *Simulator) recordProposerSlashing(slashing *ethpb.ProposerSlashing) {
+	s.detectedLock.Lock()
+	defer s.detectedLock.Unlock()
+	h1, h2 := slashing.Header_1, slashing.Header_2
+	if h1 == nil || h2 == nil || h1.Header == nil || h2.Header == nil ||
+		h1.Header.Slot != h2.Header.Slot ||
+		h1.Header.ProposerIndex != h2.Header.ProposerIndex ||
+		bytes.Equal(h1.Signature, h2.Signature) {
+		s.invalidSlashings++
+		return
+	}
+	s.detectedProposerSlashings[offense{validatorIdx: h1.Header.ProposerIndex, slotOrEpoch: h1.Header.Slot}] = true
+}
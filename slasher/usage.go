@@ -73,8 +73,15 @@ var appHelpFlagGroups = []flagGroup{
 			flags.BeaconCertFlag,
 			flags.KeyFlag,
 			flags.RPCPort,
-			flags.RebuildSpanMapsFlag,
+			flags.GRPCGatewayPort,
+			flags.ChunkedSpansFlag,
+			flags.HistoricalDetectionWorkersFlag,
+			flags.SlashingResubmitEpochsFlag,
+			flags.PruningEpochsFlag,
+			flags.DisableDBCompactionFlag,
 			flags.BeaconRPCProviderFlag,
+			flags.AdditionalBeaconRPCProvidersFlag,
+			flags.EnableGossipStreamsFlag,
 		},
 	},
 }
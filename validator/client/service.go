@@ -3,6 +3,7 @@ package client
 import (
 	"context"
 	"strings"
+	"time"
 
 	"github.com/dgraph-io/ristretto"
 	middleware "github.com/grpc-ecosystem/go-grpc-middleware"
@@ -14,6 +15,7 @@ import (
 	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
 	"github.com/prysmaticlabs/go-ssz"
 	"github.com/prysmaticlabs/prysm/beacon-chain/core/helpers"
+	slashpb "github.com/prysmaticlabs/prysm/proto/slashing"
 	"github.com/prysmaticlabs/prysm/shared/bls"
 	"github.com/prysmaticlabs/prysm/shared/bytesutil"
 	"github.com/prysmaticlabs/prysm/shared/params"
@@ -45,6 +47,11 @@ type ValidatorService struct {
 	maxCallRecvMsgSize   int
 	grpcRetries          uint
 	grpcHeaders          []string
+	slasherConn          *grpc.ClientConn
+	slasherEndpoint      string
+	slasherCert          string
+	slasherTimeout       time.Duration
+	slasherFailClosed    bool
 }
 
 // Config for the validator service.
@@ -59,6 +66,10 @@ type Config struct {
 	GrpcMaxCallRecvMsgSizeFlag int
 	GrpcRetriesFlag            uint
 	GrpcHeadersFlag            string
+	SlasherEndpoint            string
+	SlasherCertFlag            string
+	SlasherTimeout             time.Duration
+	SlasherFailClosed          bool
 }
 
 // NewValidatorService creates a new validator service for the service
@@ -78,6 +89,10 @@ func NewValidatorService(ctx context.Context, cfg *Config) (*ValidatorService, e
 		maxCallRecvMsgSize:   cfg.GrpcMaxCallRecvMsgSizeFlag,
 		grpcRetries:          cfg.GrpcRetriesFlag,
 		grpcHeaders:          strings.Split(cfg.GrpcHeadersFlag, ","),
+		slasherEndpoint:      cfg.SlasherEndpoint,
+		slasherCert:          cfg.SlasherCertFlag,
+		slasherTimeout:       cfg.SlasherTimeout,
+		slasherFailClosed:    cfg.SlasherFailClosed,
 	}, nil
 }
 
@@ -157,6 +172,21 @@ func (v *ValidatorService) Start() {
 	}
 
 	v.conn = conn
+	var slasherClient slashpb.SlasherClient
+	if v.slasherEndpoint != "" {
+		slasherConn, err := v.dialSlasher()
+		switch {
+		case err != nil && v.slasherFailClosed:
+			log.Errorf("Could not dial slasher endpoint: %s, %v", v.slasherEndpoint, err)
+			return
+		case err != nil:
+			log.WithError(err).Warnf("Could not dial slasher endpoint: %s, relying on local slashing protection only", v.slasherEndpoint)
+		default:
+			v.slasherConn = slasherConn
+			slasherClient = slashpb.NewSlasherClient(slasherConn)
+			log.WithField("endpoint", v.slasherEndpoint).Info("Checking attestations and blocks with slasher before submitting")
+		}
+	}
 	cache, err := ristretto.NewCache(&ristretto.Config{
 		NumCounters: 1280, // number of keys to track.
 		MaxCost:     128,  // maximum cost of cache, 1 item = 1 cost.
@@ -184,6 +214,9 @@ func (v *ValidatorService) Start() {
 		attLogs:                        make(map[[32]byte]*attSubmitted),
 		domainDataCache:                cache,
 		aggregatedSlotCommitteeIDCache: aggregatedSlotCommitteeIDCache,
+		slasherClient:                  slasherClient,
+		slasherTimeout:                 v.slasherTimeout,
+		slasherFailClosed:              v.slasherFailClosed,
 	}
 	go run(v.ctx, v.validator)
 }
@@ -192,12 +225,42 @@ func (v *ValidatorService) Start() {
 func (v *ValidatorService) Stop() error {
 	v.cancel()
 	log.Info("Stopping service")
+	if v.slasherConn != nil {
+		if err := v.slasherConn.Close(); err != nil {
+			log.WithError(err).Error("Could not close slasher connection")
+		}
+	}
 	if v.conn != nil {
 		return v.conn.Close()
 	}
 	return nil
 }
 
+// dialSlasher opens the gRPC connection to the slasher node which checks attestations
+// and blocks before they are submitted.
+func (v *ValidatorService) dialSlasher() (*grpc.ClientConn, error) {
+	var dialOpt grpc.DialOption
+	if v.slasherCert != "" {
+		creds, err := credentials.NewClientTLSFromFile(v.slasherCert, "")
+		if err != nil {
+			return nil, errors.Wrap(err, "could not get valid slasher credentials")
+		}
+		dialOpt = grpc.WithTransportCredentials(creds)
+	} else {
+		dialOpt = grpc.WithInsecure()
+		log.Warn("You are using an insecure slasher gRPC connection! Please provide a certificate to use a secure connection.")
+	}
+	return grpc.DialContext(v.ctx, v.slasherEndpoint,
+		dialOpt,
+		grpc.WithStatsHandler(&ocgrpc.ClientHandler{}),
+		grpc.WithUnaryInterceptor(middleware.ChainUnaryClient(
+			grpc_opentracing.UnaryClientInterceptor(),
+			grpc_prometheus.UnaryClientInterceptor,
+			logDebugRequestInfoUnaryInterceptor,
+		)),
+	)
+}
+
 // Status ...
 //
 // WIP - not done.
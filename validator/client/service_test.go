@@ -124,6 +124,43 @@ func TestLifecycle_Insecure(t *testing.T) {
 	testutil.AssertLogsContain(t, hook, "Stopping service")
 }
 
+func TestStart_SlasherDialFailure(t *testing.T) {
+	tests := []struct {
+		name        string
+		failClosed  bool
+		wantLog     string
+		wantStarted bool
+	}{
+		{name: "fail open", failClosed: false, wantLog: "relying on local slashing protection only", wantStarted: true},
+		{name: "fail closed", failClosed: true, wantLog: "Could not dial slasher endpoint", wantStarted: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hook := logTest.NewGlobal()
+			// Use canceled context so that the run function exits immediately.
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			validatorService := &ValidatorService{
+				ctx:               ctx,
+				cancel:            cancel,
+				endpoint:          "merkle tries",
+				keyManager:        keymanager.NewDirect(nil),
+				slasherEndpoint:   "merkle tries",
+				slasherCert:       "alice.crt",
+				slasherFailClosed: tt.failClosed,
+			}
+			validatorService.Start()
+			testutil.AssertLogsContain(t, hook, tt.wantLog)
+			if started := validatorService.validator != nil; started != tt.wantStarted {
+				t.Errorf("Expected validator started to be %v, received %v", tt.wantStarted, started)
+			}
+			if err := validatorService.Stop(); err != nil {
+				t.Fatalf("Could not stop service: %v", err)
+			}
+		})
+	}
+}
+
 func TestStatus_NoConnectionError(t *testing.T) {
 	validatorService := &ValidatorService{}
 	if err := validatorService.Status(); !strings.Contains(err.Error(), "no connection") {
@@ -0,0 +1,89 @@
+package client
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	"github.com/prysmaticlabs/go-ssz"
+	"go.opencensus.io/trace"
+)
+
+// slasherRejectsAttestation returns true if the slasher node detects the signed attestation
+// is a double vote or surround vote, or the slasher node cannot check it and the validator
+// fails closed. The slasher node records the attestation if it is not slashable.
+func (v *validator) slasherRejectsAttestation(ctx context.Context, att *ethpb.IndexedAttestation) bool {
+	if v.slasherClient == nil {
+		return false
+	}
+	ctx, span := trace.StartSpan(ctx, "validator.slasherRejectsAttestation")
+	defer span.End()
+
+	ctx, cancel := v.slasherContext(ctx)
+	defer cancel()
+	resp, err := v.slasherClient.IsSlashableAttestation(ctx, att)
+	if err != nil {
+		return v.slasherUnavailable(errors.Wrap(err, "could not check attestation with slasher"))
+	}
+	if len(resp.AttesterSlashing) > 0 {
+		log.WithField("targetEpoch", att.Data.Target.Epoch).Error("Slasher detected a slashable attestation, rejected")
+		return true
+	}
+	return false
+}
+
+// slasherRejectsBlock returns true if the slasher node detects the signed block is a
+// double proposal, or the slasher node cannot check it and the validator fails closed.
+// The slasher node records the block header if it is not slashable.
+func (v *validator) slasherRejectsBlock(ctx context.Context, blk *ethpb.SignedBeaconBlock) bool {
+	if v.slasherClient == nil {
+		return false
+	}
+	ctx, span := trace.StartSpan(ctx, "validator.slasherRejectsBlock")
+	defer span.End()
+
+	bodyRoot, err := ssz.HashTreeRoot(blk.Block.Body)
+	if err != nil {
+		return v.slasherUnavailable(errors.Wrap(err, "could not get block body root"))
+	}
+	header := &ethpb.SignedBeaconBlockHeader{
+		Header: &ethpb.BeaconBlockHeader{
+			Slot:          blk.Block.Slot,
+			ProposerIndex: blk.Block.ProposerIndex,
+			ParentRoot:    blk.Block.ParentRoot,
+			StateRoot:     blk.Block.StateRoot,
+			BodyRoot:      bodyRoot[:],
+		},
+		Signature: blk.Signature,
+	}
+
+	ctx, cancel := v.slasherContext(ctx)
+	defer cancel()
+	resp, err := v.slasherClient.IsSlashableBlock(ctx, header)
+	if err != nil {
+		return v.slasherUnavailable(errors.Wrap(err, "could not check block with slasher"))
+	}
+	if len(resp.ProposerSlashing) > 0 {
+		log.WithField("blockSlot", blk.Block.Slot).Error("Slasher detected a double proposal, rejected")
+		return true
+	}
+	return false
+}
+
+// slasherUnavailable applies the fail-closed or fail-open policy when the slasher node
+// could not check an attestation or block, and returns true if it is rejected.
+func (v *validator) slasherUnavailable(err error) bool {
+	if v.slasherFailClosed {
+		log.WithError(err).Error("Slasher unavailable, rejected")
+		return true
+	}
+	log.WithError(err).Warn("Slasher unavailable, relying on local slashing protection only")
+	return false
+}
+
+func (v *validator) slasherContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if v.slasherTimeout > 0 {
+		return context.WithTimeout(ctx, v.slasherTimeout)
+	}
+	return context.WithCancel(ctx)
+}
@@ -0,0 +1,161 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	ethpb "github.com/prysmaticlabs/ethereumapis/eth/v1alpha1"
+	slashpb "github.com/prysmaticlabs/prysm/proto/slashing"
+	"github.com/prysmaticlabs/prysm/shared/testutil"
+	logTest "github.com/sirupsen/logrus/hooks/test"
+	"google.golang.org/grpc"
+)
+
+// fakeSlasherClient answers slashable checks with fixed slashings or a fixed error.
+type fakeSlasherClient struct {
+	slashpb.SlasherClient
+	attesterSlashings []*ethpb.AttesterSlashing
+	proposerSlashings []*ethpb.ProposerSlashing
+	err               error
+	calls             int
+}
+
+func (f *fakeSlasherClient) IsSlashableAttestation(_ context.Context, _ *ethpb.IndexedAttestation, _ ...grpc.CallOption) (*slashpb.AttesterSlashingResponse, error) {
+	f.calls++
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &slashpb.AttesterSlashingResponse{AttesterSlashing: f.attesterSlashings}, nil
+}
+
+func (f *fakeSlasherClient) IsSlashableBlock(_ context.Context, _ *ethpb.SignedBeaconBlockHeader, _ ...grpc.CallOption) (*slashpb.ProposerSlashingResponse, error) {
+	f.calls++
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &slashpb.ProposerSlashingResponse{ProposerSlashing: f.proposerSlashings}, nil
+}
+
+func setupAttestationDuty(validator *validator, m *mocks) {
+	validator.duties = &ethpb.DutiesResponse{Duties: []*ethpb.DutiesResponse_Duty{
+		{
+			PublicKey:      validatorKey.PublicKey.Marshal(),
+			CommitteeIndex: 5,
+			Committee:      []uint64{0, 3, 4, 2, 7},
+			ValidatorIndex: 7,
+		}}}
+	m.validatorClient.EXPECT().GetAttestationData(
+		gomock.Any(), // ctx
+		gomock.AssignableToTypeOf(&ethpb.AttestationDataRequest{}),
+	).Return(&ethpb.AttestationData{
+		BeaconBlockRoot: []byte("A"),
+		Target:          &ethpb.Checkpoint{Root: []byte("B"), Epoch: 4},
+		Source:          &ethpb.Checkpoint{Root: []byte("C"), Epoch: 3},
+	}, nil)
+	m.validatorClient.EXPECT().DomainData(
+		gomock.Any(), // ctx
+		gomock.Any(), // epoch
+	).Return(&ethpb.DomainResponse{SignatureDomain: []byte{}}, nil /*err*/)
+}
+
+func setupBlockProposal(m *mocks) {
+	m.validatorClient.EXPECT().DomainData(
+		gomock.Any(), // ctx
+		gomock.Any(), //epoch
+	).Return(&ethpb.DomainResponse{}, nil /*err*/).Times(2)
+	m.validatorClient.EXPECT().GetBlock(
+		gomock.Any(), // ctx
+		gomock.Any(),
+	).Return(&ethpb.BeaconBlock{Body: &ethpb.BeaconBlockBody{}}, nil /*err*/)
+}
+
+func TestSubmitAttestation_SlasherRejectsSlashableAttestation(t *testing.T) {
+	hook := logTest.NewGlobal()
+	validator, m, finish := setup(t)
+	defer finish()
+	slasher := &fakeSlasherClient{attesterSlashings: []*ethpb.AttesterSlashing{{}}}
+	validator.slasherClient = slasher
+	setupAttestationDuty(validator, m)
+
+	validator.SubmitAttestation(context.Background(), 30, validatorPubKey)
+	if slasher.calls != 1 {
+		t.Errorf("Expected 1 call to slasher, received %d", slasher.calls)
+	}
+	testutil.AssertLogsContain(t, hook, "Slasher detected a slashable attestation, rejected")
+}
+
+func TestSubmitAttestation_SlasherUnavailable(t *testing.T) {
+	tests := []struct {
+		name       string
+		failClosed bool
+		wantLog    string
+	}{
+		{name: "fail open", failClosed: false, wantLog: "relying on local slashing protection only"},
+		{name: "fail closed", failClosed: true, wantLog: "Slasher unavailable, rejected"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hook := logTest.NewGlobal()
+			validator, m, finish := setup(t)
+			defer finish()
+			validator.slasherClient = &fakeSlasherClient{err: errors.New("connection refused")}
+			validator.slasherFailClosed = tt.failClosed
+			setupAttestationDuty(validator, m)
+			if !tt.failClosed {
+				m.validatorClient.EXPECT().ProposeAttestation(
+					gomock.Any(), // ctx
+					gomock.AssignableToTypeOf(&ethpb.Attestation{}),
+				).Return(&ethpb.AttestResponse{}, nil /* error */)
+			}
+
+			validator.SubmitAttestation(context.Background(), 30, validatorPubKey)
+			testutil.AssertLogsContain(t, hook, tt.wantLog)
+		})
+	}
+}
+
+func TestProposeBlock_SlasherRejectsDoubleProposal(t *testing.T) {
+	hook := logTest.NewGlobal()
+	validator, m, finish := setup(t)
+	defer finish()
+	slasher := &fakeSlasherClient{proposerSlashings: []*ethpb.ProposerSlashing{{}}}
+	validator.slasherClient = slasher
+	setupBlockProposal(m)
+
+	validator.ProposeBlock(context.Background(), 1, validatorPubKey)
+	if slasher.calls != 1 {
+		t.Errorf("Expected 1 call to slasher, received %d", slasher.calls)
+	}
+	testutil.AssertLogsContain(t, hook, "Slasher detected a double proposal, rejected")
+}
+
+func TestProposeBlock_SlasherAllowsBlock(t *testing.T) {
+	validator, m, finish := setup(t)
+	defer finish()
+	slasher := &fakeSlasherClient{}
+	validator.slasherClient = slasher
+	setupBlockProposal(m)
+	m.validatorClient.EXPECT().ProposeBlock(
+		gomock.Any(), // ctx
+		gomock.AssignableToTypeOf(&ethpb.SignedBeaconBlock{}),
+	).Return(&ethpb.ProposeResponse{}, nil /*error*/)
+
+	validator.ProposeBlock(context.Background(), 1, validatorPubKey)
+	if slasher.calls != 1 {
+		t.Errorf("Expected 1 call to slasher, received %d", slasher.calls)
+	}
+}
+
+func TestProposeBlock_SlasherUnavailable_FailClosed(t *testing.T) {
+	hook := logTest.NewGlobal()
+	validator, m, finish := setup(t)
+	defer finish()
+	validator.slasherClient = &fakeSlasherClient{err: errors.New("deadline exceeded")}
+	validator.slasherFailClosed = true
+	setupBlockProposal(m)
+
+	validator.ProposeBlock(context.Background(), 1, validatorPubKey)
+	testutil.AssertLogsContain(t, hook, "Slasher unavailable, rejected")
+}
@@ -114,6 +114,18 @@ func (v *validator) SubmitAttestation(ctx context.Context, slot uint64, pubKey [
 		return
 	}
 
+	indexedAtt := &ethpb.IndexedAttestation{
+		AttestingIndices: []uint64{duty.ValidatorIndex},
+		Data:             data,
+		Signature:        sig,
+	}
+	if v.slasherRejectsAttestation(ctx, indexedAtt) {
+		if v.emitAccountMetrics {
+			validatorAttestFailVec.WithLabelValues(fmtKey).Inc()
+		}
+		return
+	}
+
 	var indexInCommittee uint64
 	var found bool
 	for i, vID := range duty.Committee {
@@ -1,6 +1,8 @@
 package flags
 
 import (
+	"time"
+
 	"gopkg.in/urfave/cli.v2"
 )
 
@@ -81,6 +83,29 @@ var (
 		Name:  "password",
 		Usage: "String value of the password for your validator private keys",
 	}
+	// SlasherRPCProviderFlag defines a slasher node RPC endpoint consulted before signing.
+	SlasherRPCProviderFlag = &cli.StringFlag{
+		Name: "slasher-rpc-provider",
+		Usage: "Slasher node RPC provider endpoint. If set, attestations and blocks are checked " +
+			"with the slasher before they are submitted, in addition to the local slashing protection history",
+	}
+	// SlasherCertFlag defines a flag for the slasher node's TLS certificate.
+	SlasherCertFlag = &cli.StringFlag{
+		Name:  "slasher-tls-cert",
+		Usage: "Certificate for secure gRPC connections to the slasher node.",
+	}
+	// SlasherTimeoutFlag defines how long to wait for the slasher node to check an attestation or block.
+	SlasherTimeoutFlag = &cli.DurationFlag{
+		Name:  "slasher-timeout",
+		Usage: "Time to wait for the slasher node to check an attestation or block before it is considered unavailable",
+		Value: time.Second,
+	}
+	// SlasherFailClosedFlag refuses to submit attestations and blocks if the slasher node is unavailable.
+	SlasherFailClosedFlag = &cli.BoolFlag{
+		Name: "slasher-fail-closed",
+		Usage: "Do not submit attestations and blocks if the slasher node cannot check them. " +
+			"By default, only the local slashing protection history is used if the slasher node is unavailable",
+	}
 	// UnencryptedKeysFlag specifies a file path of a JSON file of unencrypted validator keys as an
 	// alternative from launching the validator client from decrypting a keystore directory.
 	UnencryptedKeysFlag = &cli.StringFlag{
@@ -50,6 +50,10 @@ var appFlags = []cli.Flag{
 	flags.KeyManager,
 	flags.KeyManagerOpts,
 	flags.AccountMetricsFlag,
+	flags.SlasherRPCProviderFlag,
+	flags.SlasherCertFlag,
+	flags.SlasherTimeoutFlag,
+	flags.SlasherFailClosedFlag,
 	cmd.VerbosityFlag,
 	cmd.DataDirFlag,
 	cmd.ClearDB,
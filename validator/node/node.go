@@ -188,6 +188,10 @@ func (s *ValidatorClient) registerClientService(ctx *cli.Context, keyManager key
 		GrpcMaxCallRecvMsgSizeFlag: maxCallRecvMsgSize,
 		GrpcRetriesFlag:            grpcRetries,
 		GrpcHeadersFlag:            ctx.String(flags.GrpcHeadersFlag.Name),
+		SlasherEndpoint:            ctx.String(flags.SlasherRPCProviderFlag.Name),
+		SlasherCertFlag:            ctx.String(flags.SlasherCertFlag.Name),
+		SlasherTimeout:             ctx.Duration(flags.SlasherTimeoutFlag.Name),
+		SlasherFailClosed:          ctx.Bool(flags.SlasherFailClosedFlag.Name),
 	})
 	if err != nil {
 		return errors.Wrap(err, "could not initialize client service")
@@ -85,6 +85,10 @@ var appHelpFlagGroups = []flagGroup{
 			flags.GrpcRetriesFlag,
 			flags.GrpcHeadersFlag,
 			flags.AccountMetricsFlag,
+			flags.SlasherRPCProviderFlag,
+			flags.SlasherCertFlag,
+			flags.SlasherTimeoutFlag,
+			flags.SlasherFailClosedFlag,
 		},
 	},
 	{